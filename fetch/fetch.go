@@ -0,0 +1,292 @@
+package fetch
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	observe "github.com/shah/observe-go"
+)
+
+// Defaults used for settings missing from HTTPFetchSettings
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultMaxRedirects = 10
+	DefaultUserAgent    = "lectiod (+https://github.com/lectio/lectiod)"
+	DefaultMaxBodyBytes = 10 * 1024 * 1024
+)
+
+// Policy controls how lectiod makes HTTP requests on behalf of a settings bundle
+type Policy struct {
+	Timeout              time.Duration
+	MaxRedirects         int
+	UserAgent            string
+	AcceptedContentTypes []string
+	MaxBodyBytes         int64
+	Proxy                *url.URL
+
+	// MaxConcurrentRequests of 0 means unlimited, so does MaxConcurrentRequestsPerHost
+	MaxConcurrentRequests        int
+	MaxConcurrentRequestsPerHost int
+	HostDelay                    time.Duration
+}
+
+// Limits of a Policy a PolicyError may report
+const (
+	MaxRedirectsLimit         = "maxRedirects"
+	AcceptedContentTypesLimit = "acceptedContentTypes"
+)
+
+// PolicyError is returned when a fetch is stopped because it violates the Policy
+type PolicyError struct {
+	URL    string
+	Limit  string
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("Fetch policy violated by %s: %s", e.URL, e.Reason)
+}
+
+// Hop is a single request made while following redirects
+type Hop struct {
+	URL        *url.URL
+	StatusCode int
+	Location   string
+	Latency    time.Duration
+}
+
+// Result is the outcome of a Fetch, when Fetch fails it only holds the requests made so far. Request, Proto
+// and Status describe the final exchange as it was made, so it can be archived.
+type Result struct {
+	URL         *url.URL
+	StatusCode  int
+	Header      http.Header
+	ContentType string
+	Body        []byte
+	Truncated   bool
+	Hops        []*Hop
+	Request     *http.Request
+	Proto       string
+	Status      string
+	FetchedAt   time.Time
+}
+
+// Client fetches URLs according to a Policy
+type Client struct {
+	observatory observe.Observatory
+	policy      *Policy
+	client      *http.Client
+	scheduler   *scheduler
+}
+
+// NewPolicy converts settings into a Policy, using defaults for missing values
+func NewPolicy(settings *models.HTTPFetchSettings) (*Policy, error) {
+	result := new(Policy)
+	result.Timeout = DefaultTimeout
+	result.MaxRedirects = DefaultMaxRedirects
+	result.UserAgent = DefaultUserAgent
+	result.MaxBodyBytes = DefaultMaxBodyBytes
+	if settings == nil {
+		return result, nil
+	}
+
+	if settings.TimeoutSeconds != nil && *settings.TimeoutSeconds > 0 {
+		result.Timeout = time.Duration(*settings.TimeoutSeconds) * time.Second
+	}
+	if settings.MaxRedirects != nil {
+		result.MaxRedirects = int(*settings.MaxRedirects)
+	}
+	if settings.UserAgent != nil && *settings.UserAgent != "" {
+		result.UserAgent = string(*settings.UserAgent)
+	}
+	for _, contentType := range settings.AcceptedContentTypes {
+		if contentType != nil && *contentType != "" {
+			result.AcceptedContentTypes = append(result.AcceptedContentTypes, strings.ToLower(string(*contentType)))
+		}
+	}
+	if settings.MaxBodyBytes != nil && *settings.MaxBodyBytes > 0 {
+		result.MaxBodyBytes = int64(*settings.MaxBodyBytes)
+	}
+	if settings.Proxy != nil && *settings.Proxy != "" {
+		proxy, err := url.Parse(string(*settings.Proxy))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid fetch proxy '%s': %v", *settings.Proxy, err)
+		}
+		result.Proxy = proxy
+	}
+	if settings.MaxConcurrentRequests != nil {
+		result.MaxConcurrentRequests = int(*settings.MaxConcurrentRequests)
+	}
+	if settings.MaxConcurrentRequestsPerHost != nil {
+		result.MaxConcurrentRequestsPerHost = int(*settings.MaxConcurrentRequestsPerHost)
+	}
+	if settings.HostDelayMilliseconds != nil {
+		result.HostDelay = time.Duration(*settings.HostDelayMilliseconds) * time.Millisecond
+	}
+	return result, nil
+}
+
+// NewClient constructs a Client which applies policy to every request
+func NewClient(observatory observe.Observatory, policy *Policy) *Client {
+	result := new(Client)
+	result.observatory = observatory
+	result.policy = policy
+	result.scheduler = newScheduler(policy)
+
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if policy.Proxy != nil {
+		transport.Proxy = http.ProxyURL(policy.Proxy)
+	}
+	result.client = &http.Client{
+		Timeout:   policy.Timeout,
+		Transport: transport,
+		// redirects are followed by Fetch so each hop can be recorded and counted
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return result
+}
+
+// Policy returns the policy the client applies
+func (c *Client) Policy() *Policy {
+	return c.policy
+}
+
+// IsAccepted returns true if contentType is allowed by Policy.AcceptedContentTypes; entries may be
+// exact media types (text/html) or wildcards (text/*)
+func (p *Policy) IsAccepted(contentType string) bool {
+	if len(p.AcceptedContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	for _, accepted := range p.AcceptedContentTypes {
+		if accepted == mediaType || accepted == "*/*" {
+			return true
+		}
+		if strings.HasSuffix(accepted, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(accepted, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestFilter is asked before every request Fetch makes, including those for redirects; a non-nil
+// error stops the fetch
+type RequestFilter func(u *url.URL, span opentracing.Span) error
+
+// Fetch requests rawURL, following HTTP redirects up to Policy.MaxRedirects, and reads at most
+// Policy.MaxBodyBytes of the final response's body. Each request waits for the scheduler first. If
+// filter (which may be nil) rejects a request, its error is returned along with the partial result
+// whose URL is the rejected one. Other errors are also returned with the partial result so the hops
+// made before the failure are known.
+func (c *Client) Fetch(rawURL string, filter RequestFilter, parent opentracing.Span) (*Result, error) {
+	span := c.observatory.StartChildTrace("fetch.Fetch", parent)
+	defer span.Finish()
+	span.LogFields(log.String("url", rawURL))
+	return c.fetch(rawURL, filter, true, span)
+}
+
+// FetchRobotsTxt requests the robots.txt file which applies to u, whatever its content type
+func (c *Client) FetchRobotsTxt(u *url.URL, parent opentracing.Span) (*Result, error) {
+	span := c.observatory.StartChildTrace("fetch.FetchRobotsTxt", parent)
+	defer span.Finish()
+	rawURL := RobotsTxtURL(u)
+	span.LogFields(log.String("url", rawURL))
+	return c.fetch(rawURL, nil, false, span)
+}
+
+func (c *Client) fetch(rawURL string, filter RequestFilter, checkContentType bool, span opentracing.Span) (*Result, error) {
+
+	result := new(Result)
+	fail := func(err error) (*Result, error) {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+		return result, err
+	}
+
+	current, err := url.Parse(rawURL)
+	if err != nil {
+		return fail(err)
+	}
+	for {
+		if filter != nil {
+			err = filter(current, span)
+			if err != nil {
+				result.URL = current
+				span.LogFields(log.String("filtered", current.String()), log.Error(err))
+				return result, err
+			}
+		}
+
+		request, err := http.NewRequest(http.MethodGet, current.String(), nil)
+		if err != nil {
+			return fail(err)
+		}
+		request.Header.Set("User-Agent", c.policy.UserAgent)
+
+		release := c.scheduler.acquire(current.Host, span)
+		started := time.Now()
+		response, err := c.client.Do(request)
+		if err != nil {
+			release()
+			return fail(err)
+		}
+		hop := &Hop{URL: current, StatusCode: response.StatusCode, Latency: time.Since(started)}
+		result.Hops = append(result.Hops, hop)
+
+		if response.StatusCode >= 300 && response.StatusCode < 400 && response.Header.Get("Location") != "" {
+			response.Body.Close()
+			release()
+			hop.Location = response.Header.Get("Location")
+			if len(result.Hops) > c.policy.MaxRedirects {
+				return fail(&PolicyError{URL: rawURL, Limit: MaxRedirectsLimit, Reason: fmt.Sprintf("more than %d redirects", c.policy.MaxRedirects)})
+			}
+			next, err := current.Parse(hop.Location)
+			if err != nil {
+				return fail(fmt.Errorf("Invalid redirect location '%s' from %s: %v", hop.Location, current, err))
+			}
+			current = next
+			continue
+		}
+
+		defer release()
+		defer response.Body.Close()
+		result.URL = current
+		result.StatusCode = response.StatusCode
+		result.Header = response.Header
+		result.Request = request
+		result.Proto = response.Proto
+		result.Status = response.Status
+		result.FetchedAt = started
+		result.ContentType = response.Header.Get("Content-Type")
+		if checkContentType && !c.policy.IsAccepted(result.ContentType) {
+			return fail(&PolicyError{URL: rawURL, Limit: AcceptedContentTypesLimit, Reason: fmt.Sprintf("content type '%s' is not accepted", result.ContentType)})
+		}
+
+		body, err := ioutil.ReadAll(io.LimitReader(response.Body, c.policy.MaxBodyBytes+1))
+		if err != nil {
+			return fail(err)
+		}
+		if int64(len(body)) > c.policy.MaxBodyBytes {
+			body = body[:c.policy.MaxBodyBytes]
+			result.Truncated = true
+		}
+		result.Body = body
+		span.LogFields(log.Int("status", result.StatusCode), log.Int("hops", len(result.Hops)), log.Int("bytes", len(body)), log.Bool("truncated", result.Truncated))
+		return result, nil
+	}
+}
@@ -0,0 +1,146 @@
+package fetch
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	observe "github.com/shah/observe-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type FetchSuite struct {
+	suite.Suite
+	observatory observe.Observatory
+	span        opentracing.Span
+	server      *httptest.Server
+}
+
+func (suite *FetchSuite) SetupSuite() {
+	observatory := observe.MakeObservatoryFromEnv()
+	suite.observatory = observatory
+	suite.span = observatory.StartTrace("FetchSuite")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<html><body>page</body></html>")
+	})
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "0123456789")
+	})
+	mux.HandleFunc("/user-agent", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, r.UserAgent())
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/redirect/", func(w http.ResponseWriter, r *http.Request) {
+		// /redirect/N redirects N times before ending at /page
+		var remaining int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/redirect/"), "%d", &remaining)
+		if remaining <= 1 {
+			http.Redirect(w, r, "/page", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, fmt.Sprintf("/redirect/%d", remaining-1), http.StatusMovedPermanently)
+	})
+	suite.server = httptest.NewServer(mux)
+}
+
+func (suite *FetchSuite) TearDownSuite() {
+	suite.server.Close()
+	suite.span.Finish()
+	suite.observatory.Close()
+}
+
+func (suite *FetchSuite) newClient(configure func(*Policy)) *Client {
+	policy, err := NewPolicy(nil)
+	suite.Nil(err, "Unable to create default policy")
+	if configure != nil {
+		configure(policy)
+	}
+	return NewClient(suite.observatory, policy)
+}
+
+func (suite *FetchSuite) TestFetch() {
+	tests := []struct {
+		name        string
+		path        string
+		configure   func(*Policy)
+		status      int
+		hops        int
+		body        string
+		truncated   bool
+		policyLimit string
+	}{
+		{name: "ok", path: "/page", status: 200, hops: 1, body: "<html><body>page</body></html>"},
+		{name: "not found isn't an error", path: "/missing", status: 404, hops: 1},
+		{name: "redirects are followed", path: "/redirect/3", status: 200, hops: 4, body: "<html><body>page</body></html>"},
+		{name: "redirects within limit", path: "/redirect/2", configure: func(p *Policy) { p.MaxRedirects = 2 }, status: 200, hops: 3},
+		{name: "too many redirects", path: "/redirect/3", configure: func(p *Policy) { p.MaxRedirects = 2 }, hops: 3, policyLimit: MaxRedirectsLimit},
+		{name: "accepted content type", path: "/page", configure: func(p *Policy) { p.AcceptedContentTypes = []string{"text/html"} }, status: 200, hops: 1},
+		{name: "accepted wildcard", path: "/text", configure: func(p *Policy) { p.AcceptedContentTypes = []string{"text/*"} }, status: 200, hops: 1, body: "0123456789"},
+		{name: "rejected content type", path: "/text", configure: func(p *Policy) { p.AcceptedContentTypes = []string{"text/html"} }, hops: 1, policyLimit: AcceptedContentTypesLimit},
+		{name: "truncated body", path: "/text", configure: func(p *Policy) { p.MaxBodyBytes = 4 }, status: 200, hops: 1, body: "0123", truncated: true},
+		{name: "user agent", path: "/user-agent", configure: func(p *Policy) { p.UserAgent = "lectiod-test" }, status: 200, hops: 1, body: "lectiod-test"},
+	}
+	for _, test := range tests {
+		client := suite.newClient(test.configure)
+		result, err := client.Fetch(suite.server.URL+test.path, nil, suite.span)
+		suite.Len(result.Hops, test.hops, test.name)
+		if test.policyLimit != "" {
+			policyError, ok := err.(*PolicyError)
+			if suite.True(ok, "%s: expected a PolicyError, got %v", test.name, err) {
+				suite.Equal(test.policyLimit, policyError.Limit, test.name)
+			}
+			continue
+		}
+		if !suite.Nil(err, test.name) {
+			continue
+		}
+		suite.Equal(test.status, result.StatusCode, test.name)
+		suite.Equal(test.truncated, result.Truncated, test.name)
+		if test.body != "" {
+			suite.Equal(test.body, string(result.Body), test.name)
+		}
+	}
+}
+
+func (suite *FetchSuite) TestFetchFilter() {
+	client := suite.newClient(nil)
+	rejected := fmt.Errorf("Rejected")
+	filter := func(u *url.URL, span opentracing.Span) error {
+		if u.Path == "/page" {
+			return rejected
+		}
+		return nil
+	}
+
+	result, err := client.Fetch(suite.server.URL+"/redirect/1", filter, suite.span)
+	suite.Equal(rejected, err, "The redirect target should be filtered")
+	suite.Equal("/page", result.URL.Path, "The result should hold the rejected URL")
+	suite.Len(result.Hops, 1, "Only the redirect should have been requested")
+}
+
+func (suite *FetchSuite) TestNewPolicy() {
+	policy, err := NewPolicy(nil)
+	suite.Nil(err, "Defaults shouldn't fail")
+	suite.Equal(DefaultTimeout, policy.Timeout)
+	suite.Equal(DefaultMaxRedirects, policy.MaxRedirects)
+	suite.Equal(DefaultUserAgent, policy.UserAgent)
+	suite.Equal(int64(DefaultMaxBodyBytes), policy.MaxBodyBytes)
+	suite.Equal(0, policy.MaxConcurrentRequests, "Requests shouldn't be limited unless configured")
+	suite.Equal(0, policy.MaxConcurrentRequestsPerHost, "Requests per host shouldn't be limited unless configured")
+	suite.True(policy.IsAccepted("application/pdf"), "Without accepted types everything is accepted")
+}
+
+func TestFetchSuite(t *testing.T) {
+	suite.Run(t, new(FetchSuite))
+}
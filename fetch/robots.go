@@ -0,0 +1,155 @@
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// RobotsTxt holds the rules of a host's robots.txt file
+type RobotsTxt struct {
+	groups []*robotsGroup
+}
+
+type robotsGroup struct {
+	agents []string
+	rules  []*robotsRule
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	regEx   *regexp.Regexp
+}
+
+func (r *robotsRule) String() string {
+	if r.allow {
+		return "Allow: " + r.pattern
+	}
+	return "Disallow: " + r.pattern
+}
+
+// NewRobotsTxt interprets the response to a robots.txt request: a missing file (4xx) allows everything,
+// a server error (5xx) disallows everything and anything else is parsed. A statusCode of 0, for a request
+// which got no response, allows everything too.
+func NewRobotsTxt(statusCode int, body []byte) *RobotsTxt {
+	switch {
+	case statusCode == 0, statusCode >= 400 && statusCode < 500:
+		return new(RobotsTxt)
+	case statusCode >= 500:
+		return ParseRobotsTxt([]byte("User-agent: *\nDisallow: /\n"))
+	}
+	return ParseRobotsTxt(body)
+}
+
+// ParseRobotsTxt parses the contents of a robots.txt file, unknown lines are ignored
+func ParseRobotsTxt(body []byte) *RobotsTxt {
+	result := new(RobotsTxt)
+	var group *robotsGroup
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if index := strings.Index(line, "#"); index >= 0 {
+			line = line[:index]
+		}
+		colon := strings.Index(line, ":")
+		if colon < 0 {
+			continue
+		}
+		field := strings.ToLower(strings.TrimSpace(line[:colon]))
+		value := strings.TrimSpace(line[colon+1:])
+
+		switch field {
+		case "user-agent":
+			// consecutive user-agent lines share a group
+			if group == nil || len(group.rules) > 0 {
+				group = new(robotsGroup)
+				result.groups = append(result.groups, group)
+			}
+			// an empty agent names nobody, it would otherwise match every product
+			if value == "" {
+				continue
+			}
+			group.agents = append(group.agents, strings.ToLower(value))
+		case "allow", "disallow":
+			if group == nil || value == "" {
+				continue
+			}
+			group.rules = append(group.rules, &robotsRule{allow: field == "allow", pattern: value, regEx: robotsPatternRegEx(value)})
+		}
+	}
+	return result
+}
+
+// robotsPatternRegEx converts a path pattern, where * matches anything and a trailing $ anchors the end, into a regexp
+func robotsPatternRegEx(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+// group returns the rules which apply to userAgent: the group naming its product token, else the * group
+func (r *RobotsTxt) group(userAgent string) []*robotsRule {
+	product := strings.ToLower(strings.Fields(userAgent + " ")[0])
+	if index := strings.Index(product, "/"); index >= 0 {
+		product = product[:index]
+	}
+
+	var matched, wildcard []*robotsRule
+	for _, group := range r.groups {
+		for _, agent := range group.agents {
+			switch {
+			case agent == "*":
+				wildcard = append(wildcard, group.rules...)
+			case product != "" && strings.Contains(product, agent):
+				matched = append(matched, group.rules...)
+			}
+		}
+	}
+	if matched != nil {
+		return matched
+	}
+	return wildcard
+}
+
+// Allowed reports whether userAgent may request u and, if a rule decided that, the rule. The longest
+// matching pattern wins, Allow wins a tie.
+func (r *RobotsTxt) Allowed(userAgent string, u *url.URL) (bool, string) {
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+
+	var decided *robotsRule
+	for _, rule := range r.group(userAgent) {
+		if !rule.regEx.MatchString(path) {
+			continue
+		}
+		if decided == nil || len(rule.pattern) > len(decided.pattern) || (len(rule.pattern) == len(decided.pattern) && rule.allow) {
+			decided = rule
+		}
+	}
+	if decided == nil {
+		return true, ""
+	}
+	return decided.allow, decided.String()
+}
+
+// RobotsTxtURL returns the location of the robots.txt file which applies to u
+func RobotsTxtURL(u *url.URL) string {
+	return fmt.Sprintf("%s://%s/robots.txt", u.Scheme, u.Host)
+}
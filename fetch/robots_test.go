@@ -0,0 +1,68 @@
+package fetch
+
+import (
+	"net/url"
+)
+
+func (suite *FetchSuite) TestRobotsTxtAllowed() {
+	robotsTxt := `# comments and unknown lines are ignored
+Sitemap: https://example.com/sitemap.xml
+
+User-agent: *
+Disallow: /private/
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: lectiod
+User-agent: otherbot
+Disallow: /no-lectiod/
+
+User-agent:
+Disallow: /nobody/
+`
+	tests := []struct {
+		name      string
+		userAgent string
+		path      string
+		allowed   bool
+		rule      string
+	}{
+		{name: "no matching rule", userAgent: "somebot/1.0", path: "/", allowed: true},
+		{name: "disallowed prefix", userAgent: "somebot/1.0", path: "/private/page", rule: "Disallow: /private/"},
+		{name: "longer allow wins", userAgent: "somebot/1.0", path: "/private/public/page", allowed: true, rule: "Allow: /private/public"},
+		{name: "anchored wildcard", userAgent: "somebot/1.0", path: "/files/report.pdf", rule: "Disallow: /*.pdf$"},
+		{name: "anchored wildcard doesn't match a longer path", userAgent: "somebot/1.0", path: "/files/report.pdf.html", allowed: true},
+		{name: "named group replaces the wildcard group", userAgent: DefaultUserAgent, path: "/private/page", allowed: true},
+		{name: "named group", userAgent: DefaultUserAgent, path: "/no-lectiod/page", rule: "Disallow: /no-lectiod/"},
+		{name: "shared group", userAgent: "OtherBot/2.0", path: "/no-lectiod/page", rule: "Disallow: /no-lectiod/"},
+		{name: "empty user-agent names nobody", userAgent: "somebot/1.0", path: "/nobody/page", allowed: true},
+		{name: "empty user-agent doesn't match named agents", userAgent: DefaultUserAgent, path: "/nobody/page", allowed: true},
+	}
+	robots := ParseRobotsTxt([]byte(robotsTxt))
+	for _, test := range tests {
+		u, err := url.Parse("https://example.com" + test.path)
+		suite.Nil(err, test.name)
+		allowed, rule := robots.Allowed(test.userAgent, u)
+		suite.Equal(test.allowed, allowed, test.name)
+		suite.Equal(test.rule, rule, test.name)
+	}
+}
+
+func (suite *FetchSuite) TestNewRobotsTxt() {
+	tests := []struct {
+		name       string
+		statusCode int
+		allowed    bool
+	}{
+		{name: "parsed", statusCode: 200},
+		{name: "missing file allows everything", statusCode: 404, allowed: true},
+		{name: "server error disallows everything", statusCode: 503},
+		{name: "no response allows everything", statusCode: 0, allowed: true},
+	}
+	u, _ := url.Parse("https://example.com/page")
+	for _, test := range tests {
+		robots := NewRobotsTxt(test.statusCode, []byte("User-agent: *\nDisallow: /\n"))
+		allowed, _ := robots.Allowed(DefaultUserAgent, u)
+		suite.Equal(test.allowed, allowed, test.name)
+	}
+}
@@ -0,0 +1,138 @@
+package fetch
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// scheduler limits how many requests run at the same time, in total and per host, and spaces out
+// the requests made to each host by at least Policy.HostDelay
+type scheduler struct {
+	global  chan struct{}
+	perHost int
+	delay   time.Duration
+
+	mutex sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	slots chan struct{}
+	// requests counts the requests holding or waiting for the host, it's guarded by the scheduler's mutex
+	requests int
+
+	mutex       sync.Mutex
+	nextRequest time.Time
+}
+
+func newScheduler(policy *Policy) *scheduler {
+	result := new(scheduler)
+	if policy.MaxConcurrentRequests > 0 {
+		result.global = make(chan struct{}, policy.MaxConcurrentRequests)
+	}
+	result.perHost = policy.MaxConcurrentRequestsPerHost
+	result.delay = policy.HostDelay
+	result.hosts = make(map[string]*hostState)
+	return result
+}
+
+// host returns the state of a host and counts the request it's returned for until release is called
+func (s *scheduler) host(name string) *hostState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := s.hosts[name]
+	if result == nil {
+		result = new(hostState)
+		if s.perHost > 0 {
+			result.slots = make(chan struct{}, s.perHost)
+		}
+		s.hosts[name] = result
+	}
+	result.requests++
+	return result
+}
+
+// release ends a request to a host returned by host
+func (s *scheduler) release(name string, state *hostState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	state.requests--
+	s.prune(name, state)
+}
+
+// prune forgets a host once no request holds or waits for it and its delay has passed, so hosts
+// aren't kept after they're fetched. The caller holds the mutex.
+func (s *scheduler) prune(name string, state *hostState) {
+	if state.requests > 0 || s.hosts[name] != state {
+		return
+	}
+	state.mutex.Lock()
+	wait := time.Until(state.nextRequest)
+	state.mutex.Unlock()
+	if wait > 0 {
+		time.AfterFunc(wait, func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			s.prune(name, state)
+		})
+		return
+	}
+	delete(s.hosts, name)
+}
+
+// acquire blocks until a request to host may start and returns the function which must be called
+// once the request has finished. Every wait is logged as an event on span.
+func (s *scheduler) acquire(host string, span opentracing.Span) func() {
+	name := strings.ToLower(host)
+	state := s.host(name)
+
+	// a host slot is taken before the global one so requests queued for a busy host don't hold up other hosts
+	if state.slots != nil {
+		started := time.Now()
+		state.slots <- struct{}{}
+		logWait(span, "host concurrency", host, time.Since(started))
+	}
+
+	if s.delay > 0 {
+		state.mutex.Lock()
+		now := time.Now()
+		start := state.nextRequest
+		if start.Before(now) {
+			start = now
+		}
+		state.nextRequest = start.Add(s.delay)
+		state.mutex.Unlock()
+		if wait := start.Sub(now); wait > 0 {
+			time.Sleep(wait)
+			logWait(span, "host delay", host, wait)
+		}
+	}
+
+	if s.global != nil {
+		started := time.Now()
+		s.global <- struct{}{}
+		logWait(span, "global concurrency", host, time.Since(started))
+	}
+
+	return func() {
+		if s.global != nil {
+			<-s.global
+		}
+		if state.slots != nil {
+			<-state.slots
+		}
+		s.release(name, state)
+	}
+}
+
+// logWait records waits long enough to matter, acquiring a free slot takes microseconds
+func logWait(span opentracing.Span, limit string, host string, waited time.Duration) {
+	if waited < time.Millisecond {
+		return
+	}
+	span.LogFields(log.String("event", "fetch.wait"), log.String("limit", limit), log.String("host", host), log.String("waited", waited.String()))
+}
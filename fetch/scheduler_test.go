@@ -0,0 +1,38 @@
+package fetch
+
+import "time"
+
+// hostsCount returns the number of hosts the scheduler keeps state for
+func (s *scheduler) hostsCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.hosts)
+}
+
+func (suite *FetchSuite) TestSchedulerForgetsIdleHosts() {
+	busy := newScheduler(&Policy{MaxConcurrentRequestsPerHost: 1})
+	first := busy.acquire("Example.com", suite.span)
+	started := make(chan func())
+	go func() {
+		started <- busy.acquire("example.com", suite.span)
+	}()
+	select {
+	case <-started:
+		suite.Fail("The host's limit should hold the second request")
+	case <-time.After(20 * time.Millisecond):
+	}
+	suite.Equal(1, busy.hostsCount(), "Hosts should be kept while requests wait for them")
+	first()
+	second := <-started
+	suite.Equal(1, busy.hostsCount(), "Hosts should be kept while requests hold them")
+	second()
+	suite.Equal(0, busy.hostsCount(), "Idle hosts should be forgotten")
+
+	delayed := newScheduler(&Policy{HostDelay: 50 * time.Millisecond})
+	delayed.acquire("example.com", suite.span)()
+	suite.Equal(1, delayed.hostsCount(), "Hosts should be kept until their delay has passed")
+	for i := 0; i < 50 && delayed.hostsCount() > 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	suite.Equal(0, delayed.hostsCount(), "Hosts should be forgotten once their delay has passed")
+}
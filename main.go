@@ -1,10 +1,16 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
 
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+	"github.com/lectio/lectiod/resolvers"
 	"github.com/lectio/lectiod/server"
+	opentracing "github.com/opentracing/opentracing-go"
 	observe "github.com/shah/observe-go"
 )
 
@@ -12,6 +18,99 @@ func configPathProvider(configName string) []string {
 	return []string{"conf"}
 }
 
+// rotateStorageKeys implements `lectiod rotate-storage-keys [-new-master-key]`, which re-encrypts the
+// records of every settings bundle that has storage encryption enabled with fresh data keys. The daemon
+// caches data keys so this refuses to run while it has the store open.
+func rotateStorageKeys(observatory observe.Observatory, args []string, parent opentracing.Span) error {
+	span := observatory.StartChildTrace("main.rotateStorageKeys", parent)
+	defer span.Finish()
+
+	flags := flag.NewFlagSet("rotate-storage-keys", flag.ExitOnError)
+	newMasterKey := flags.Bool("new-master-key", false, "add a new master key to each bundle's key file and make it active before rotating")
+	flags.Parse(args)
+
+	handler := resolvers.NewSchemaResolvers(observatory, configPathProvider, span)
+	defer handler.Close()
+
+	for name, config := range handler.Configurations() {
+		encryption := config.Settings().Storage.Encryption
+		if encryption == nil {
+			fmt.Printf("%s: storage encryption not enabled, skipping\n", name)
+			continue
+		}
+		if *newMasterKey {
+			id, err := persistence.AddMasterKey(string(encryption.KeyFile))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: added master key %s to %s\n", name, id, encryption.KeyFile)
+			config.OpenStore(handler, span)
+		}
+		if !config.Store().IsValid() {
+			return fmt.Errorf("%s: %v", name, config.Store().GetError())
+		}
+		count, err := config.Store().RotateEncryptionKeys(span)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: re-encrypted %d records\n", name, count)
+	}
+	return nil
+}
+
+// backupStorage implements `lectiod backup-storage [-bundle name]`
+func backupStorage(observatory observe.Observatory, args []string, parent opentracing.Span) error {
+	span := observatory.StartChildTrace("main.backupStorage", parent)
+	defer span.Finish()
+
+	flags := flag.NewFlagSet("backup-storage", flag.ExitOnError)
+	bundle := flags.String("bundle", string(resolvers.DefaultSettingsBundleName), "settings bundle whose storage should be backed up")
+	flags.Parse(args)
+
+	handler := resolvers.NewSchemaResolvers(observatory, configPathProvider, span)
+	defer handler.Close()
+
+	config := handler.Configurations()[models.SettingsBundleName(*bundle)]
+	if config == nil {
+		return fmt.Errorf("Settings bundle '%s' not found", *bundle)
+	}
+	backup, err := config.Store().BackupToFile(models.SettingsBundleName(*bundle), span)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: backed up %d records (%d bytes) to %s, sha256 %s\n", backup.Bundle, backup.Records, backup.Bytes, backup.File, backup.Checksum)
+	return nil
+}
+
+// restoreStorage implements `lectiod restore-storage [-bundle name] -file archive`; restoring into a bundle
+// configured with another StorageType migrates the records to that backend
+func restoreStorage(observatory observe.Observatory, args []string, parent opentracing.Span) error {
+	span := observatory.StartChildTrace("main.restoreStorage", parent)
+	defer span.Finish()
+
+	flags := flag.NewFlagSet("restore-storage", flag.ExitOnError)
+	bundle := flags.String("bundle", string(resolvers.DefaultSettingsBundleName), "settings bundle whose storage the backup should be restored into")
+	file := flags.String("file", "", "backup archive created by backup-storage or the backupStorage mutation, in the bundle's storage.backupsPath")
+	flags.Parse(args)
+	if *file == "" {
+		return fmt.Errorf("restore-storage requires -file")
+	}
+
+	handler := resolvers.NewSchemaResolvers(observatory, configPathProvider, span)
+	defer handler.Close()
+
+	config := handler.Configurations()[models.SettingsBundleName(*bundle)]
+	if config == nil {
+		return fmt.Errorf("Settings bundle '%s' not found", *bundle)
+	}
+	backup, err := config.Store().RestoreFromFile(models.FilePathAndName(*file), span)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: restored %d records (%d bytes) from %s backup of %s\n", *bundle, backup.Records, backup.Bytes, backup.StorageType, backup.Bundle)
+	return nil
+}
+
 func main() {
 	observatory := observe.MakeObservatoryFromEnv()
 	defer observatory.Close()
@@ -19,6 +118,24 @@ func main() {
 	span := observatory.StartTrace("main()")
 	defer span.Finish()
 
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "rotate-storage-keys":
+			err = rotateStorageKeys(observatory, os.Args[2:], span)
+		case "backup-storage":
+			err = backupStorage(observatory, os.Args[2:], span)
+		case "restore-storage":
+			err = restoreStorage(observatory, os.Args[2:], span)
+		default:
+			err = fmt.Errorf("Unknown command '%s', expected rotate-storage-keys, backup-storage or restore-storage", os.Args[1])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	graphQLHTTPServer := server.CreateGraphQLOverHTTPServer(observatory, configPathProvider, span)
 	//TODO: graphQLHTTPServer resolvers have configurations that need to be closed so call resolvers.Close()
 
@@ -8,6 +8,15 @@ import (
 	strconv "strconv"
 )
 
+type ArchiveRecord struct {
+	RecordID   SmallText         `json:"recordId"`
+	WarcFile   SmallText         `json:"warcFile"`
+	Offset     StorageBytesCount `json:"offset"`
+	Length     StorageBytesCount `json:"length"`
+	TargetURL  URLText           `json:"targetURL"`
+	ArchivedAt DateTime          `json:"archivedAt"`
+	ReplayPath URLText           `json:"replayPath"`
+}
 type AuthenticationIdentity interface{}
 type AuthorizationClaimCryptoKey interface{}
 type AuthorizationInput struct {
@@ -15,25 +24,136 @@ type AuthorizationInput struct {
 	ClaimMedium AuthorizationClaimMedium `json:"claimMedium"`
 	SessionID   *AuthenticatedSessionID  `json:"sessionID"`
 }
+type CanonicalURLSettings struct {
+	Detect bool `json:"detect"`
+	Dedupe bool `json:"dedupe"`
+}
+type CanonicalizerSettings struct {
+	Name    CanonicalizerName `json:"name"`
+	Enabled bool              `json:"enabled"`
+}
+type DomainHarvestRules struct {
+	Domain                    DomainName           `json:"domain"`
+	CleanURLs                 *bool                `json:"cleanURLs"`
+	RemoveParamsFromURLsRegEx []*RegularExpression `json:"removeParamsFromURLsRegEx"`
+	FollowHTMLRedirects       *bool                `json:"followHTMLRedirects"`
+}
+type ExpiredStorageRecord struct {
+	Collection StorageDestinationCollection `json:"collection"`
+	Key        StorageKey                   `json:"key"`
+	Kind       SavedResourceKind            `json:"kind"`
+	URL        URLText                      `json:"url"`
+	SavedAt    DateTime                     `json:"savedAt"`
+	ExpiresAt  DateTime                     `json:"expiresAt"`
+}
 type FileStorageSettings struct {
 	BasePath DirectoryPath `json:"basePath"`
 }
+type HTTPFetchSettings struct {
+	TimeoutSeconds               *TimeoutSeconds    `json:"timeoutSeconds"`
+	MaxRedirects                 *RedirectsCount    `json:"maxRedirects"`
+	UserAgent                    *UserAgent         `json:"userAgent"`
+	AcceptedContentTypes         []*ContentType     `json:"acceptedContentTypes"`
+	MaxBodyBytes                 *BodySizeBytes     `json:"maxBodyBytes"`
+	Proxy                        *URLText           `json:"proxy"`
+	MaxConcurrentRequests        *ConcurrencyLimit  `json:"maxConcurrentRequests"`
+	MaxConcurrentRequestsPerHost *ConcurrencyLimit  `json:"maxConcurrentRequestsPerHost"`
+	HostDelayMilliseconds        *DelayMilliseconds `json:"hostDelayMilliseconds"`
+}
+type HarvestDirectivesInput struct {
+	IgnoreURLsRegExprs        []RegularExpression `json:"ignoreURLsRegExprs"`
+	RemoveParamsFromURLsRegEx []RegularExpression `json:"removeParamsFromURLsRegEx"`
+	FollowHTMLRedirects       *bool               `json:"followHTMLRedirects"`
+	RespectRobotsTxt          *bool               `json:"respectRobotsTxt"`
+	Archive                   *bool               `json:"archive"`
+}
 type HarvestDirectivesSettings struct {
-	IgnoreURLsRegExprs        []*RegularExpression `json:"ignoreURLsRegExprs"`
-	RemoveParamsFromURLsRegEx []*RegularExpression `json:"removeParamsFromURLsRegEx"`
-	FollowHTMLRedirects       bool                 `json:"followHTMLRedirects"`
+	IgnoreURLsRegExprs        []*RegularExpression      `json:"ignoreURLsRegExprs"`
+	RemoveParamsFromURLsRegEx []*RegularExpression      `json:"removeParamsFromURLsRegEx"`
+	FollowHTMLRedirects       bool                      `json:"followHTMLRedirects"`
+	JobWorkers                *HarvestJobWorkersCount   `json:"jobWorkers"`
+	BatchConcurrency          *ConcurrencyLimit         `json:"batchConcurrency"`
+	AllowRequestDirectives    bool                      `json:"allowRequestDirectives"`
+	Fetch                     *HTTPFetchSettings        `json:"fetch"`
+	RespectRobotsTxt          bool                      `json:"respectRobotsTxt"`
+	RobotsTxtCacheMinutes     *CacheTTLMinutes          `json:"robotsTxtCacheMinutes"`
+	ResolutionCacheMinutes    *CacheTTLMinutes          `json:"resolutionCacheMinutes"`
+	IgnoreURLsRules           []*HarvestRule            `json:"ignoreURLsRules"`
+	RemoveParamsRules         []*HarvestRule            `json:"removeParamsRules"`
+	AllowDomains              []*DomainName             `json:"allowDomains"`
+	DenyDomains               []*DomainName             `json:"denyDomains"`
+	DomainRules               []*DomainHarvestRules     `json:"domainRules"`
+	Normalization             *URLNormalizationSettings `json:"normalization"`
+	Canonicalizers            []*CanonicalizerSettings  `json:"canonicalizers"`
+	CanonicalURLs             *CanonicalURLSettings     `json:"canonicalURLs"`
+	ExtractMetadata           bool                      `json:"extractMetadata"`
+	ExtractContent            bool                      `json:"extractContent"`
+	Archive                   bool                      `json:"archive"`
+}
+type HarvestJob struct {
+	ID          HarvestJobID        `json:"id"`
+	Status      HarvestJobStatus    `json:"status"`
+	Destination *StorageKey         `json:"destination"`
+	SubmittedAt DateTime            `json:"submittedAt"`
+	StartedAt   *DateTime           `json:"startedAt"`
+	FinishedAt  *DateTime           `json:"finishedAt"`
+	Discovered  ResourcesCount      `json:"discovered"`
+	Processed   ResourcesCount      `json:"processed"`
+	Harvested   ResourcesCount      `json:"harvested"`
+	Ignored     ResourcesCount      `json:"ignored"`
+	Invalid     ResourcesCount      `json:"invalid"`
+	Error       *ErrorMessage       `json:"error"`
+	Resources   *HarvestedResources `json:"resources"`
+}
+type HarvestProgressEvent interface{}
+type HarvestRule struct {
+	Name        RuleIdentifier    `json:"name"`
+	Description *MediumText       `json:"description"`
+	Enabled     *bool             `json:"enabled"`
+	RegEx       RegularExpression `json:"regEx"`
+}
+type HarvestRuleStats struct {
+	Kind        HarvestRuleKind   `json:"kind"`
+	Domain      *DomainName       `json:"domain"`
+	Name        RuleIdentifier    `json:"name"`
+	Description *MediumText       `json:"description"`
+	Enabled     bool              `json:"enabled"`
+	RegEx       RegularExpression `json:"regEx"`
+	Hits        HitsCount         `json:"hits"`
+}
+type HarvestRulesTest struct {
+	URL           URLText              `json:"url"`
+	IsValid       bool                 `json:"isValid"`
+	IgnoreRule    *RuleIdentifier      `json:"ignoreRule"`
+	RemovedParams []*RemovedQueryParam `json:"removedParams"`
+	Cleaned       *URLText             `json:"cleaned"`
+}
+type HarvestedContent struct {
+	Text               ExtraLargeText     `json:"text"`
+	HTML               ExtraLargeText     `json:"html"`
+	WordCount          WordsCount         `json:"wordCount"`
+	ReadingTimeMinutes ReadingTimeMinutes `json:"readingTimeMinutes"`
+	ExtractedAt        DateTime           `json:"extractedAt"`
 }
 type HarvestedResource struct {
-	Urls           HarvestedResourceUrls `json:"urls"`
-	IsHTMLRedirect bool                  `json:"isHTMLRedirect"`
-	IsCleaned      bool                  `json:"isCleaned"`
-	RedirectURL    *URLText              `json:"redirectURL"`
+	Urls            HarvestedResourceUrls `json:"urls"`
+	IsHTMLRedirect  bool                  `json:"isHTMLRedirect"`
+	IsCleaned       bool                  `json:"isCleaned"`
+	RedirectURL     *URLText              `json:"redirectURL"`
+	RedirectChain   []*RedirectHop        `json:"redirectChain"`
+	CacheStatus     ResolutionCacheStatus `json:"cacheStatus"`
+	CanonicalizedBy []CanonicalizerName   `json:"canonicalizedBy"`
+	Metadata        *PageMetadata         `json:"metadata"`
+	ArchiveRecord   *ArchiveRecord        `json:"archiveRecord"`
+	Discovery       *ResourceDiscovery    `json:"discovery"`
 }
 type HarvestedResourceUrls struct {
-	Original URLText `json:"original"`
-	Final    URLText `json:"final"`
-	Cleaned  URLText `json:"cleaned"`
-	Resolved URLText `json:"resolved"`
+	Original   URLText  `json:"original"`
+	Final      URLText  `json:"final"`
+	Cleaned    URLText  `json:"cleaned"`
+	Resolved   URLText  `json:"resolved"`
+	Normalized URLText  `json:"normalized"`
+	Canonical  *URLText `json:"canonical"`
 }
 type HarvestedResources struct {
 	Text      LargeText              `json:"text"`
@@ -42,8 +162,21 @@ type HarvestedResources struct {
 	Invalid   []*UnharvestedResource `json:"invalid"`
 }
 type IgnoredResource struct {
-	Urls   HarvestedResourceUrls `json:"urls"`
-	Reason SmallText             `json:"reason"`
+	Urls          HarvestedResourceUrls `json:"urls"`
+	Reason        SmallText             `json:"reason"`
+	ReasonCode    ReasonCode            `json:"reasonCode"`
+	MatchedRule   *RuleIdentifier       `json:"matchedRule"`
+	RedirectChain []*RedirectHop        `json:"redirectChain"`
+	Discovery     *ResourceDiscovery    `json:"discovery"`
+}
+type OpenGraphMetadata struct {
+	Type        *SmallText    `json:"type"`
+	Title       *SmallText    `json:"title"`
+	Description *MediumText   `json:"description"`
+	Image       *URLText      `json:"image"`
+	URL         *URLText      `json:"url"`
+	SiteName    *NameText     `json:"siteName"`
+	Locale      *LanguageCode `json:"locale"`
 }
 type Organization struct {
 	ID       string                `json:"id"`
@@ -57,6 +190,17 @@ type OrganizationalUnit struct {
 	Units    []*OrganizationalUnit `json:"units"`
 	Services []*ServiceIdentity    `json:"services"`
 }
+type PageMetadata struct {
+	Title       *SmallText           `json:"title"`
+	Description *MediumText          `json:"description"`
+	Author      *NameText            `json:"author"`
+	PublishedAt *DateTime            `json:"publishedAt"`
+	SiteName    *NameText            `json:"siteName"`
+	Favicon     *URLText             `json:"favicon"`
+	Language    *LanguageCode        `json:"language"`
+	OpenGraph   *OpenGraphMetadata   `json:"openGraph"`
+	TwitterCard *TwitterCardMetadata `json:"twitterCard"`
+}
 type Party interface{}
 type Person struct {
 	ID        string             `json:"id"`
@@ -71,6 +215,23 @@ type PrivilegedAuthorizationInput struct {
 	ClaimMedium AuthorizationClaimMedium `json:"claimMedium"`
 	SessionID   *AuthenticatedSessionID  `json:"sessionID"`
 }
+type RedirectHop struct {
+	URL                 URLText             `json:"url"`
+	StatusCode          HTTPStatusCode      `json:"statusCode"`
+	Location            URLText             `json:"location"`
+	Kind                RedirectKind        `json:"kind"`
+	LatencyMilliseconds LatencyMilliseconds `json:"latencyMilliseconds"`
+}
+type RemovedQueryParam struct {
+	Param SmallText      `json:"param"`
+	Rule  RuleIdentifier `json:"rule"`
+}
+type ResourceDiscovery struct {
+	Offset         TextOffset `json:"offset"`
+	Length         TextLength `json:"length"`
+	AnchorText     *SmallText `json:"anchorText"`
+	ContextSnippet MediumText `json:"contextSnippet"`
+}
 type ServiceIdentity struct {
 	ID        string             `json:"id"`
 	Type      AuthenticationType `json:"type"`
@@ -83,22 +244,97 @@ type SettingsBundle struct {
 	Harvest HarvestDirectivesSettings `json:"harvest"`
 	Errors  []*ErrorMessage           `json:"errors"`
 }
+type StorageBackup struct {
+	Bundle      SettingsBundleName  `json:"bundle"`
+	File        FilePathAndName     `json:"file"`
+	StorageType StorageType         `json:"storageType"`
+	CreatedAt   DateTime            `json:"createdAt"`
+	Records     StorageRecordsCount `json:"records"`
+	Bytes       StorageBytesCount   `json:"bytes"`
+	Checksum    Checksum            `json:"checksum"`
+}
+type StorageCollectionUsage struct {
+	Collection StorageKey            `json:"collection"`
+	Records    StorageRecordsCount   `json:"records"`
+	Bytes      StorageBytesCount     `json:"bytes"`
+	Quota      *StorageQuotaSettings `json:"quota"`
+}
 type StorageDestinationInput struct {
 	Collection StorageDestinationCollection `json:"collection"`
 	Key        StorageKey                   `json:"key"`
 }
+type StorageEncryptionSettings struct {
+	KeyFile FilePathAndName `json:"keyFile"`
+}
+type StorageQuotaSettings struct {
+	MaxRecords *StorageRecordsCount `json:"maxRecords"`
+	MaxBytes   *StorageBytesCount   `json:"maxBytes"`
+}
+type StorageRetentionReport struct {
+	Bundle      SettingsBundleName      `json:"bundle"`
+	EvaluatedAt DateTime                `json:"evaluatedAt"`
+	DryRun      bool                    `json:"dryRun"`
+	Examined    StorageRecordsCount     `json:"examined"`
+	Expired     StorageRecordsCount     `json:"expired"`
+	Records     []*ExpiredStorageRecord `json:"records"`
+	ExpiredJobs StorageRecordsCount     `json:"expiredJobs"`
+}
+type StorageRetentionRule struct {
+	Kind       SavedResourceKind             `json:"kind"`
+	Collection *StorageDestinationCollection `json:"collection"`
+	Key        *StorageKey                   `json:"key"`
+	KeepDays   *RetentionDays                `json:"keepDays"`
+}
+type StorageRetentionSettings struct {
+	Rules                []*StorageRetentionRule `json:"rules"`
+	JobsKeepDays         *RetentionDays          `json:"jobsKeepDays"`
+	SweepIntervalMinutes *SweepIntervalMinutes   `json:"sweepIntervalMinutes"`
+}
 type StorageSettings struct {
-	Type    StorageType          `json:"type"`
-	Filesys *FileStorageSettings `json:"filesys"`
+	Type         StorageType                `json:"type"`
+	Filesys      *FileStorageSettings       `json:"filesys"`
+	Encryption   *StorageEncryptionSettings `json:"encryption"`
+	BackupsPath  *DirectoryPath             `json:"backupsPath"`
+	ArchivesPath *DirectoryPath             `json:"archivesPath"`
+	Quota        *StorageQuotaSettings      `json:"quota"`
+	TenantQuota  *StorageQuotaSettings      `json:"tenantQuota"`
+	Retention    *StorageRetentionSettings  `json:"retention"`
+}
+type StorageUsage struct {
+	Bundle      SettingsBundleName        `json:"bundle"`
+	Records     StorageRecordsCount       `json:"records"`
+	Bytes       StorageBytesCount         `json:"bytes"`
+	Quota       *StorageQuotaSettings     `json:"quota"`
+	Collections []*StorageCollectionUsage `json:"collections"`
 }
 type Tenant struct {
 	ID   string       `json:"id"`
 	Name NameText     `json:"name"`
 	Org  Organization `json:"org"`
 }
+type TwitterCardMetadata struct {
+	Card        *SmallText  `json:"card"`
+	Site        *NameText   `json:"site"`
+	Creator     *NameText   `json:"creator"`
+	Title       *SmallText  `json:"title"`
+	Description *MediumText `json:"description"`
+	Image       *URLText    `json:"image"`
+}
+type URLNormalizationSettings struct {
+	LowercaseSchemeAndHost *bool                `json:"lowercaseSchemeAndHost"`
+	RemoveDefaultPort      *bool                `json:"removeDefaultPort"`
+	RemoveFragment         *bool                `json:"removeFragment"`
+	TrailingSlash          *TrailingSlashPolicy `json:"trailingSlash"`
+	SortQueryParams        *bool                `json:"sortQueryParams"`
+	PunycodeHost           *bool                `json:"punycodeHost"`
+	FoldWWW                *bool                `json:"foldWWW"`
+}
 type UnharvestedResource struct {
-	URL    URLText   `json:"url"`
-	Reason SmallText `json:"reason"`
+	URL        URLText            `json:"url"`
+	Reason     SmallText          `json:"reason"`
+	ReasonCode ReasonCode         `json:"reasonCode"`
+	HTTPStatus *HTTPStatusCode    `json:"httpStatus"`
+	Discovery  *ResourceDiscovery `json:"discovery"`
 }
 type UserIdentity struct {
 	ID        string             `json:"id"`
@@ -286,6 +522,309 @@ func (e AuthorizationClaimType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type CanonicalizerName string
+
+const (
+	CanonicalizerNameGoogleRedirect CanonicalizerName = "GOOGLE_REDIRECT"
+	CanonicalizerNameAmp            CanonicalizerName = "AMP"
+	CanonicalizerNameYoutube        CanonicalizerName = "YOUTUBE"
+	CanonicalizerNameAmazon         CanonicalizerName = "AMAZON"
+	CanonicalizerNameMedium         CanonicalizerName = "MEDIUM"
+)
+
+func (e CanonicalizerName) IsValid() bool {
+	switch e {
+	case CanonicalizerNameGoogleRedirect, CanonicalizerNameAmp, CanonicalizerNameYoutube, CanonicalizerNameAmazon, CanonicalizerNameMedium:
+		return true
+	}
+	return false
+}
+
+func (e CanonicalizerName) String() string {
+	return string(e)
+}
+
+func (e *CanonicalizerName) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CanonicalizerName(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CanonicalizerName", str)
+	}
+	return nil
+}
+
+func (e CanonicalizerName) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type DocumentFormat string
+
+const (
+	DocumentFormatHtml     DocumentFormat = "HTML"
+	DocumentFormatMarkdown DocumentFormat = "MARKDOWN"
+	DocumentFormatText     DocumentFormat = "TEXT"
+)
+
+func (e DocumentFormat) IsValid() bool {
+	switch e {
+	case DocumentFormatHtml, DocumentFormatMarkdown, DocumentFormatText:
+		return true
+	}
+	return false
+}
+
+func (e DocumentFormat) String() string {
+	return string(e)
+}
+
+func (e *DocumentFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DocumentFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DocumentFormat", str)
+	}
+	return nil
+}
+
+func (e DocumentFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HarvestJobStatus string
+
+const (
+	HarvestJobStatusQueued    HarvestJobStatus = "QUEUED"
+	HarvestJobStatusRunning   HarvestJobStatus = "RUNNING"
+	HarvestJobStatusCompleted HarvestJobStatus = "COMPLETED"
+	HarvestJobStatusFailed    HarvestJobStatus = "FAILED"
+)
+
+func (e HarvestJobStatus) IsValid() bool {
+	switch e {
+	case HarvestJobStatusQueued, HarvestJobStatusRunning, HarvestJobStatusCompleted, HarvestJobStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e HarvestJobStatus) String() string {
+	return string(e)
+}
+
+func (e *HarvestJobStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HarvestJobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HarvestJobStatus", str)
+	}
+	return nil
+}
+
+func (e HarvestJobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type HarvestRuleKind string
+
+const (
+	HarvestRuleKindIgnoreUrl   HarvestRuleKind = "IGNORE_URL"
+	HarvestRuleKindRemoveParam HarvestRuleKind = "REMOVE_PARAM"
+)
+
+func (e HarvestRuleKind) IsValid() bool {
+	switch e {
+	case HarvestRuleKindIgnoreUrl, HarvestRuleKindRemoveParam:
+		return true
+	}
+	return false
+}
+
+func (e HarvestRuleKind) String() string {
+	return string(e)
+}
+
+func (e *HarvestRuleKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HarvestRuleKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HarvestRuleKind", str)
+	}
+	return nil
+}
+
+func (e HarvestRuleKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ReasonCode string
+
+const (
+	ReasonCodeInvalidUrl             ReasonCode = "INVALID_URL"
+	ReasonCodeDestinationUnreachable ReasonCode = "DESTINATION_UNREACHABLE"
+	ReasonCodeHttpError              ReasonCode = "HTTP_ERROR"
+	ReasonCodeTooManyRedirects       ReasonCode = "TOO_MANY_REDIRECTS"
+	ReasonCodeContentTypeNotAccepted ReasonCode = "CONTENT_TYPE_NOT_ACCEPTED"
+	ReasonCodeMatchedIgnoreRule      ReasonCode = "MATCHED_IGNORE_RULE"
+	ReasonCodeDisallowedByRobotsTxt  ReasonCode = "DISALLOWED_BY_ROBOTS_TXT"
+	ReasonCodeDomainDenied           ReasonCode = "DOMAIN_DENIED"
+	ReasonCodeDomainNotAllowed       ReasonCode = "DOMAIN_NOT_ALLOWED"
+)
+
+func (e ReasonCode) IsValid() bool {
+	switch e {
+	case ReasonCodeInvalidUrl, ReasonCodeDestinationUnreachable, ReasonCodeHttpError, ReasonCodeTooManyRedirects, ReasonCodeContentTypeNotAccepted, ReasonCodeMatchedIgnoreRule, ReasonCodeDisallowedByRobotsTxt, ReasonCodeDomainDenied, ReasonCodeDomainNotAllowed:
+		return true
+	}
+	return false
+}
+
+func (e ReasonCode) String() string {
+	return string(e)
+}
+
+func (e *ReasonCode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReasonCode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReasonCode", str)
+	}
+	return nil
+}
+
+func (e ReasonCode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type RedirectKind string
+
+const (
+	RedirectKindHttp     RedirectKind = "HTTP"
+	RedirectKindHtmlMeta RedirectKind = "HTML_META"
+)
+
+func (e RedirectKind) IsValid() bool {
+	switch e {
+	case RedirectKindHttp, RedirectKindHtmlMeta:
+		return true
+	}
+	return false
+}
+
+func (e RedirectKind) String() string {
+	return string(e)
+}
+
+func (e *RedirectKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RedirectKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RedirectKind", str)
+	}
+	return nil
+}
+
+func (e RedirectKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ResolutionCacheStatus string
+
+const (
+	ResolutionCacheStatusFresh    ResolutionCacheStatus = "FRESH"
+	ResolutionCacheStatusCached   ResolutionCacheStatus = "CACHED"
+	ResolutionCacheStatusUncached ResolutionCacheStatus = "UNCACHED"
+)
+
+func (e ResolutionCacheStatus) IsValid() bool {
+	switch e {
+	case ResolutionCacheStatusFresh, ResolutionCacheStatusCached, ResolutionCacheStatusUncached:
+		return true
+	}
+	return false
+}
+
+func (e ResolutionCacheStatus) String() string {
+	return string(e)
+}
+
+func (e *ResolutionCacheStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ResolutionCacheStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ResolutionCacheStatus", str)
+	}
+	return nil
+}
+
+func (e ResolutionCacheStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SavedResourceKind string
+
+const (
+	SavedResourceKindHarvested SavedResourceKind = "HARVESTED"
+	SavedResourceKindIgnored   SavedResourceKind = "IGNORED"
+	SavedResourceKindInvalid   SavedResourceKind = "INVALID"
+)
+
+func (e SavedResourceKind) IsValid() bool {
+	switch e {
+	case SavedResourceKindHarvested, SavedResourceKindIgnored, SavedResourceKindInvalid:
+		return true
+	}
+	return false
+}
+
+func (e SavedResourceKind) String() string {
+	return string(e)
+}
+
+func (e *SavedResourceKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SavedResourceKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SavedResourceKind", str)
+	}
+	return nil
+}
+
+func (e SavedResourceKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type StorageDestinationCollection string
 
 const (
@@ -356,3 +895,40 @@ func (e *StorageType) UnmarshalGQL(v interface{}) error {
 func (e StorageType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type TrailingSlashPolicy string
+
+const (
+	TrailingSlashPolicyKeep   TrailingSlashPolicy = "KEEP"
+	TrailingSlashPolicyAdd    TrailingSlashPolicy = "ADD"
+	TrailingSlashPolicyRemove TrailingSlashPolicy = "REMOVE"
+)
+
+func (e TrailingSlashPolicy) IsValid() bool {
+	switch e {
+	case TrailingSlashPolicyKeep, TrailingSlashPolicyAdd, TrailingSlashPolicyRemove:
+		return true
+	}
+	return false
+}
+
+func (e TrailingSlashPolicy) String() string {
+	return string(e)
+}
+
+func (e *TrailingSlashPolicy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TrailingSlashPolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TrailingSlashPolicy", str)
+	}
+	return nil
+}
+
+func (e TrailingSlashPolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
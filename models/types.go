@@ -1,7 +1,9 @@
 package models
 
 import (
+	"fmt"
 	io "io"
+	"time"
 
 	graphql "github.com/99designs/gqlgen/graphql"
 )
@@ -31,6 +33,48 @@ type AuthenticatedSessionTimeout uint
 type DirectoryPath string
 type FilePathAndName string
 type FileNameOnly string
+type Document string
+
+// File is a file uploaded with a GraphQL multipart request. The server keeps the uploaded parts with the
+// request and sets each File variable to the name of its part, resolvers read the part's name, content type
+// and content from the request.
+type File struct {
+	Part        string
+	Name        string
+	ContentType string
+	Content     []byte
+}
+
+type DateTime string
+
+type StorageRecordsCount uint
+type StorageBytesCount uint64
+type Checksum string
+
+type RetentionDays uint
+type SweepIntervalMinutes uint
+
+type HarvestJobID string
+type HarvestJobWorkersCount uint
+type ResourcesCount uint
+type ConcurrencyLimit uint
+type TimeoutSeconds uint
+type RedirectsCount uint
+type UserAgent string
+type ContentType string
+type BodySizeBytes uint64
+type DelayMilliseconds uint
+type CacheTTLMinutes uint
+type HTTPStatusCode uint
+type LatencyMilliseconds uint
+type RuleIdentifier string
+type HitsCount uint64
+type DomainName string
+type LanguageCode string
+type WordsCount uint
+type ReadingTimeMinutes uint
+type TextOffset uint
+type TextLength uint
 
 func (t NameText) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
@@ -64,10 +108,26 @@ func (t URLText) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
 }
 
+func (t *URLText) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		*t = URLText(str)
+	}
+	return err
+}
+
 func (t RegularExpression) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
 }
 
+func (t *RegularExpression) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		*t = RegularExpression(str)
+	}
+	return err
+}
+
 func (t ErrorMessage) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
 }
@@ -76,6 +136,10 @@ func (t DirectoryPath) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
 }
 
+func (t FilePathAndName) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
 func (t SettingsBundleName) MarshalGQL(w io.Writer) {
 	graphql.MarshalString(string(t)).MarshalGQL(w)
 }
@@ -155,3 +219,168 @@ func (t *StorageKey) UnmarshalGQL(v interface{}) error {
 	}
 	return err
 }
+
+// NewDateTime formats t as an RFC 3339 DateTime
+func NewDateTime(t time.Time) DateTime {
+	return DateTime(t.Format(time.RFC3339))
+}
+
+func (t DateTime) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t *DateTime) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		_, err = time.Parse(time.RFC3339, str)
+	}
+	if err == nil {
+		*t = DateTime(str)
+	}
+	return err
+}
+
+func (t StorageRecordsCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t StorageBytesCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t Checksum) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t *FilePathAndName) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		*t = FilePathAndName(str)
+	}
+	return err
+}
+
+func (t RetentionDays) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t SweepIntervalMinutes) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t HarvestJobID) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t *HarvestJobID) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		*t = HarvestJobID(str)
+	}
+	return err
+}
+
+func (t HarvestJobWorkersCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t ResourcesCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t ConcurrencyLimit) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t TimeoutSeconds) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t RedirectsCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t UserAgent) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t ContentType) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t BodySizeBytes) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t DelayMilliseconds) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t CacheTTLMinutes) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t HTTPStatusCode) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t LatencyMilliseconds) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t RuleIdentifier) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t HitsCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t DomainName) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t LanguageCode) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t WordsCount) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t ReadingTimeMinutes) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t TextOffset) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t TextLength) MarshalGQL(w io.Writer) {
+	graphql.MarshalInt(int(t)).MarshalGQL(w)
+}
+
+func (t Document) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(string(t)).MarshalGQL(w)
+}
+
+func (t *Document) UnmarshalGQL(v interface{}) error {
+	str, err := graphql.UnmarshalString(v)
+	if err == nil {
+		*t = Document(str)
+	}
+	return err
+}
+
+func (t File) MarshalGQL(w io.Writer) {
+	graphql.MarshalString(t.Name).MarshalGQL(w)
+}
+
+func (t *File) UnmarshalGQL(v interface{}) error {
+	part, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("File must be uploaded with a multipart request, got %T", v)
+	}
+	t.Part = part
+	return nil
+}
@@ -0,0 +1,238 @@
+package persistence
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/warc"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	observe "github.com/shah/observe-go"
+)
+
+// MaxWARCFileBytes is the size after which WARCArchive starts a new file
+const MaxWARCFileBytes = 1024 * 1024 * 1024
+
+// warcFileSuffix follows the bundle's name in the names of the files written by WARCArchive, its group is
+// the file's sequence number
+const warcFileSuffix = `-[0-9]{8}T[0-9]{6}Z-([0-9]{5})\.warc\.gz`
+
+// warcFileName matches the names of the files written by WARCArchive, so replay requests can't name other files
+var warcFileName = regexp.MustCompile(`^[A-Za-z0-9_-]+` + warcFileSuffix + `$`)
+
+// WARCLocation is where a record was written in a WARC file
+type WARCLocation struct {
+	File   string
+	Offset int64
+	Length int64
+}
+
+// WARCArchive appends records to the .warc.gz files of a settings bundle, files are created when the first
+// record is written and a new one is started every MaxWARCFileBytes. After a restart the bundle's latest
+// file is appended to until it's full.
+type WARCArchive struct {
+	bundle      models.SettingsBundleName
+	path        string
+	mutex       sync.Mutex
+	file        *os.File
+	fileName    string
+	fileBytes   int64
+	sequence    int
+	observatory observe.Observatory
+}
+
+// NewWARCArchive constructs a WARCArchive which writes into config.ArchivesPath, or next to the bundle's
+// flatfs directory if that's not set
+func NewWARCArchive(observatory observe.Observatory, bundle models.SettingsBundleName, config *models.StorageSettings) *WARCArchive {
+	result := new(WARCArchive)
+	result.bundle = bundle
+	result.observatory = observatory
+	switch {
+	case config.ArchivesPath != nil && *config.ArchivesPath != "":
+		result.path = string(*config.ArchivesPath)
+	case config.Filesys != nil && config.Filesys.BasePath != "":
+		result.path = filepath.Clean(string(config.Filesys.BasePath)) + "-warc"
+	default:
+		result.path = filepath.Join(os.TempDir(), string(bundle)+"-warc")
+	}
+	return result
+}
+
+// Path returns the directory the WARC files are written to
+func (a *WARCArchive) Path() string {
+	return a.path
+}
+
+// Append writes records one after the other into the current WARC file and returns where each was written,
+// the file is synced so the records are on disk before their locations are saved
+func (a *WARCArchive) Append(records []*warc.Record, parent opentracing.Span) ([]*WARCLocation, error) {
+	span := a.observatory.StartChildTrace("persistence.WARCArchive.Append", parent)
+	defer span.Finish()
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	fail := func(err error) ([]*WARCLocation, error) {
+		error := fmt.Errorf("Unable to archive in '%s': %v", a.path, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		// a partially written record would corrupt what follows, so the next records go into a new file
+		a.closeFile()
+		return nil, error
+	}
+
+	if a.file == nil && a.sequence == 0 {
+		err := a.resumeFile(span)
+		if err != nil {
+			return fail(err)
+		}
+	}
+	if a.file == nil || a.fileBytes >= MaxWARCFileBytes {
+		err := a.openFile(span)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	result := make([]*WARCLocation, 0, len(records))
+	for _, record := range records {
+		written, err := warc.WriteRecord(a.file, record)
+		if err != nil {
+			return fail(err)
+		}
+		result = append(result, &WARCLocation{File: a.fileName, Offset: a.fileBytes, Length: written})
+		a.fileBytes += written
+	}
+	err := a.file.Sync()
+	if err != nil {
+		return fail(err)
+	}
+	span.LogFields(log.String("file", a.fileName), log.Int("records", len(records)), log.Int64("fileBytes", a.fileBytes))
+	return result, nil
+}
+
+// resumeFile reopens the bundle's latest WARC file for appending if it isn't full, and continues the
+// sequence of file names after it either way. Records are written whole and synced, so the file ends
+// with a complete record unless the disk failed.
+func (a *WARCArchive) resumeFile(span opentracing.Span) error {
+	entries, err := ioutil.ReadDir(a.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	bundleFileName := regexp.MustCompile(`^` + regexp.QuoteMeta(safeFileName(string(a.bundle))) + warcFileSuffix + `$`)
+	var names []string
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() && bundleFileName.MatchString(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	// names sort by the time they were started at, then by sequence number
+	sort.Strings(names)
+	latest := names[len(names)-1]
+	a.sequence, _ = strconv.Atoi(bundleFileName.FindStringSubmatch(latest)[1])
+
+	file, err := os.OpenFile(filepath.Join(a.path, latest), os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if info.Size() >= MaxWARCFileBytes {
+		return file.Close()
+	}
+	a.file, a.fileName, a.fileBytes = file, latest, info.Size()
+	span.LogFields(log.String("resumed", latest), log.Int64("fileBytes", a.fileBytes))
+	return nil
+}
+
+// openFile starts a new WARC file with a warcinfo record
+func (a *WARCArchive) openFile(span opentracing.Span) error {
+	a.closeFile()
+	err := os.MkdirAll(a.path, 0700)
+	if err != nil {
+		return err
+	}
+	a.sequence++
+	name := fmt.Sprintf("%s-%s-%05d.warc.gz", safeFileName(string(a.bundle)), time.Now().UTC().Format("20060102T150405Z"), a.sequence)
+	file, err := os.OpenFile(filepath.Join(a.path, name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	a.file, a.fileName, a.fileBytes = file, name, 0
+
+	info := warc.NewRecord(warc.TypeWarcinfo, time.Now(), []byte(fmt.Sprintf("software: lectiod\r\nformat: WARC File Format 1.0\r\nbundle: %s\r\n", a.bundle)))
+	info.Set(warc.FieldFilename, name)
+	info.Set(warc.FieldContentType, "application/warc-fields")
+	written, err := warc.WriteRecord(a.file, info)
+	if err != nil {
+		return err
+	}
+	a.fileBytes = written
+	span.LogFields(log.String("opened", name))
+	return nil
+}
+
+func (a *WARCArchive) closeFile() {
+	if a.file != nil {
+		a.file.Close()
+		a.file = nil
+	}
+}
+
+// Read returns the record written at offset of file
+func (a *WARCArchive) Read(file string, offset int64) (*warc.Record, error) {
+	if !warcFileName.MatchString(file) {
+		return nil, fmt.Errorf("Invalid WARC file name '%s'", file)
+	}
+	if offset < 0 {
+		return nil, fmt.Errorf("Invalid offset %d in WARC file '%s'", offset, file)
+	}
+	opened, err := os.Open(filepath.Join(a.path, file))
+	if err != nil {
+		return nil, err
+	}
+	defer opened.Close()
+	_, err = opened.Seek(offset, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	return warc.ReadRecord(opened)
+}
+
+// Close closes the current WARC file, the next record written starts a new one
+func (a *WARCArchive) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.closeFile()
+	return nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// safeFileName replaces the characters of name which may not appear in a WARC file name
+func safeFileName(name string) string {
+	result := unsafeFileNameChars.ReplaceAllString(name, "_")
+	if result == "" {
+		return "_"
+	}
+	return result
+}
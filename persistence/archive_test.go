@@ -0,0 +1,108 @@
+package persistence
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/warc"
+)
+
+// newWARCArchive constructs the archive of bundle writing into path
+func (suite *DatastoreSuite) newWARCArchive(bundle models.SettingsBundleName, path string) *WARCArchive {
+	archivesPath := models.DirectoryPath(path)
+	return NewWARCArchive(suite.observatory, bundle, &models.StorageSettings{ArchivesPath: &archivesPath})
+}
+
+// appendRecord writes a response record whose block is text and returns where it was written
+func (suite *DatastoreSuite) appendRecord(archive *WARCArchive, text string) *WARCLocation {
+	locations, err := archive.Append([]*warc.Record{warc.NewRecord(warc.TypeResponse, time.Now(), []byte(text))}, suite.span)
+	suite.Nil(err, "Unable to append '%s'", text)
+	suite.Len(locations, 1, "Unable to append '%s'", text)
+	return locations[0]
+}
+
+func (suite *DatastoreSuite) TestWARCArchive() {
+	archive := suite.newWARCArchive("TEST", suite.tempDir())
+	defer archive.Close()
+
+	first := suite.appendRecord(archive, "first")
+	second := suite.appendRecord(archive, "second")
+	suite.Equal(first.File, second.File, "Records should be appended to the same file")
+	suite.Equal(first.Offset+first.Length, second.Offset, "Records should follow each other")
+	suite.True(regexp.MustCompile(`^TEST-[0-9]{8}T[0-9]{6}Z-00001\.warc\.gz$`).MatchString(first.File), first.File)
+
+	for text, location := range map[string]*WARCLocation{"first": first, "second": second} {
+		record, err := archive.Read(location.File, location.Offset)
+		if suite.Nil(err, text) {
+			suite.Equal(warc.TypeResponse, record.Get(warc.FieldType), text)
+			suite.Equal(text, string(record.Block), text)
+		}
+	}
+	info, err := archive.Read(first.File, 0)
+	if suite.Nil(err, "The file should start with a warcinfo record") {
+		suite.Equal(warc.TypeWarcinfo, info.Get(warc.FieldType))
+		suite.Equal(first.File, info.Get(warc.FieldFilename))
+	}
+
+	invalid := []struct {
+		file   string
+		offset int64
+	}{
+		{file: "../" + first.File},
+		{file: "TEST.warc.gz"},
+		{file: "storage.json"},
+		{file: first.File, offset: -1},
+		{file: first.File, offset: first.Offset + 1},
+	}
+	for _, test := range invalid {
+		_, err := archive.Read(test.file, test.offset)
+		suite.NotNil(err, "'%s' at %d shouldn't be read", test.file, test.offset)
+	}
+
+	archive.Close()
+	third := suite.appendRecord(archive, "third")
+	suite.NotEqual(first.File, third.File, "A closed archive should start a new file")
+	suite.True(regexp.MustCompile(`^TEST-[0-9]{8}T[0-9]{6}Z-00002\.warc\.gz$`).MatchString(third.File), third.File)
+}
+
+func (suite *DatastoreSuite) TestWARCArchiveResumesFile() {
+	path := suite.tempDir()
+	archive := suite.newWARCArchive("TEST", path)
+	first := suite.appendRecord(archive, "first")
+	other := suite.newWARCArchive("TEST-2", path)
+	suite.appendRecord(other, "other bundle")
+	other.Close()
+	archive.Close()
+
+	// a restarted daemon appends to the bundle's latest file after the records already in it
+	restarted := suite.newWARCArchive("TEST", path)
+	second := suite.appendRecord(restarted, "second")
+	suite.Equal(first.File, second.File, "The latest file should be resumed")
+	suite.Equal(first.Offset+first.Length, second.Offset, "The file's existing bytes should be counted")
+	for text, location := range map[string]*WARCLocation{"first": first, "second": second} {
+		record, err := restarted.Read(location.File, location.Offset)
+		if suite.Nil(err, text) {
+			suite.Equal(text, string(record.Block), text)
+		}
+	}
+	restarted.Close()
+
+	// a full file isn't resumed, the next one continues its sequence
+	full, err := os.OpenFile(filepath.Join(path, "TEST-99991231T235959Z-00007.warc.gz"), os.O_CREATE|os.O_WRONLY, 0600)
+	suite.Nil(err, "Unable to create a full file")
+	suite.Nil(full.Truncate(MaxWARCFileBytes), "Unable to fill the file")
+	full.Close()
+
+	restarted = suite.newWARCArchive("TEST", path)
+	defer restarted.Close()
+	third := suite.appendRecord(restarted, "third")
+	suite.True(regexp.MustCompile(`^TEST-[0-9]{8}T[0-9]{6}Z-00008\.warc\.gz$`).MatchString(third.File), "The sequence should continue after the full file: %s", third.File)
+
+	files, err := ioutil.ReadDir(path)
+	suite.Nil(err, "Unable to list the archive")
+	suite.Len(files, 4, "Only the full file should have caused a new one")
+}
@@ -0,0 +1,470 @@
+package persistence
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// BackupFormatVersion is the version of the archive layout written by Backup
+const BackupFormatVersion = 1
+
+const (
+	backupManifestName = "manifest.json"
+	backupRecordsDir   = "records/"
+)
+
+// MaxBackupRecordBytes and MaxBackupManifestBytes limit the archive entries Restore reads into memory
+const (
+	MaxBackupRecordBytes   int64 = 64 << 20
+	MaxBackupManifestBytes int64 = 256 << 20
+)
+
+// ErrBackupsPathNotConfigured is returned by BackupToFile and RestoreFromFile when storage.backupsPath isn't set
+var ErrBackupsPathNotConfigured = errors.New("Storage backupsPath is not configured, backups are disabled")
+
+// BackupManifestEntry describes a single record in a backup archive
+type BackupManifestEntry struct {
+	Key    string `json:"key"`
+	File   string `json:"file"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BackupManifest is stored as the last entry of a backup archive and lists every record with its checksum
+type BackupManifest struct {
+	FormatVersion int                       `json:"formatVersion"`
+	Bundle        models.SettingsBundleName `json:"bundle"`
+	StorageType   models.StorageType        `json:"storageType"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+	Bytes         int64                     `json:"bytes"`
+	Encrypted     bool                      `json:"encrypted"`
+	Records       []BackupManifestEntry     `json:"records"`
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Backup streams every record of the Datastore into w as a gzip compressed tar archive. Records of an
+// encrypted Datastore are written as they're stored, together with their wrapped data keys, so the archive
+// can only be restored into storage using the same master key file.
+func (d *Datastore) Backup(w io.Writer, bundle models.SettingsBundleName, parent opentracing.Span) (*BackupManifest, error) {
+	span := d.observatory.StartChildTrace("persistence.Backup", parent)
+	defer span.Finish()
+
+	fail := func(err error) (*BackupManifest, error) {
+		error := fmt.Errorf("Unable to backup storage of bundle '%s': %v", bundle, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+
+	keys, err := d.backupKeys()
+	if err != nil {
+		return fail(err)
+	}
+
+	manifest := &BackupManifest{FormatVersion: BackupFormatVersion, Bundle: bundle, StorageType: d.config.Type, CreatedAt: time.Now(), Encrypted: d.IsEncrypted()}
+	compressed := gzip.NewWriter(w)
+	archive := tar.NewWriter(compressed)
+	write := func(name string, data []byte) error {
+		err := archive.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: manifest.CreatedAt})
+		if err != nil {
+			return err
+		}
+		_, err = archive.Write(data)
+		return err
+	}
+
+	for _, key := range keys {
+		var value interface{}
+		if d.IsEncrypted() {
+			value, err = d.store.Get(encodeKey(key))
+		} else {
+			value, err = d.Get(key)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		data, ok := value.([]byte)
+		if !ok {
+			return fail(fmt.Errorf("unexpected value type %T stored in '%s'", value, key))
+		}
+		name := backupRecordsDir + encodeKey(key).String()[1:]
+		err = write(name, data)
+		if err != nil {
+			return fail(err)
+		}
+		manifest.Records = append(manifest.Records, BackupManifestEntry{Key: key.String(), File: name, Size: int64(len(data)), SHA256: checksum(data)})
+		manifest.Bytes += int64(len(data))
+	}
+
+	encoded, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return fail(err)
+	}
+	err = write(backupManifestName, encoded)
+	if err == nil {
+		err = archive.Close()
+	}
+	if err == nil {
+		err = compressed.Close()
+	}
+	if err != nil {
+		return fail(err)
+	}
+	span.LogFields(log.Int("records", len(manifest.Records)), log.Int64("bytes", manifest.Bytes))
+	return manifest, nil
+}
+
+// backupKeys returns the keys of the records Backup writes. An encrypted Datastore's wrapped data keys are
+// included and come first, so Restore checks them before it writes any record.
+func (d *Datastore) backupKeys() ([]datastore.Key, error) {
+	if !d.IsEncrypted() {
+		results, err := d.Query(dsq.Query{KeysOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		entries, err := results.Rest()
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]datastore.Key, 0, len(entries))
+		for _, entry := range entries {
+			keys = append(keys, datastore.RawKey(entry.Key))
+		}
+		return keys, nil
+	}
+
+	listing, err := d.store.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	physical, err := listing.Rest()
+	if err != nil {
+		return nil, err
+	}
+	var dataKeys, records []datastore.Key
+	for _, p := range physical {
+		key, err := decodeKey(p.Key)
+		if err != nil || len(key.Namespaces()) == 0 {
+			continue
+		}
+		if key.Namespaces()[0] == string(DataKeysCollection) {
+			dataKeys = append(dataKeys, key)
+		} else {
+			records = append(records, key)
+		}
+	}
+	return append(dataKeys, records...), nil
+}
+
+// readBackupEntry reads the current entry of archive, refusing entries larger than limit
+func readBackupEntry(archive *tar.Reader, header *tar.Header, limit int64) ([]byte, error) {
+	if header.Size > limit {
+		return nil, fmt.Errorf("'%s' is %d bytes, the limit is %d", header.Name, header.Size, limit)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(archive, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("'%s' is larger than the limit of %d bytes", header.Name, limit)
+	}
+	return data, nil
+}
+
+// verifyBackup reads an archive written by Backup and checks every record against the manifest
+// without keeping the records, it returns the manifest and the checksum of the whole archive
+func verifyBackup(r io.Reader) (*BackupManifest, []byte, error) {
+	hash := sha256.New()
+	tee := io.TeeReader(r, hash)
+	compressed, err := gzip.NewReader(tee)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer compressed.Close()
+
+	var manifest *BackupManifest
+	files := make(map[string]BackupManifestEntry)
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.Name == backupManifestName {
+			data, err := readBackupEntry(archive, header, MaxBackupManifestBytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			manifest = new(BackupManifest)
+			err = json.Unmarshal(data, manifest)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid manifest: %v", err)
+			}
+			continue
+		}
+		if header.Size > MaxBackupRecordBytes {
+			return nil, nil, fmt.Errorf("'%s' is %d bytes, the limit is %d", header.Name, header.Size, MaxBackupRecordBytes)
+		}
+		sum := sha256.New()
+		size, err := io.Copy(sum, io.LimitReader(archive, MaxBackupRecordBytes+1))
+		if err != nil {
+			return nil, nil, err
+		}
+		files[header.Name] = BackupManifestEntry{File: header.Name, Size: size, SHA256: hex.EncodeToString(sum.Sum(nil))}
+	}
+	// drain the gzip trailer so the archive checksum covers the whole file
+	_, err = io.Copy(ioutil.Discard, compressed)
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, tee)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("archive has no %s", backupManifestName)
+	}
+	if manifest.FormatVersion > BackupFormatVersion {
+		return nil, nil, fmt.Errorf("archive format version %d is newer than supported version %d", manifest.FormatVersion, BackupFormatVersion)
+	}
+	if len(files) != len(manifest.Records) {
+		return nil, nil, fmt.Errorf("archive has %d records, manifest lists %d", len(files), len(manifest.Records))
+	}
+	for _, record := range manifest.Records {
+		file, ok := files[record.File]
+		if !ok {
+			return nil, nil, fmt.Errorf("record '%s' listed in manifest is missing", record.Key)
+		}
+		if file.Size != record.Size || file.SHA256 != record.SHA256 {
+			return nil, nil, fmt.Errorf("checksum mismatch for record '%s'", record.Key)
+		}
+	}
+	return manifest, hash.Sum(nil), nil
+}
+
+// Restore reads an archive written by Backup and writes every record into this Datastore. Records with
+// the same key are replaced, other existing records are kept. The archive is read twice so nothing is
+// written unless the whole archive is valid, and records are put one at a time as they're read. Encrypted
+// archives are only restored into an encrypted Datastore which has the master keys they were made with.
+func (d *Datastore) Restore(r io.ReadSeeker, parent opentracing.Span) (*BackupManifest, []byte, error) {
+	span := d.observatory.StartChildTrace("persistence.Restore", parent)
+	defer span.Finish()
+
+	fail := func(err error) (*BackupManifest, []byte, error) {
+		error := fmt.Errorf("Unable to restore storage: %v", err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, nil, error
+	}
+
+	manifest, sum, err := verifyBackup(r)
+	if err != nil {
+		return fail(err)
+	}
+	if manifest.Encrypted && !d.IsEncrypted() {
+		return fail(fmt.Errorf("the backup of '%s' is encrypted, restore it into storage using the same encryption key file", manifest.Bundle))
+	}
+	records := make(map[string]BackupManifestEntry, len(manifest.Records))
+	for _, record := range manifest.Records {
+		records[record.File] = record
+	}
+
+	_, err = r.Seek(0, io.SeekStart)
+	if err != nil {
+		return fail(err)
+	}
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return fail(err)
+	}
+	defer compressed.Close()
+
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		record, ok := records[header.Name]
+		if !ok {
+			continue
+		}
+		data, err := readBackupEntry(archive, header, MaxBackupRecordBytes)
+		if err != nil {
+			return fail(err)
+		}
+		// the archive may have changed since it was verified
+		if int64(len(data)) != record.Size || checksum(data) != record.SHA256 {
+			return fail(fmt.Errorf("checksum mismatch for record '%s'", record.Key))
+		}
+		if manifest.Encrypted {
+			err = d.restoreSealed(datastore.RawKey(record.Key), data)
+		} else {
+			err = d.Put(datastore.RawKey(record.Key), data)
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+	span.LogFields(log.String("bundle", string(manifest.Bundle)), log.Int("records", len(manifest.Records)))
+	return manifest, sum, nil
+}
+
+// restoreSealed writes a record of an encrypted archive as it was stored. Wrapped data keys are added to
+// the tenant's existing ones, so records which aren't in the archive can still be decrypted, and other
+// records must decrypt with them before they're written.
+func (d *Datastore) restoreSealed(key datastore.Key, data []byte) error {
+	namespaces := key.Namespaces()
+	if len(namespaces) == 0 {
+		return fmt.Errorf("invalid record key '%s'", key)
+	}
+	if namespaces[0] != string(DataKeysCollection) {
+		if !bytes.HasPrefix(data, sealedValuePrefix) {
+			return fmt.Errorf("'%s' is not encrypted", key)
+		}
+		plaintext, err := d.encryption.decrypt(d, key, data)
+		if err != nil {
+			return err
+		}
+		err = d.store.Put(encodeKey(key), data)
+		if err == nil {
+			d.trackPut(key, plaintext)
+		}
+		return err
+	}
+
+	restored := new(tenantDataKeys)
+	err := json.Unmarshal(data, restored)
+	if err != nil {
+		return fmt.Errorf("unable to decode data keys '%s': %v", key, err)
+	}
+	tenant := datastore.KeyWithNamespaces(namespaces[1:])
+
+	d.encryption.mutex.Lock()
+	defer d.encryption.mutex.Unlock()
+	for id, wrapped := range restored.Keys {
+		if _, ok := d.encryption.masterKeys[wrapped.MasterKeyID]; !ok {
+			return fmt.Errorf("master key '%s' needed for data key '%s' of '%s' is not in '%s'", wrapped.MasterKeyID, id, tenant, d.encryption.keyFile)
+		}
+	}
+	keys, err := d.encryption.loadDataKeys(d, tenant)
+	if err != nil {
+		return err
+	}
+	if keys == nil {
+		keys = restored
+	} else {
+		for id, wrapped := range restored.Keys {
+			keys.Keys[id] = wrapped
+		}
+	}
+	return d.encryption.saveDataKeys(d, tenant, keys)
+}
+
+// backupFilePath returns name if it's inside the storage's backupsPath, relative names are within backupsPath
+func (d *Datastore) backupFilePath(name models.FilePathAndName) (string, error) {
+	if d.config.BackupsPath == nil || *d.config.BackupsPath == "" {
+		return "", ErrBackupsPathNotConfigured
+	}
+	directory, err := filepath.Abs(string(*d.config.BackupsPath))
+	if err != nil {
+		return "", err
+	}
+	path := string(name)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(directory, path)
+	}
+	relative, err := filepath.Rel(directory, filepath.Clean(path))
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("Backup file '%s' is not in the storage backupsPath '%s'", name, directory)
+	}
+	return filepath.Join(directory, relative), nil
+}
+
+// BackupToFile writes a backup of the Datastore into a new file in the storage's backupsPath, it refuses
+// to back up when backupsPath isn't configured
+func (d *Datastore) BackupToFile(bundle models.SettingsBundleName, parent opentracing.Span) (*models.StorageBackup, error) {
+	if d.config.BackupsPath == nil || *d.config.BackupsPath == "" {
+		return nil, ErrBackupsPathNotConfigured
+	}
+	name := filepath.Join(string(*d.config.BackupsPath), fmt.Sprintf("%s-%s.lectio-backup.tar.gz", bundle, time.Now().UTC().Format("20060102T150405Z")))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create backup file '%s': %v", name, err)
+	}
+
+	hash := sha256.New()
+	manifest, err := d.Backup(io.MultiWriter(file, hash), bundle, parent)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("Unable to write backup file '%s': %v", name, closeErr)
+	}
+	if err != nil {
+		os.Remove(name)
+		return nil, err
+	}
+	return storageBackup(manifest, models.FilePathAndName(name), hash.Sum(nil)), nil
+}
+
+// RestoreFromFile restores a backup file written by BackupToFile, only files in the storage's backupsPath are read
+func (d *Datastore) RestoreFromFile(name models.FilePathAndName, parent opentracing.Span) (*models.StorageBackup, error) {
+	path, err := d.backupFilePath(name)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read backup file '%s': %v", name, err)
+	}
+	defer file.Close()
+
+	manifest, sum, err := d.Restore(file, parent)
+	if err != nil {
+		return nil, err
+	}
+	return storageBackup(manifest, name, sum), nil
+}
+
+func storageBackup(manifest *BackupManifest, name models.FilePathAndName, sum []byte) *models.StorageBackup {
+	result := new(models.StorageBackup)
+	result.Bundle = manifest.Bundle
+	result.File = name
+	result.StorageType = manifest.StorageType
+	result.CreatedAt = models.NewDateTime(manifest.CreatedAt)
+	result.Records = models.StorageRecordsCount(len(manifest.Records))
+	result.Bytes = models.StorageBytesCount(manifest.Bytes)
+	result.Checksum = models.Checksum(hex.EncodeToString(sum))
+	return result
+}
@@ -0,0 +1,220 @@
+package persistence
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/lectio/lectiod/models"
+)
+
+// backupEntry is a file written into a test archive
+type backupEntry struct {
+	name string
+	data []byte
+	size int64
+}
+
+// writeArchive writes entries as a gzip compressed tar archive the way Backup does, a non-zero size
+// overrides the size in the entry's header (which truncates the archive, so it must be the last entry)
+func (suite *DatastoreSuite) writeArchive(entries []backupEntry) []byte {
+	var buffer bytes.Buffer
+	compressed := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(compressed)
+	for _, entry := range entries {
+		size := int64(len(entry.data))
+		if entry.size != 0 {
+			size = entry.size
+		}
+		archive.WriteHeader(&tar.Header{Name: entry.name, Mode: 0600, Size: size})
+		archive.Write(entry.data)
+	}
+	archive.Close()
+	suite.Nil(compressed.Close())
+	return buffer.Bytes()
+}
+
+// withBackupsPath configures storage to keep its backups in directory
+func withBackupsPath(directory string) func(*models.StorageSettings) {
+	return func(config *models.StorageSettings) {
+		path := models.DirectoryPath(directory)
+		config.BackupsPath = &path
+	}
+}
+
+func (suite *DatastoreSuite) TestBackupAndRestore() {
+	directory := suite.tempDir()
+	source := suite.newDatastore(withBackupsPath(directory))
+	records := map[datastore.Key]string{
+		RecordKey(SessionsCollection, "session"):                               "session",
+		datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1"): "resource",
+		RecordKey(JobsCollection, "job"):                                       "job",
+	}
+	for key, value := range records {
+		suite.Nil(source.Put(key, []byte(value)))
+	}
+
+	backup, err := source.BackupToFile("TEST", suite.span)
+	if !suite.Nil(err, "Unable to backup") {
+		return
+	}
+	suite.Equal(directory, filepath.Dir(string(backup.File)))
+	suite.Equal(models.StorageRecordsCount(len(records)), backup.Records)
+	suite.Equal(models.StorageBytesCount(len("session")+len("resource")+len("job")), backup.Bytes)
+	contents, err := ioutil.ReadFile(string(backup.File))
+	suite.Nil(err)
+	sum := sha256.Sum256(contents)
+	suite.Equal(models.Checksum(hex.EncodeToString(sum[:])), backup.Checksum)
+
+	plain := suite.newDatastore(withBackupsPath(directory))
+	encrypted, _ := suite.newEncryptedDatastore(withBackupsPath(directory))
+	for _, target := range []*Datastore{plain, encrypted} {
+		suite.Nil(target.Put(RecordKey(SessionsCollection, "kept"), []byte("kept")))
+		restored, err := target.RestoreFromFile(backup.File, suite.span)
+		suite.Nil(err, "Unable to restore")
+		suite.Equal(backup.Checksum, restored.Checksum)
+		suite.Equal(backup.Records, restored.Records)
+		for key, value := range records {
+			stored, err := target.Get(key)
+			suite.Nil(err, "%s should be restored", key)
+			suite.Equal([]byte(value), stored)
+		}
+		kept, err := target.Get(RecordKey(SessionsCollection, "kept"))
+		suite.Nil(err, "Existing records should be kept")
+		suite.Equal([]byte("kept"), kept)
+	}
+}
+
+func (suite *DatastoreSuite) TestEncryptedBackup() {
+	directory := suite.tempDir()
+	source, keyFile := suite.newEncryptedDatastore(withBackupsPath(directory))
+	key := datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1")
+	suite.Nil(source.Put(key, []byte("secret resource")))
+	backup, err := source.BackupToFile("TEST", suite.span)
+	if !suite.Nil(err, "Unable to backup") {
+		return
+	}
+
+	contents, err := ioutil.ReadFile(string(backup.File))
+	suite.Nil(err)
+	uncompressed, err := gzip.NewReader(bytes.NewReader(contents))
+	if suite.Nil(err) {
+		archive, _ := ioutil.ReadAll(uncompressed)
+		suite.False(bytes.Contains(archive, []byte("secret resource")), "Records should stay encrypted in the backup")
+	}
+
+	// records are restored with their data keys into storage sharing the key file
+	target := suite.newDatastore(func(config *models.StorageSettings) {
+		withBackupsPath(directory)(config)
+		config.Encryption = &models.StorageEncryptionSettings{KeyFile: models.FilePathAndName(keyFile)}
+	})
+	other := datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/2")
+	suite.Nil(target.Put(other, []byte("existing resource")))
+	_, err = target.RestoreFromFile(backup.File, suite.span)
+	suite.Nil(err, "Unable to restore into storage with the same key file")
+	for key, value := range map[datastore.Key]string{key: "secret resource", other: "existing resource"} {
+		stored, err := target.Get(key)
+		if suite.Nil(err, "%s should be readable", key) {
+			suite.Equal([]byte(value), stored)
+		}
+	}
+
+	plain := suite.newDatastore(withBackupsPath(directory))
+	_, err = plain.RestoreFromFile(backup.File, suite.span)
+	suite.NotNil(err, "An encrypted backup can't be restored into unencrypted storage")
+	otherKeys, _ := suite.newEncryptedDatastore(withBackupsPath(directory))
+	_, err = otherKeys.RestoreFromFile(backup.File, suite.span)
+	suite.NotNil(err, "An encrypted backup can't be restored without its master key")
+	exists, _ := otherKeys.Has(key)
+	suite.False(exists, "Nothing should be written without the master key")
+}
+
+func (suite *DatastoreSuite) TestBackupsPath() {
+	_, err := suite.newDatastore(nil).BackupToFile("TEST", suite.span)
+	suite.Equal(ErrBackupsPathNotConfigured, err, "Backups should be disabled without backupsPath")
+
+	directory := suite.tempDir()
+	store := suite.newDatastore(withBackupsPath(directory))
+	backup, err := store.BackupToFile("TEST", suite.span)
+	if !suite.Nil(err, "Unable to backup") {
+		return
+	}
+	outside := filepath.Join(suite.tempDir(), "backup.tar.gz")
+	contents, _ := ioutil.ReadFile(string(backup.File))
+	suite.Nil(ioutil.WriteFile(outside, contents, 0600))
+
+	tests := []struct {
+		name  string
+		file  string
+		valid bool
+	}{
+		{name: "absolute", file: string(backup.File), valid: true},
+		{name: "relative", file: filepath.Base(string(backup.File)), valid: true},
+		{name: "outside", file: outside},
+		{name: "relative outside", file: filepath.Join("..", filepath.Base(filepath.Dir(outside)), "backup.tar.gz")},
+		{name: "escaping", file: filepath.Join(directory, "..", filepath.Base(filepath.Dir(outside)), "backup.tar.gz")},
+	}
+	for _, test := range tests {
+		_, err := store.RestoreFromFile(models.FilePathAndName(test.file), suite.span)
+		if test.valid {
+			suite.Nil(err, test.name)
+		} else {
+			suite.NotNil(err, test.name)
+		}
+	}
+	_, err = suite.newDatastore(nil).RestoreFromFile(backup.File, suite.span)
+	suite.Equal(ErrBackupsPathNotConfigured, err, "Restores should be disabled without backupsPath")
+}
+
+func (suite *DatastoreSuite) TestInvalidBackupsAreNotRestored() {
+	key := RecordKey(SessionsCollection, "session")
+	name := backupRecordsDir + encodeKey(key).String()[1:]
+	manifest := func(formatVersion int, size int64, sha string) []byte {
+		encoded, _ := json.Marshal(BackupManifest{FormatVersion: formatVersion, Records: []BackupManifestEntry{{Key: key.String(), File: name, Size: size, SHA256: sha}}})
+		return encoded
+	}
+	record := []byte("session")
+	valid := manifest(BackupFormatVersion, int64(len(record)), checksum(record))
+
+	tests := []struct {
+		name    string
+		entries []backupEntry
+		valid   bool
+	}{
+		{name: "valid", entries: []backupEntry{{name: name, data: record}, {name: backupManifestName, data: valid}}, valid: true},
+		{name: "no manifest", entries: []backupEntry{{name: name, data: record}}},
+		{name: "invalid manifest", entries: []backupEntry{{name: name, data: record}, {name: backupManifestName, data: []byte("{")}}},
+		{name: "newer format", entries: []backupEntry{{name: name, data: record}, {name: backupManifestName, data: manifest(BackupFormatVersion+1, int64(len(record)), checksum(record))}}},
+		{name: "tampered record", entries: []backupEntry{{name: name, data: []byte("tampers")}, {name: backupManifestName, data: valid}}},
+		{name: "missing record", entries: []backupEntry{{name: name + "X", data: record}, {name: backupManifestName, data: valid}}},
+		{name: "unlisted record", entries: []backupEntry{{name: name, data: record}, {name: name + "X", data: record}, {name: backupManifestName, data: valid}}},
+		{name: "record over limit", entries: []backupEntry{{name: backupManifestName, data: valid}, {name: name, data: record, size: MaxBackupRecordBytes + 1}}},
+		{name: "not gzip", entries: nil},
+	}
+	for _, test := range tests {
+		archive := []byte("not an archive")
+		if test.entries != nil {
+			archive = suite.writeArchive(test.entries)
+		}
+		directory := suite.tempDir()
+		file := filepath.Join(directory, "backup.tar.gz")
+		suite.Nil(ioutil.WriteFile(file, archive, 0600))
+
+		store := suite.newDatastore(withBackupsPath(directory))
+		_, err := store.RestoreFromFile(models.FilePathAndName(file), suite.span)
+		exists, _ := store.Has(key)
+		if test.valid {
+			suite.Nil(err, test.name)
+			suite.True(exists, "%s should be restored", test.name)
+			continue
+		}
+		suite.NotNil(err, "%s should fail", test.name)
+		suite.False(exists, "Nothing should be written from %s", test.name)
+	}
+}
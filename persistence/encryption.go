@@ -0,0 +1,483 @@
+package persistence
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DataKeysCollection holds the wrapped per-tenant data keys, these records are never encrypted themselves
+const DataKeysCollection Collection = "datakeys"
+
+// encryptionKeySize is the size of master and data keys, 32 bytes selects AES-256
+const encryptionKeySize = 32
+
+// sealedValuePrefix marks values written by envelopeEncryption. Only RotateEncryptionKeys accepts
+// values without it, so it can encrypt the plaintext records written before encryption was enabled.
+var sealedValuePrefix = []byte("LECTIO-AESGCM:")
+
+// MasterKeyFile is the format of the key file referenced by StorageSettings.Encryption.KeyFile.
+// Keys are hex encoded; older keys must be kept until a rotation has re-wrapped every data key.
+type MasterKeyFile struct {
+	ActiveKeyID string            `json:"activeKeyId"`
+	Keys        map[string]string `json:"keys"`
+}
+
+// wrappedDataKey is a tenant data key encrypted with a master key
+type wrappedDataKey struct {
+	MasterKeyID string    `json:"masterKeyId"`
+	Nonce       []byte    `json:"nonce"`
+	Wrapped     []byte    `json:"wrapped"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// tenantDataKeys is the stored set of data keys of a single tenant
+type tenantDataKeys struct {
+	ActiveKeyID string                     `json:"activeKeyId"`
+	Keys        map[string]*wrappedDataKey `json:"keys"`
+}
+
+// sealedValue is the stored form of an encrypted value
+type sealedValue struct {
+	DataKeyID  string `json:"dataKeyId"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// envelopeEncryption encrypts every value with an AES-GCM data key belonging to the value's
+// tenant; data keys are themselves encrypted ("wrapped") with a master key from the key file
+type envelopeEncryption struct {
+	keyFile    string
+	masterKeys map[string][]byte
+	activeKey  string
+	dataKeys   map[string]*tenantDataKeys
+	mutex      sync.Mutex
+}
+
+// ReadMasterKeyFile reads and validates a master key file
+func ReadMasterKeyFile(path string) (*MasterKeyFile, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read encryption key file '%s': %v", path, err)
+	}
+	result := new(MasterKeyFile)
+	err = json.Unmarshal(contents, result)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode encryption key file '%s': %v", path, err)
+	}
+	if _, ok := result.Keys[result.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("Encryption key file '%s' does not contain its active key '%s'", path, result.ActiveKeyID)
+	}
+	return result, nil
+}
+
+// AddMasterKey generates a new master key, adds it to the key file at path (creating the file if
+// necessary) and makes it the active key. Run a key rotation afterwards to re-wrap existing data keys.
+func AddMasterKey(path string) (string, error) {
+	file, err := ReadMasterKeyFile(path)
+	if err != nil {
+		if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+			return "", err
+		}
+		file = &MasterKeyFile{Keys: make(map[string]string)}
+	}
+
+	key, err := newEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+	suffix, err := newKeyID()
+	if err != nil {
+		return "", err
+	}
+	id := time.Now().UTC().Format("20060102T150405Z") + "-" + suffix
+	file.Keys[id] = hex.EncodeToString(key)
+	file.ActiveKeyID = id
+
+	contents, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(path, contents, 0600)
+	if err != nil {
+		return "", fmt.Errorf("Unable to write encryption key file '%s': %v", path, err)
+	}
+	return id, nil
+}
+
+func newEnvelopeEncryption(keyFile string) (*envelopeEncryption, error) {
+	result := new(envelopeEncryption)
+	result.keyFile = keyFile
+	result.dataKeys = make(map[string]*tenantDataKeys)
+	err := result.loadMasterKeys()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadMasterKeys (re)reads the master keys from the key file. Unwrapped data keys stay cached because
+// adding master keys doesn't change them. The caller must hold the mutex once e is in use.
+func (e *envelopeEncryption) loadMasterKeys() error {
+	file, err := ReadMasterKeyFile(e.keyFile)
+	if err != nil {
+		return err
+	}
+	masterKeys := make(map[string][]byte)
+	for id, encoded := range file.Keys {
+		key, err := hex.DecodeString(encoded)
+		if err != nil || len(key) != encryptionKeySize {
+			return fmt.Errorf("Encryption key '%s' in '%s' must be %d hex encoded bytes", id, e.keyFile, encryptionKeySize)
+		}
+		masterKeys[id] = key
+	}
+	e.masterKeys = masterKeys
+	e.activeKey = file.ActiveKeyID
+	return nil
+}
+
+func newEncryptionKey() ([]byte, error) {
+	key := make([]byte, encryptionKeySize)
+	_, err := io.ReadFull(rand.Reader, key)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to generate encryption key: %v", err)
+	}
+	return key, nil
+}
+
+func newKeyID() (string, error) {
+	id := make([]byte, 8)
+	_, err := io.ReadFull(rand.Reader, id)
+	if err != nil {
+		return "", fmt.Errorf("Unable to generate key ID: %v", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, []byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nonce, gcm.Seal(nil, nonce, plaintext, additionalData), nil
+}
+
+func open(key []byte, nonce []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// TenantKey returns the namespace which owns the data key used for key: each storage destination
+// (e.g. /resources/SESSION_TENANT/reading-list) is a tenant, other collections share one tenant each
+func TenantKey(key datastore.Key) datastore.Key {
+	namespaces := key.Namespaces()
+	if len(namespaces) > 3 && namespaces[0] == string(ResourcesCollection) {
+		return datastore.KeyWithNamespaces(namespaces[:3])
+	}
+	return datastore.KeyWithNamespaces(namespaces[:1])
+}
+
+func dataKeysRecordKey(tenant datastore.Key) datastore.Key {
+	return CollectionKey(DataKeysCollection).Child(tenant)
+}
+
+// loadDataKeys returns the (cached) data keys of tenant, nil if none were created yet
+func (e *envelopeEncryption) loadDataKeys(d *Datastore, tenant datastore.Key) (*tenantDataKeys, error) {
+	if keys, ok := e.dataKeys[tenant.String()]; ok {
+		return keys, nil
+	}
+	stored, err := d.store.Get(encodeKey(dataKeysRecordKey(tenant)))
+	if err == datastore.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := stored.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected value type %T stored in data keys of '%s'", stored, tenant)
+	}
+	keys := new(tenantDataKeys)
+	err = json.Unmarshal(raw, keys)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode data keys of '%s': %v", tenant, err)
+	}
+	e.dataKeys[tenant.String()] = keys
+	return keys, nil
+}
+
+func (e *envelopeEncryption) saveDataKeys(d *Datastore, tenant datastore.Key, keys *tenantDataKeys) error {
+	encoded, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	err = d.store.Put(encodeKey(dataKeysRecordKey(tenant)), encoded)
+	if err != nil {
+		return err
+	}
+	e.dataKeys[tenant.String()] = keys
+	return nil
+}
+
+// addDataKey creates a new data key for tenant, wraps it with the active master key and makes it active
+func (e *envelopeEncryption) addDataKey(d *Datastore, tenant datastore.Key, keys *tenantDataKeys) (*tenantDataKeys, error) {
+	if keys == nil {
+		keys = &tenantDataKeys{Keys: make(map[string]*wrappedDataKey)}
+	}
+	key, err := newEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	id, err := newKeyID()
+	if err != nil {
+		return nil, err
+	}
+	nonce, wrapped, err := seal(e.masterKeys[e.activeKey], key, tenant.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to wrap data key of '%s': %v", tenant, err)
+	}
+	keys.Keys[id] = &wrappedDataKey{MasterKeyID: e.activeKey, Nonce: nonce, Wrapped: wrapped, CreatedAt: time.Now()}
+	keys.ActiveKeyID = id
+	return keys, e.saveDataKeys(d, tenant, keys)
+}
+
+// dataKey unwraps the data key id of tenant
+func (e *envelopeEncryption) dataKey(tenant datastore.Key, keys *tenantDataKeys, id string) ([]byte, error) {
+	wrapped, ok := keys.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("Data key '%s' of '%s' not found", id, tenant)
+	}
+	masterKey, ok := e.masterKeys[wrapped.MasterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("Master key '%s' needed for data key '%s' of '%s' is not in '%s'", wrapped.MasterKeyID, id, tenant, e.keyFile)
+	}
+	key, err := open(masterKey, wrapped.Nonce, wrapped.Wrapped, tenant.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to unwrap data key '%s' of '%s': %v", id, tenant, err)
+	}
+	return key, nil
+}
+
+// encrypt seals value with the active data key of key's tenant, creating the data key if necessary
+func (e *envelopeEncryption) encrypt(d *Datastore, key datastore.Key, value interface{}) (interface{}, error) {
+	plaintext, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("Only []byte values can be encrypted, '%s' is %T", key, value)
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	tenant := TenantKey(key)
+	keys, err := e.loadDataKeys(d, tenant)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys, err = e.addDataKey(d, tenant, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	dataKey, err := e.dataKey(tenant, keys, keys.ActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+	nonce, ciphertext, err := seal(dataKey, plaintext, key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encrypt '%s': %v", key, err)
+	}
+	encoded, err := json.Marshal(sealedValue{DataKeyID: keys.ActiveKeyID, Nonce: nonce, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, sealedValuePrefix...), encoded...), nil
+}
+
+// decrypt opens a value written by encrypt, values which weren't are an error
+func (e *envelopeEncryption) decrypt(d *Datastore, key datastore.Key, value interface{}) (interface{}, error) {
+	stored, ok := value.([]byte)
+	if !ok || !bytes.HasPrefix(stored, sealedValuePrefix) {
+		return nil, fmt.Errorf("'%s' is not encrypted, run rotate-storage-keys to encrypt records stored before encryption was enabled", key)
+	}
+	var sealed sealedValue
+	err := json.Unmarshal(stored[len(sealedValuePrefix):], &sealed)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decode encrypted value of '%s': %v", key, err)
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	tenant := TenantKey(key)
+	keys, err := e.loadDataKeys(d, tenant)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		return nil, fmt.Errorf("No data keys found for '%s', unable to decrypt '%s'", tenant, key)
+	}
+	dataKey, err := e.dataKey(tenant, keys, sealed.DataKeyID)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataKey, sealed.Nonce, sealed.Ciphertext, key.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to decrypt '%s': %v", key, err)
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted returns true if values are encrypted before they're written to the underlying store
+func (d *Datastore) IsEncrypted() bool {
+	return d.encryption != nil
+}
+
+// RotateEncryptionKeys gives every tenant a new data key wrapped with the active master key,
+// re-encrypts all records (including ones stored before encryption was enabled) and then drops
+// the old data keys. It returns the number of records re-encrypted. Other processes may have the
+// old data keys cached so it takes the store's lock exclusively and fails while lectiod is running.
+func (d *Datastore) RotateEncryptionKeys(parent opentracing.Span) (int, error) {
+	span := d.observatory.StartChildTrace("persistence.RotateEncryptionKeys", parent)
+	defer span.Finish()
+
+	if d.encryption == nil {
+		error := fmt.Errorf("Encryption is not enabled in storage settings, nothing to rotate")
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return 0, error
+	}
+
+	if d.lock != nil {
+		err := d.lock.lockExclusive()
+		if err != nil {
+			error := fmt.Errorf("Unable to rotate encryption keys while the storage is in use, stop lectiod first: %v", err)
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(error))
+			return 0, error
+		}
+		defer d.lock.unlockExclusive()
+	}
+
+	// pick up master keys added to the key file since the Datastore was opened
+	d.encryption.mutex.Lock()
+	err := d.encryption.loadMasterKeys()
+	d.encryption.mutex.Unlock()
+	if err != nil {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+		return 0, err
+	}
+
+	results, err := d.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return 0, err
+	}
+	tenants := make(map[string][]datastore.Key)
+	for _, entry := range entries {
+		key := datastore.RawKey(entry.Key)
+		tenant := TenantKey(key).String()
+		tenants[tenant] = append(tenants[tenant], key)
+	}
+
+	count := 0
+	for tenantName, keys := range tenants {
+		tenant := datastore.RawKey(tenantName)
+		values := make(map[datastore.Key]interface{}, len(keys))
+		for _, key := range keys {
+			value, err := d.rotationValue(key)
+			if err != nil {
+				return count, d.rotationError(span, tenant, err)
+			}
+			values[key] = value
+		}
+
+		d.encryption.mutex.Lock()
+		previous, err := d.encryption.loadDataKeys(d, tenant)
+		var active *tenantDataKeys
+		if err == nil {
+			active, err = d.encryption.addDataKey(d, tenant, previous)
+		}
+		d.encryption.mutex.Unlock()
+		if err != nil {
+			return count, d.rotationError(span, tenant, err)
+		}
+
+		for key, value := range values {
+			err = d.Put(key, value)
+			if err != nil {
+				return count, d.rotationError(span, tenant, err)
+			}
+			count++
+		}
+
+		d.encryption.mutex.Lock()
+		for id := range active.Keys {
+			if id != active.ActiveKeyID {
+				delete(active.Keys, id)
+			}
+		}
+		err = d.encryption.saveDataKeys(d, tenant, active)
+		d.encryption.mutex.Unlock()
+		if err != nil {
+			return count, d.rotationError(span, tenant, err)
+		}
+		span.LogFields(log.String("tenant", tenantName), log.Int("records", len(keys)))
+	}
+	return count, nil
+}
+
+// rotationValue loads key for RotateEncryptionKeys, the only reader which accepts plaintext values
+func (d *Datastore) rotationValue(key datastore.Key) (interface{}, error) {
+	value, err := d.store.Get(encodeKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if stored, ok := value.([]byte); ok && !bytes.HasPrefix(stored, sealedValuePrefix) {
+		return value, nil
+	}
+	return d.encryption.decrypt(d, key, value)
+}
+
+func (d *Datastore) rotationError(span opentracing.Span, tenant datastore.Key, err error) error {
+	error := fmt.Errorf("Unable to rotate encryption keys of '%s': %v", strings.TrimPrefix(tenant.String(), "/"), err)
+	opentrext.Error.Set(span, true)
+	span.LogFields(log.Error(error))
+	return error
+}
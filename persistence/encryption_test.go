@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/lectio/lectiod/models"
+)
+
+// newEncryptedDatastore opens an empty datastore encrypted with a new key file, returned with it
+func (suite *DatastoreSuite) newEncryptedDatastore(configure func(*models.StorageSettings)) (*Datastore, string) {
+	keyFile := filepath.Join(suite.tempDir(), "keys.json")
+	_, err := AddMasterKey(keyFile)
+	suite.Nil(err, "Unable to create key file")
+	store := suite.newDatastore(func(config *models.StorageSettings) {
+		config.Encryption = &models.StorageEncryptionSettings{KeyFile: models.FilePathAndName(keyFile)}
+		if configure != nil {
+			configure(config)
+		}
+	})
+	suite.True(store.IsEncrypted(), "Datastore should be encrypted")
+	return store, keyFile
+}
+
+func (suite *DatastoreSuite) TestEncryptedValuesRoundTrip() {
+	store, _ := suite.newEncryptedDatastore(nil)
+	tests := []struct {
+		key    datastore.Key
+		value  string
+		tenant string
+	}{
+		{key: datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1"), value: "first resource", tenant: "/resources/SESSION_TENANT/reading-list"},
+		{key: datastore.NewKey("/resources/SESSION_TENANT/archive/harvested/1"), value: "second resource", tenant: "/resources/SESSION_TENANT/archive"},
+		{key: RecordKey(SessionsCollection, "session"), value: "session", tenant: "/sessions"},
+	}
+	for _, test := range tests {
+		suite.Equal(test.tenant, TenantKey(test.key).String())
+		suite.Nil(store.Put(test.key, []byte(test.value)), "Unable to put %s", test.key)
+
+		stored, err := store.store.Get(encodeKey(test.key))
+		suite.Nil(err)
+		suite.True(bytes.HasPrefix(stored.([]byte), sealedValuePrefix), "%s should be stored sealed", test.key)
+		suite.False(bytes.Contains(stored.([]byte), []byte(test.value)), "%s is stored in plaintext", test.key)
+
+		value, err := store.Get(test.key)
+		suite.Nil(err, "Unable to get %s", test.key)
+		suite.Equal([]byte(test.value), value)
+	}
+
+	// a value sealed for one key can't be passed off as another's
+	stored, _ := store.store.Get(encodeKey(tests[0].key))
+	suite.Nil(store.store.Put(encodeKey(datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/2")), stored))
+	_, err := store.Get(datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/2"))
+	suite.NotNil(err, "Moved values should fail to decrypt")
+}
+
+func (suite *DatastoreSuite) TestPlaintextValuesAreEncryptedByRotation() {
+	store, _ := suite.newEncryptedDatastore(nil)
+	key := RecordKey(SessionsCollection, "plaintext")
+	suite.Nil(store.store.Put(encodeKey(key), []byte("stored before encryption")))
+
+	_, err := store.Get(key)
+	suite.NotNil(err, "Unencrypted values should be an error once encryption is enabled")
+
+	count, err := store.RotateEncryptionKeys(suite.span)
+	suite.Nil(err, "Rotation should encrypt plaintext records")
+	suite.Equal(1, count)
+	value, err := store.Get(key)
+	suite.Nil(err)
+	suite.Equal([]byte("stored before encryption"), value)
+}
+
+func (suite *DatastoreSuite) TestRotationWithNewMasterKey() {
+	store, keyFile := suite.newEncryptedDatastore(nil)
+	keys := []datastore.Key{
+		datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1"),
+		datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/2"),
+		RecordKey(JobsCollection, "job"),
+	}
+	for _, key := range keys {
+		suite.Nil(store.Put(key, []byte(key.String())))
+	}
+
+	newKey, err := AddMasterKey(keyFile)
+	suite.Nil(err)
+	count, err := store.RotateEncryptionKeys(suite.span)
+	suite.Nil(err)
+	suite.Equal(len(keys), count)
+
+	for _, tenant := range []string{"/resources/SESSION_TENANT/reading-list", "/jobs"} {
+		dataKeys, err := store.encryption.loadDataKeys(store, datastore.NewKey(tenant))
+		suite.Nil(err)
+		suite.Len(dataKeys.Keys, 1, "Old data keys of %s should be dropped", tenant)
+		suite.Equal(newKey, dataKeys.Keys[dataKeys.ActiveKeyID].MasterKeyID, "Data key of %s should be wrapped by the new master key", tenant)
+	}
+
+	// once rotated the old master keys aren't needed anymore
+	file, err := ReadMasterKeyFile(keyFile)
+	suite.Nil(err)
+	file.Keys = map[string]string{newKey: file.Keys[newKey]}
+	contents, _ := json.Marshal(file)
+	suite.Nil(ioutil.WriteFile(keyFile, contents, 0600))
+	store.encryption.dataKeys = make(map[string]*tenantDataKeys)
+	suite.Nil(store.encryption.loadMasterKeys())
+	for _, key := range keys {
+		value, err := store.Get(key)
+		suite.Nil(err, "Unable to get %s after rotation", key)
+		suite.Equal([]byte(key.String()), value)
+	}
+}
+
+func (suite *DatastoreSuite) TestRotationRefusedWhileStoreInUse() {
+	store, _ := suite.newEncryptedDatastore(nil)
+	suite.Nil(store.Put(RecordKey(SessionsCollection, "session"), []byte("session")))
+
+	// another process (the daemon) has the store open
+	daemon, err := os.OpenFile(store.lock.path, os.O_RDWR, 0644)
+	suite.Nil(err)
+	suite.Nil(flock(daemon, false))
+
+	_, err = store.RotateEncryptionKeys(suite.span)
+	suite.NotNil(err, "Rotation should refuse to run while another process uses the store")
+	other, err := os.OpenFile(store.lock.path, os.O_RDWR, 0644)
+	suite.Nil(err)
+	suite.NotNil(flock(other, true), "A refused rotation should keep holding the lock shared")
+	other.Close()
+	value, err := store.Get(RecordKey(SessionsCollection, "session"))
+	suite.Nil(err)
+	suite.Equal([]byte("session"), value)
+
+	daemon.Close()
+	_, err = store.RotateEncryptionKeys(suite.span)
+	suite.Nil(err, "Rotation should run once the store is no longer in use")
+
+	// the store goes back to sharing the lock after a rotation
+	other, err = os.OpenFile(store.lock.path, os.O_RDWR, 0644)
+	suite.Nil(err)
+	defer other.Close()
+	suite.Nil(flock(other, false), "Other processes should be able to share the lock after rotation")
+}
@@ -0,0 +1,89 @@
+package persistence
+
+import (
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/lectio/lectiod/models"
+)
+
+// Collection names a top-level namespace of records within a Datastore
+type Collection string
+
+const (
+	ResourcesCollection   Collection = "resources"
+	SessionsCollection    Collection = "sessions"
+	IdentitiesCollection  Collection = "identities"
+	BundlesCollection     Collection = "bundles"
+	JobsCollection        Collection = "jobs"
+	RobotsCollection      Collection = "robots"
+	ResolutionsCollection Collection = "resolutions"
+	ContentsCollection    Collection = "contents"
+	ArchivesCollection    Collection = "archives"
+)
+
+// ResourceKind separates harvested, ignored and invalid resources saved to a destination
+type ResourceKind string
+
+const (
+	HarvestedResourceKind ResourceKind = "harvested"
+	IgnoredResourceKind   ResourceKind = "ignored"
+	InvalidResourceKind   ResourceKind = "invalid"
+)
+
+// physicalKeyEncoding is used because flatfs only allows flat keys made of [0-9A-Z+-_=]. Keys aren't padded
+// because flatfs shards by their last characters, which padding would make the same for most keys.
+var physicalKeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// CollectionKey returns the namespace key under which all records of a collection live
+func CollectionKey(collection Collection) datastore.Key {
+	return datastore.KeyWithNamespaces([]string{string(collection)})
+}
+
+// RecordKey returns the key of a single record inside a collection, e.g. /sessions/SIMULATED
+func RecordKey(collection Collection, id string) datastore.Key {
+	return CollectionKey(collection).ChildString(id)
+}
+
+// DestinationKey returns the namespace of a storage destination (a saved collection of resources)
+func DestinationKey(collection models.StorageDestinationCollection, key models.StorageKey) datastore.Key {
+	return datastore.KeyWithNamespaces([]string{string(ResourcesCollection), string(collection), string(key)})
+}
+
+// ResourceKey returns the key of a resource saved to a destination, e.g. /resources/SESSION_TENANT/reading-list/harvested/<id>
+func ResourceKey(destination models.StorageDestinationInput, kind ResourceKind, url models.URLText) datastore.Key {
+	return DestinationKey(destination.Collection, destination.Key).ChildString(string(kind)).ChildString(ResourceID(url))
+}
+
+// ResourceID computes a stable, key-safe identifier for a URL
+func ResourceID(url models.URLText) string {
+	hash := sha1.Sum([]byte(url))
+	return hex.EncodeToString(hash[:])
+}
+
+// harvestedResourceURL returns the URL a harvested resource is saved under: its normalized URL, or its final
+// URL if it was harvested before normalized URLs existed
+func harvestedResourceURL(harvested *models.HarvestedResource) models.URLText {
+	if harvested.Urls.Normalized != "" {
+		return harvested.Urls.Normalized
+	}
+	return harvested.Urls.Final
+}
+
+// encodeKey maps a logical (namespaced) key to the flat key given to the underlying store
+func encodeKey(key datastore.Key) datastore.Key {
+	return datastore.RawKey("/" + physicalKeyEncoding.EncodeToString(key.Bytes()))
+}
+
+// decodeKey reverses encodeKey
+func decodeKey(physical string) (datastore.Key, error) {
+	decoded, err := physicalKeyEncoding.DecodeString(strings.TrimPrefix(physical, "/"))
+	if err != nil {
+		return datastore.Key{}, fmt.Errorf("Unable to decode physical key '%s': %v", physical, err)
+	}
+	return datastore.RawKey(string(decoded)), nil
+}
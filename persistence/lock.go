@@ -0,0 +1,76 @@
+package persistence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// storeLocks holds the locks taken by this process, a store opened more than once (e.g. when
+// OpenStore reopens it) shares the lock of the first
+var storeLocks = struct {
+	sync.Mutex
+	locks map[string]*storeLock
+}{locks: make(map[string]*storeLock)}
+
+// storeLock is an advisory lock on a file system store, held in the '.lock' file next to its base path.
+// Every Datastore holds it shared so the daemon and commands like backup-storage can run side by side;
+// maintenance which must not run while another process uses the store takes it exclusively.
+type storeLock struct {
+	path  string
+	file  *os.File
+	users int
+}
+
+// lockStore takes a shared lock on the store in basePath
+func lockStore(basePath string) (*storeLock, error) {
+	path := filepath.Clean(basePath) + ".lock"
+
+	storeLocks.Lock()
+	defer storeLocks.Unlock()
+	if lock, ok := storeLocks.locks[path]; ok {
+		lock.users++
+		return lock, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open lock file '%s': %v", path, err)
+	}
+	err = flock(file, false)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("'%s' is locked by another process: %v", path, err)
+	}
+	lock := &storeLock{path: path, file: file, users: 1}
+	storeLocks.locks[path] = lock
+	return lock, nil
+}
+
+// lockExclusive upgrades the lock, failing if another process holds it
+func (l *storeLock) lockExclusive() error {
+	err := flock(l.file, true)
+	if err != nil {
+		// a failed upgrade may have dropped the shared lock
+		flock(l.file, false)
+		return fmt.Errorf("'%s' is locked by another process: %v", l.path, err)
+	}
+	return nil
+}
+
+// unlockExclusive downgrades the lock taken by lockExclusive back to shared
+func (l *storeLock) unlockExclusive() error {
+	return flock(l.file, false)
+}
+
+// release gives up this user's hold on the lock, the lock file is closed once there are none left
+func (l *storeLock) release() {
+	storeLocks.Lock()
+	defer storeLocks.Unlock()
+	l.users--
+	if l.users == 0 {
+		delete(storeLocks.locks, l.path)
+		l.file.Close()
+	}
+}
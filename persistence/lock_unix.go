@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package persistence
+
+import (
+	"os"
+	"syscall"
+)
+
+// flock takes a shared or exclusive lock on file without blocking
+func flock(file *os.File, exclusive bool) error {
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	return syscall.Flock(int(file.Fd()), how|syscall.LOCK_NB)
+}
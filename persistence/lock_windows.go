@@ -0,0 +1,8 @@
+package persistence
+
+import "os"
+
+// flock does nothing on Windows, stores aren't locked there
+func flock(file *os.File, exclusive bool) error {
+	return nil
+}
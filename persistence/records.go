@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// RecordType identifies what kind of value is stored in a record
+type RecordType string
+
+// RecordVersion identifies the format of a stored record, bump it whenever the format changes
+type RecordVersion uint
+
+// Migration upgrades the data of a record stored with an older RecordVersion to the next version
+type Migration func(data json.RawMessage) (json.RawMessage, error)
+
+// recordEnvelope is the versioned encoding every typed record is stored in
+type recordEnvelope struct {
+	Type    RecordType      `json:"type"`
+	Version RecordVersion   `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Repository stores values of a single RecordType in a Datastore using a versioned JSON encoding
+type Repository struct {
+	store      *Datastore
+	recordType RecordType
+	version    RecordVersion
+	migrations map[RecordVersion]Migration
+}
+
+// NewRepository constructs a Repository whose records are written with the given (current) version
+func NewRepository(store *Datastore, recordType RecordType, version RecordVersion) *Repository {
+	result := new(Repository)
+	result.store = store
+	result.recordType = recordType
+	result.version = version
+	result.migrations = make(map[RecordVersion]Migration)
+	return result
+}
+
+// RegisterMigration adds a hook which upgrades records from version from to from+1; records older than
+// the current version are upgraded when they are loaded and rewritten in the current format.
+func (r *Repository) RegisterMigration(from RecordVersion, migration Migration) {
+	r.migrations[from] = migration
+}
+
+// Type returns the RecordType stored by this repository
+func (r *Repository) Type() RecordType {
+	return r.recordType
+}
+
+// Version returns the RecordVersion new records are written with
+func (r *Repository) Version() RecordVersion {
+	return r.version
+}
+
+// Save encodes value and stores it under key
+func (r *Repository) Save(key datastore.Key, value interface{}) error {
+	encoded, err := r.encode(key, value)
+	if err != nil {
+		return err
+	}
+	return r.store.Put(key, encoded)
+}
+
+// encode wraps value in the versioned envelope Save stores
+func (r *Repository) encode(key datastore.Key, value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode %s record '%s': %v", r.recordType, key, err)
+	}
+	encoded, err := json.Marshal(recordEnvelope{Type: r.recordType, Version: r.version, Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to encode %s record '%s': %v", r.recordType, key, err)
+	}
+	return encoded, nil
+}
+
+// Load reads the record stored under key into value, migrating it first if it was stored in an older format
+func (r *Repository) Load(key datastore.Key, value interface{}) error {
+	stored, err := r.store.Get(key)
+	if err != nil {
+		return err
+	}
+	data, migrated, err := r.decode(key, stored)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, value)
+	if err != nil {
+		return fmt.Errorf("Unable to decode %s record '%s': %v", r.recordType, key, err)
+	}
+	if migrated {
+		return r.Save(key, value)
+	}
+	return nil
+}
+
+// Has returns true if a record is stored under key
+func (r *Repository) Has(key datastore.Key) (bool, error) {
+	return r.store.Has(key)
+}
+
+// Delete removes the record stored under key
+func (r *Repository) Delete(key datastore.Key) error {
+	return r.store.Delete(key)
+}
+
+// Keys returns the keys of all records stored under the given namespace
+func (r *Repository) Keys(namespace datastore.Key) ([]datastore.Key, error) {
+	results, err := r.store.Query(dsq.Query{Prefix: namespace.String() + "/", KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]datastore.Key, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, datastore.RawKey(entry.Key))
+	}
+	return keys, nil
+}
+
+// decode unwraps the envelope of a stored record and applies any migrations needed to reach the current version
+func (r *Repository) decode(key datastore.Key, stored interface{}) (json.RawMessage, bool, error) {
+	raw, ok := stored.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("Unexpected value type %T stored in %s record '%s'", stored, r.recordType, key)
+	}
+
+	var envelope recordEnvelope
+	err := json.Unmarshal(raw, &envelope)
+	if err != nil {
+		return nil, false, fmt.Errorf("Unable to decode %s record '%s': %v", r.recordType, key, err)
+	}
+	if envelope.Type != r.recordType {
+		return nil, false, fmt.Errorf("Record '%s' is a %s record, expected %s", key, envelope.Type, r.recordType)
+	}
+	if envelope.Version > r.version {
+		return nil, false, fmt.Errorf("Record '%s' was stored with %s version %d, newer than supported version %d", key, r.recordType, envelope.Version, r.version)
+	}
+
+	data := envelope.Data
+	for version := envelope.Version; version < r.version; version++ {
+		migration := r.migrations[version]
+		if migration == nil {
+			return nil, false, fmt.Errorf("No migration registered to upgrade %s record '%s' from version %d", r.recordType, key, version)
+		}
+		data, err = migration(data)
+		if err != nil {
+			return nil, false, fmt.Errorf("Unable to migrate %s record '%s' from version %d: %v", r.recordType, key, version, err)
+		}
+	}
+	return data, envelope.Version != r.version, nil
+}
@@ -0,0 +1,393 @@
+package persistence
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/lectio/lectiod/models"
+)
+
+// Current record versions, bump these (and register a Migration) when a record's format changes
+const (
+	ResourceRecordVersion   RecordVersion = 1
+	SessionRecordVersion    RecordVersion = 1
+	IdentityRecordVersion   RecordVersion = 1
+	BundleRecordVersion     RecordVersion = 1
+	JobRecordVersion        RecordVersion = 1
+	RobotsRecordVersion     RecordVersion = 1
+	ResolutionRecordVersion RecordVersion = 1
+	ContentRecordVersion    RecordVersion = 1
+	ArchiveRecordVersion    RecordVersion = 1
+)
+
+// ResourceRecord is a harvested, ignored or invalid resource saved to a storage destination
+type ResourceRecord struct {
+	Kind        ResourceKind                   `json:"kind"`
+	Destination models.StorageDestinationInput `json:"destination"`
+	Harvested   *models.HarvestedResource      `json:"harvested,omitempty"`
+	Ignored     *models.IgnoredResource        `json:"ignored,omitempty"`
+	Invalid     *models.UnharvestedResource    `json:"invalid,omitempty"`
+	SavedAt     time.Time                      `json:"savedAt"`
+}
+
+// SessionRecord is the persisted form of an authenticated session
+type SessionRecord struct {
+	SessionID          models.AuthenticatedSessionID `json:"sessionID"`
+	SettingsBundleName models.SettingsBundleName     `json:"settingsBundleName"`
+	CreatedAt          time.Time                     `json:"createdAt"`
+}
+
+// IdentityRecord is the persisted form of a user or service identity
+type IdentityRecord struct {
+	Type      models.AuthenticationType `json:"type"`
+	Principal models.IdentityPrincipal  `json:"principal"`
+	User      *models.UserIdentity      `json:"user,omitempty"`
+	Service   *models.ServiceIdentity   `json:"service,omitempty"`
+}
+
+// HarvestJobRecord is the persisted form of an asynchronous harvest job and its input
+type HarvestJobRecord struct {
+	Job                models.HarvestJob               `json:"job"`
+	Text               models.LargeText                `json:"text"`
+	SessionID          models.AuthenticatedSessionID   `json:"sessionID"`
+	SettingsBundleName models.SettingsBundleName       `json:"settingsBundleName"`
+	Destination        *models.StorageDestinationInput `json:"destination,omitempty"`
+}
+
+// RobotsTxtRecord is a cached robots.txt response of a single origin (scheme and host)
+type RobotsTxtRecord struct {
+	Origin     string    `json:"origin"`
+	StatusCode int       `json:"statusCode"`
+	Body       []byte    `json:"body,omitempty"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+}
+
+// ResolutionRecord is a cached resolution of a URL: where it led to under the resolution policy Policy
+// identifies
+type ResolutionRecord struct {
+	URL            models.URLText        `json:"url"`
+	Policy         string                `json:"policy"`
+	Resolved       models.URLText        `json:"resolved"`
+	IsHTMLRedirect bool                  `json:"isHTMLRedirect"`
+	RedirectURL    models.URLText        `json:"redirectURL,omitempty"`
+	RedirectChain  []*models.RedirectHop `json:"redirectChain,omitempty"`
+	Canonical      models.URLText        `json:"canonical,omitempty"`
+	Metadata       *models.PageMetadata  `json:"metadata,omitempty"`
+	ResolvedAt     time.Time             `json:"resolvedAt"`
+}
+
+// ContentRecord is the readable article text extracted from a harvested page
+type ContentRecord struct {
+	URL         models.URLText `json:"url"`
+	Text        string         `json:"text"`
+	HTML        string         `json:"html"`
+	ExtractedAt time.Time      `json:"extractedAt"`
+}
+
+// ArchivedResourceRecord is the latest WARC record archived for a harvested page
+type ArchivedResourceRecord struct {
+	URL     models.URLText        `json:"url"`
+	Archive *models.ArchiveRecord `json:"archive"`
+}
+
+// ResourcesRepository stores resources saved to storage destinations
+type ResourcesRepository struct {
+	*Repository
+}
+
+// SessionsRepository stores authenticated sessions
+type SessionsRepository struct {
+	*Repository
+}
+
+// IdentitiesRepository stores user and service identities
+type IdentitiesRepository struct {
+	*Repository
+}
+
+// BundlesRepository stores settings bundles
+type BundlesRepository struct {
+	*Repository
+}
+
+// JobsRepository stores asynchronous harvest jobs
+type JobsRepository struct {
+	*Repository
+}
+
+// RobotsRepository caches robots.txt files
+type RobotsRepository struct {
+	*Repository
+}
+
+// ResolutionsRepository caches URL resolutions
+type ResolutionsRepository struct {
+	*Repository
+}
+
+// ContentsRepository stores the article text of harvested pages
+type ContentsRepository struct {
+	*Repository
+}
+
+// ArchivesRepository stores where harvested pages were archived
+type ArchivesRepository struct {
+	*Repository
+}
+
+// Resources returns the typed repository for saved resources
+func (d *Datastore) Resources() *ResourcesRepository {
+	return &ResourcesRepository{NewRepository(d, "resource", ResourceRecordVersion)}
+}
+
+// Sessions returns the typed repository for sessions
+func (d *Datastore) Sessions() *SessionsRepository {
+	return &SessionsRepository{NewRepository(d, "session", SessionRecordVersion)}
+}
+
+// Identities returns the typed repository for identities
+func (d *Datastore) Identities() *IdentitiesRepository {
+	return &IdentitiesRepository{NewRepository(d, "identity", IdentityRecordVersion)}
+}
+
+// Bundles returns the typed repository for settings bundles
+func (d *Datastore) Bundles() *BundlesRepository {
+	return &BundlesRepository{NewRepository(d, "bundle", BundleRecordVersion)}
+}
+
+// Jobs returns the typed repository for harvest jobs
+func (d *Datastore) Jobs() *JobsRepository {
+	return &JobsRepository{NewRepository(d, "job", JobRecordVersion)}
+}
+
+// Robots returns the typed repository for cached robots.txt files
+func (d *Datastore) Robots() *RobotsRepository {
+	return &RobotsRepository{NewRepository(d, "robots", RobotsRecordVersion)}
+}
+
+// Contents returns the typed repository for the article text of harvested pages
+func (d *Datastore) Contents() *ContentsRepository {
+	return &ContentsRepository{NewRepository(d, "content", ContentRecordVersion)}
+}
+
+// Archives returns the typed repository for the archive records of harvested pages
+func (d *Datastore) Archives() *ArchivesRepository {
+	return &ArchivesRepository{NewRepository(d, "archive", ArchiveRecordVersion)}
+}
+
+// Resolutions returns the typed repository for cached URL resolutions
+func (d *Datastore) Resolutions() *ResolutionsRepository {
+	return &ResolutionsRepository{NewRepository(d, "resolution", ResolutionRecordVersion)}
+}
+
+// SaveHarvestedResources saves everything in resources to destination. Nothing is saved if that would
+// exceed a storage quota (a *QuotaExceededError is returned), otherwise the first error encountered is returned.
+func (r *ResourcesRepository) SaveHarvestedResources(destination models.StorageDestinationInput, resources *models.HarvestedResources) error {
+	now := time.Now()
+	encoded := make(map[datastore.Key][]byte)
+	add := func(record *ResourceRecord, url models.URLText) error {
+		record.Destination = destination
+		record.SavedAt = now
+		key := ResourceKey(destination, record.Kind, url)
+		value, err := r.encode(key, record)
+		if err == nil {
+			encoded[key] = value
+		}
+		return err
+	}
+
+	for _, harvested := range resources.Harvested {
+		if err := add(&ResourceRecord{Kind: HarvestedResourceKind, Harvested: harvested}, harvestedResourceURL(harvested)); err != nil {
+			return err
+		}
+	}
+	for _, ignored := range resources.Ignored {
+		if err := add(&ResourceRecord{Kind: IgnoredResourceKind, Ignored: ignored}, ignored.Urls.Original); err != nil {
+			return err
+		}
+	}
+	for _, invalid := range resources.Invalid {
+		if err := add(&ResourceRecord{Kind: InvalidResourceKind, Invalid: invalid}, invalid.URL); err != nil {
+			return err
+		}
+	}
+
+	return r.store.PutWithinQuota(encoded)
+}
+
+// List returns all resources of the given kind saved to destination
+func (r *ResourcesRepository) List(destination models.StorageDestinationInput, kind ResourceKind) ([]*ResourceRecord, error) {
+	keys, err := r.Keys(DestinationKey(destination.Collection, destination.Key).ChildString(string(kind)))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*ResourceRecord, 0, len(keys))
+	for _, key := range keys {
+		record := new(ResourceRecord)
+		err = r.Load(key, record)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// SaveSession stores session under its ID
+func (r *SessionsRepository) SaveSession(session *SessionRecord) error {
+	return r.Save(RecordKey(SessionsCollection, string(session.SessionID)), session)
+}
+
+// LoadSession reads the session stored under id
+func (r *SessionsRepository) LoadSession(id models.AuthenticatedSessionID) (*SessionRecord, error) {
+	result := new(SessionRecord)
+	err := r.Load(RecordKey(SessionsCollection, string(id)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveIdentity stores identity under its principal
+func (r *IdentitiesRepository) SaveIdentity(identity *IdentityRecord) error {
+	return r.Save(RecordKey(IdentitiesCollection, string(identity.Principal)), identity)
+}
+
+// LoadIdentity reads the identity stored under principal
+func (r *IdentitiesRepository) LoadIdentity(principal models.IdentityPrincipal) (*IdentityRecord, error) {
+	result := new(IdentityRecord)
+	err := r.Load(RecordKey(IdentitiesCollection, string(principal)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveBundle stores bundle under its name
+func (r *BundlesRepository) SaveBundle(bundle *models.SettingsBundle) error {
+	return r.Save(RecordKey(BundlesCollection, string(bundle.Name)), bundle)
+}
+
+// LoadBundle reads the bundle stored under name
+func (r *BundlesRepository) LoadBundle(name models.SettingsBundleName) (*models.SettingsBundle, error) {
+	result := new(models.SettingsBundle)
+	err := r.Load(RecordKey(BundlesCollection, string(name)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveJob stores job under its ID
+func (r *JobsRepository) SaveJob(job *HarvestJobRecord) error {
+	return r.Save(RecordKey(JobsCollection, string(job.Job.ID)), job)
+}
+
+// LoadJob reads the job stored under id
+func (r *JobsRepository) LoadJob(id models.HarvestJobID) (*HarvestJobRecord, error) {
+	result := new(HarvestJobRecord)
+	err := r.Load(RecordKey(JobsCollection, string(id)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteJob deletes the job stored under id
+func (r *JobsRepository) DeleteJob(id models.HarvestJobID) error {
+	return r.store.Delete(RecordKey(JobsCollection, string(id)))
+}
+
+// ListJobs returns every stored job
+func (r *JobsRepository) ListJobs() ([]*HarvestJobRecord, error) {
+	keys, err := r.Keys(CollectionKey(JobsCollection))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*HarvestJobRecord, 0, len(keys))
+	for _, key := range keys {
+		job := new(HarvestJobRecord)
+		err = r.Load(key, job)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, job)
+	}
+	return result, nil
+}
+
+// robotsTxtKey returns the key of an origin's robots.txt, e.g. /robots/https_example.com
+func robotsTxtKey(origin *url.URL) datastore.Key {
+	return RecordKey(RobotsCollection, origin.Scheme+"_"+strings.ToLower(origin.Host))
+}
+
+// SaveRobotsTxt stores the robots.txt response of origin
+func (r *RobotsRepository) SaveRobotsTxt(origin *url.URL, record *RobotsTxtRecord) error {
+	return r.Save(robotsTxtKey(origin), record)
+}
+
+// LoadRobotsTxt reads the robots.txt response stored for origin
+func (r *RobotsRepository) LoadRobotsTxt(origin *url.URL) (*RobotsTxtRecord, error) {
+	result := new(RobotsTxtRecord)
+	err := r.Load(robotsTxtKey(origin), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolutionKey returns the key of a URL's cached resolution, which differs for each resolution policy
+func resolutionKey(url models.URLText, policy string) datastore.Key {
+	id := ResourceID(url)
+	if policy != "" {
+		id += "_" + policy
+	}
+	return RecordKey(ResolutionsCollection, id)
+}
+
+// SaveResolution stores a URL's resolution
+func (r *ResolutionsRepository) SaveResolution(record *ResolutionRecord) error {
+	return r.Save(resolutionKey(record.URL, record.Policy), record)
+}
+
+// LoadResolution reads the resolution stored for url under policy
+func (r *ResolutionsRepository) LoadResolution(url models.URLText, policy string) (*ResolutionRecord, error) {
+	result := new(ResolutionRecord)
+	err := r.Load(resolutionKey(url, policy), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveContent stores the article text of the page at record.URL
+func (r *ContentsRepository) SaveContent(record *ContentRecord) error {
+	return r.Save(RecordKey(ContentsCollection, ResourceID(record.URL)), record)
+}
+
+// LoadContent reads the article text stored for url
+func (r *ContentsRepository) LoadContent(url models.URLText) (*ContentRecord, error) {
+	result := new(ContentRecord)
+	err := r.Load(RecordKey(ContentsCollection, ResourceID(url)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SaveArchivedResource stores where the page at record.URL was last archived
+func (r *ArchivesRepository) SaveArchivedResource(record *ArchivedResourceRecord) error {
+	return r.Save(RecordKey(ArchivesCollection, ResourceID(record.URL)), record)
+}
+
+// LoadArchivedResource reads where the page at url was last archived
+func (r *ArchivesRepository) LoadArchivedResource(url models.URLText) (*ArchivedResourceRecord, error) {
+	result := new(ArchivedResourceRecord)
+	err := r.Load(RecordKey(ArchivesCollection, ResourceID(url)), result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,222 @@
+package persistence
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DefaultSweepInterval is used when retention rules are configured without a sweep interval
+const DefaultSweepInterval = 60 * time.Minute
+
+// SavedResourceKind returns the GraphQL enum value of the resource kind
+func (k ResourceKind) SavedResourceKind() models.SavedResourceKind {
+	return models.SavedResourceKind(strings.ToUpper(string(k)))
+}
+
+// retentionRule returns the most specific rule matching record: a rule for the record's destination
+// beats a rule for its collection which beats a rule for all collections. Returns nil if none match.
+func retentionRule(settings *models.StorageRetentionSettings, record *ResourceRecord) *models.StorageRetentionRule {
+	var result *models.StorageRetentionRule
+	resultScore := -1
+	for _, rule := range settings.Rules {
+		if rule == nil || rule.Kind != record.Kind.SavedResourceKind() {
+			continue
+		}
+		score := 0
+		if rule.Collection != nil {
+			if *rule.Collection != record.Destination.Collection {
+				continue
+			}
+			score++
+		}
+		if rule.Key != nil {
+			if *rule.Key != record.Destination.Key {
+				continue
+			}
+			score += 2
+		}
+		if score > resultScore {
+			result = rule
+			resultScore = score
+		}
+	}
+	return result
+}
+
+// resourceURL returns the URL a resource record was saved under
+func resourceURL(record *ResourceRecord) models.URLText {
+	switch {
+	case record.Harvested != nil:
+		return harvestedResourceURL(record.Harvested)
+	case record.Ignored != nil:
+		return record.Ignored.Urls.Original
+	case record.Invalid != nil:
+		return record.Invalid.URL
+	}
+	return ""
+}
+
+// SweepExpiredResources finds saved resources and finished harvest jobs whose retention period has passed
+// and, unless dryRun is true, deletes them. The report lists every expired resource and counts the jobs.
+func (d *Datastore) SweepExpiredResources(bundle models.SettingsBundleName, dryRun bool, parent opentracing.Span) (*models.StorageRetentionReport, error) {
+	var span opentracing.Span
+	if parent == nil {
+		span = d.observatory.StartTrace("persistence.SweepExpiredResources")
+	} else {
+		span = d.observatory.StartChildTrace("persistence.SweepExpiredResources", parent)
+	}
+	defer span.Finish()
+	span.LogFields(log.String("bundle", string(bundle)), log.Bool("dryRun", dryRun))
+
+	now := time.Now()
+	result := new(models.StorageRetentionReport)
+	result.Bundle = bundle
+	result.EvaluatedAt = models.NewDateTime(now)
+	result.DryRun = dryRun
+
+	retention := d.config.Retention
+	if retention == nil || (len(retention.Rules) == 0 && retention.JobsKeepDays == nil) {
+		span.LogFields(log.String("event", "no retention rules configured"))
+		return result, nil
+	}
+
+	var firstErr error
+	if retention.JobsKeepDays != nil {
+		expired, err := d.sweepExpiredJobs(now.Add(-time.Duration(*retention.JobsKeepDays)*24*time.Hour), dryRun)
+		result.ExpiredJobs = models.StorageRecordsCount(expired)
+		if err != nil {
+			firstErr = err
+		}
+	}
+	if len(retention.Rules) == 0 {
+		span.LogFields(log.Int("expiredJobs", int(result.ExpiredJobs)))
+		if firstErr != nil {
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(firstErr))
+		}
+		return result, firstErr
+	}
+
+	resources := d.Resources()
+	keys, err := resources.Keys(CollectionKey(ResourcesCollection))
+	if err != nil {
+		error := fmt.Errorf("Unable to list saved resources of bundle '%s': %v", bundle, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+
+	for _, key := range keys {
+		record := new(ResourceRecord)
+		err = resources.Load(key, record)
+		if err != nil {
+			// keep sweeping, a single unreadable record shouldn't stop retention of the others
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.String("key", key.String()), log.Error(err))
+			continue
+		}
+		result.Examined++
+
+		rule := retentionRule(retention, record)
+		if rule == nil || rule.KeepDays == nil {
+			continue
+		}
+		expiresAt := record.SavedAt.Add(time.Duration(*rule.KeepDays) * 24 * time.Hour)
+		if expiresAt.After(now) {
+			continue
+		}
+
+		result.Expired++
+		result.Records = append(result.Records, &models.ExpiredStorageRecord{
+			Collection: record.Destination.Collection,
+			Key:        record.Destination.Key,
+			Kind:       record.Kind.SavedResourceKind(),
+			URL:        resourceURL(record),
+			SavedAt:    models.NewDateTime(record.SavedAt),
+			ExpiresAt:  models.NewDateTime(expiresAt),
+		})
+		if !dryRun {
+			err = d.Delete(key)
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("Unable to delete expired resource '%s': %v", key, err)
+			}
+		}
+	}
+
+	span.LogFields(log.Int("examined", int(result.Examined)), log.Int("expired", int(result.Expired)), log.Int("expiredJobs", int(result.ExpiredJobs)))
+	if firstErr != nil {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(firstErr))
+		return result, firstErr
+	}
+	return result, nil
+}
+
+// sweepExpiredJobs deletes (unless dryRun is true) the harvest jobs which finished before finishedBefore
+// and returns how many there were
+func (d *Datastore) sweepExpiredJobs(finishedBefore time.Time, dryRun bool) (int, error) {
+	jobs, err := d.Jobs().ListJobs()
+	if err != nil {
+		return 0, fmt.Errorf("Unable to list harvest jobs: %v", err)
+	}
+	expired := 0
+	for _, job := range jobs {
+		if job.Job.FinishedAt == nil {
+			continue
+		}
+		finishedAt, err := time.Parse(time.RFC3339, string(*job.Job.FinishedAt))
+		if err != nil || finishedAt.After(finishedBefore) {
+			continue
+		}
+		expired++
+		if !dryRun {
+			err = d.Delete(RecordKey(JobsCollection, string(job.Job.ID)))
+			if err != nil {
+				return expired, fmt.Errorf("Unable to delete expired harvest job '%s': %v", job.Job.ID, err)
+			}
+		}
+	}
+	return expired, nil
+}
+
+// StartRetentionSweeper runs SweepExpiredResources in a background goroutine every
+// Retention.SweepIntervalMinutes until the Datastore is closed. It does nothing if no retention is configured.
+func (d *Datastore) StartRetentionSweeper(bundle models.SettingsBundleName) {
+	retention := d.config.Retention
+	if retention == nil || (len(retention.Rules) == 0 && retention.JobsKeepDays == nil) || d.sweeperDone != nil {
+		return
+	}
+	interval := DefaultSweepInterval
+	if retention.SweepIntervalMinutes != nil && *retention.SweepIntervalMinutes > 0 {
+		interval = time.Duration(*retention.SweepIntervalMinutes) * time.Minute
+	}
+
+	d.sweeperDone = make(chan struct{})
+	go func(done chan struct{}) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// errors are already recorded on the sweep's trace
+				d.SweepExpiredResources(bundle, false, nil)
+			case <-done:
+				return
+			}
+		}
+	}(d.sweeperDone)
+}
+
+// stopRetentionSweeper stops the goroutine started by StartRetentionSweeper
+func (d *Datastore) stopRetentionSweeper() {
+	if d.sweeperDone != nil {
+		close(d.sweeperDone)
+		d.sweeperDone = nil
+	}
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *DatastoreSuite) TestRetentionRuleSpecificity() {
+	days := func(n int) *models.RetentionDays {
+		value := models.RetentionDays(n)
+		return &value
+	}
+	principal := models.StorageDestinationCollectionSessionPrincipal
+	tenant := models.StorageDestinationCollectionSessionTenant
+	readingList := models.StorageKey("reading-list")
+	settings := &models.StorageRetentionSettings{Rules: []*models.StorageRetentionRule{
+		{Kind: models.SavedResourceKindHarvested, KeepDays: days(30)},
+		{Kind: models.SavedResourceKindHarvested, Collection: &tenant, KeepDays: days(20)},
+		{Kind: models.SavedResourceKindHarvested, Key: &readingList, KeepDays: days(10)},
+		{Kind: models.SavedResourceKindHarvested, Collection: &tenant, Key: &readingList, KeepDays: days(5)},
+		{Kind: models.SavedResourceKindIgnored, Collection: &principal, KeepDays: days(1)},
+	}}
+
+	tests := []struct {
+		kind     ResourceKind
+		dest     models.StorageDestinationInput
+		keepDays int
+	}{
+		{kind: HarvestedResourceKind, dest: models.StorageDestinationInput{Collection: principal, Key: "archive"}, keepDays: 30},
+		{kind: HarvestedResourceKind, dest: models.StorageDestinationInput{Collection: tenant, Key: "archive"}, keepDays: 20},
+		{kind: HarvestedResourceKind, dest: models.StorageDestinationInput{Collection: principal, Key: readingList}, keepDays: 10},
+		{kind: HarvestedResourceKind, dest: models.StorageDestinationInput{Collection: tenant, Key: readingList}, keepDays: 5},
+		{kind: IgnoredResourceKind, dest: models.StorageDestinationInput{Collection: principal, Key: readingList}, keepDays: 1},
+		{kind: IgnoredResourceKind, dest: models.StorageDestinationInput{Collection: tenant, Key: readingList}, keepDays: 0},
+		{kind: InvalidResourceKind, dest: models.StorageDestinationInput{Collection: tenant, Key: readingList}, keepDays: 0},
+	}
+	for _, test := range tests {
+		rule := retentionRule(settings, &ResourceRecord{Kind: test.kind, Destination: test.dest})
+		if test.keepDays == 0 {
+			suite.Nil(rule, "No rule should match %s in %s/%s", test.kind, test.dest.Collection, test.dest.Key)
+			continue
+		}
+		suite.NotNil(rule, "A rule should match %s in %s/%s", test.kind, test.dest.Collection, test.dest.Key)
+		suite.Equal(models.RetentionDays(test.keepDays), *rule.KeepDays, "%s in %s/%s", test.kind, test.dest.Collection, test.dest.Key)
+	}
+}
+
+func (suite *DatastoreSuite) TestSweepExpiredResources() {
+	keepDays := models.RetentionDays(7)
+	store := suite.newDatastore(func(config *models.StorageSettings) {
+		config.Retention = &models.StorageRetentionSettings{Rules: []*models.StorageRetentionRule{
+			{Kind: models.SavedResourceKindIgnored, KeepDays: &keepDays},
+		}}
+	})
+	destination := models.StorageDestinationInput{Collection: models.StorageDestinationCollectionSessionTenant, Key: "reading-list"}
+	records := []struct {
+		url     models.URLText
+		kind    ResourceKind
+		age     time.Duration
+		expired bool
+	}{
+		{url: "https://example.com/old", kind: IgnoredResourceKind, age: 8 * 24 * time.Hour, expired: true},
+		{url: "https://example.com/new", kind: IgnoredResourceKind, age: 6 * 24 * time.Hour},
+		{url: "https://example.com/invalid", kind: InvalidResourceKind, age: 30 * 24 * time.Hour},
+	}
+	for _, record := range records {
+		saved := &ResourceRecord{Kind: record.kind, Destination: destination, SavedAt: time.Now().Add(-record.age)}
+		if record.kind == IgnoredResourceKind {
+			saved.Ignored = &models.IgnoredResource{Urls: models.HarvestedResourceUrls{Original: record.url}}
+		} else {
+			saved.Invalid = &models.UnharvestedResource{URL: record.url}
+		}
+		suite.Nil(store.Resources().Save(ResourceKey(destination, record.kind, record.url), saved))
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		report, err := store.SweepExpiredResources("TEST", dryRun, suite.span)
+		suite.Nil(err)
+		suite.Equal(dryRun, report.DryRun)
+		suite.Equal(models.StorageRecordsCount(len(records)), report.Examined)
+		suite.Equal(models.StorageRecordsCount(1), report.Expired)
+		suite.Len(report.Records, 1)
+		suite.Equal(models.URLText("https://example.com/old"), report.Records[0].URL)
+		for _, record := range records {
+			exists, _ := store.Has(ResourceKey(destination, record.kind, record.url))
+			suite.Equal(dryRun || !record.expired, exists, "%s after sweep (dry run %v)", record.url, dryRun)
+		}
+	}
+}
+
+func (suite *DatastoreSuite) TestSweepExpiredJobs() {
+	keepDays := models.RetentionDays(3)
+	store := suite.newDatastore(func(config *models.StorageSettings) {
+		config.Retention = &models.StorageRetentionSettings{JobsKeepDays: &keepDays}
+	})
+	jobs := []struct {
+		id      models.HarvestJobID
+		status  models.HarvestJobStatus
+		age     time.Duration
+		expired bool
+	}{
+		{id: "old", status: models.HarvestJobStatusCompleted, age: 4 * 24 * time.Hour, expired: true},
+		{id: "failed", status: models.HarvestJobStatusFailed, age: 5 * 24 * time.Hour, expired: true},
+		{id: "recent", status: models.HarvestJobStatusCompleted, age: 2 * 24 * time.Hour},
+		{id: "running", status: models.HarvestJobStatusRunning},
+	}
+	for _, job := range jobs {
+		record := new(HarvestJobRecord)
+		record.Job.ID = job.id
+		record.Job.Status = job.status
+		if job.age > 0 {
+			finished := models.NewDateTime(time.Now().Add(-job.age))
+			record.Job.FinishedAt = &finished
+		}
+		suite.Nil(store.Jobs().SaveJob(record))
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		report, err := store.SweepExpiredResources("TEST", dryRun, suite.span)
+		suite.Nil(err)
+		suite.Equal(models.StorageRecordsCount(2), report.ExpiredJobs)
+		for _, job := range jobs {
+			_, err := store.Jobs().LoadJob(job.id)
+			suite.Equal(dryRun || !job.expired, err == nil, "Job %s after sweep (dry run %v)", job.id, dryRun)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
@@ -13,10 +14,16 @@ import (
 	observe "github.com/shah/observe-go"
 )
 
+// Datastore wraps the configured storage backend, translating the namespaced keys used by
+// the rest of lectiod into keys the backend accepts
 type Datastore struct {
 	config      *models.StorageSettings
 	store       datastore.Datastore
 	storeError  error
+	lock        *storeLock
+	encryption  *envelopeEncryption
+	usage       *storageUsage
+	sweeperDone chan struct{}
 	observatory observe.Observatory
 }
 
@@ -28,11 +35,15 @@ func NewDatastore(observatory observe.Observatory, config *models.StorageSetting
 	result := new(Datastore)
 	result.config = config
 	result.observatory = observatory
+	result.usage = newStorageUsage()
 
 	span.LogFields(log.String("config.Type", string(models.StorageTypeFileSystem)))
 	if config.Type == models.StorageTypeFileSystem {
 		span.LogFields(log.String("config.Filesys.BasePath", string(config.Filesys.BasePath)))
 		files, err := flatfs.CreateOrOpen(string(config.Filesys.BasePath), flatfs.IPFS_DEF_SHARD, true)
+		if err == nil {
+			result.lock, err = lockStore(string(config.Filesys.BasePath))
+		}
 		if err == nil {
 			result.store = files
 		} else {
@@ -50,6 +61,21 @@ func NewDatastore(observatory observe.Observatory, config *models.StorageSetting
 		result.store = datastore.NewLogDatastore(datastore.NewMapDatastore(), "ErrorStore")
 	}
 
+	if config.Encryption != nil && result.storeError == nil {
+		span.LogFields(log.String("config.Encryption.KeyFile", string(config.Encryption.KeyFile)))
+		encryption, err := newEnvelopeEncryption(string(config.Encryption.KeyFile))
+		if err == nil {
+			result.encryption = encryption
+		} else {
+			// never fall back to writing unencrypted records when encryption was asked for
+			error := fmt.Errorf("Unable to enable encryption: %v, creating in memory store", err)
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(error))
+			result.storeError = error
+			result.store = datastore.NewLogDatastore(datastore.NewMapDatastore(), "ErrorStore")
+		}
+	}
+
 	return result
 }
 
@@ -66,27 +92,77 @@ func (d *Datastore) GetError() error {
 
 // Put implements Datastore.Put
 func (d *Datastore) Put(key datastore.Key, value interface{}) (err error) {
-	return d.store.Put(key, value)
+	stored := value
+	if d.encryption != nil {
+		stored, err = d.encryption.encrypt(d, key, value)
+		if err != nil {
+			return err
+		}
+	}
+	err = d.store.Put(encodeKey(key), stored)
+	if err == nil {
+		d.trackPut(key, value)
+	}
+	return err
 }
 
 // Get implements Datastore.Get
 func (d *Datastore) Get(key datastore.Key) (value interface{}, err error) {
-	return d.store.Get(key)
+	value, err = d.store.Get(encodeKey(key))
+	if err != nil || d.encryption == nil {
+		return value, err
+	}
+	return d.encryption.decrypt(d, key, value)
 }
 
 // Has implements Datastore.Has
 func (d *Datastore) Has(key datastore.Key) (exists bool, err error) {
-	return d.store.Has(key)
+	return d.store.Has(encodeKey(key))
 }
 
 // Delete implements Datastore.Delete
 func (d *Datastore) Delete(key datastore.Key) (err error) {
-	return d.store.Delete(key)
+	err = d.store.Delete(encodeKey(key))
+	if err == nil {
+		d.trackDelete(key)
+	}
+	return err
 }
 
-// Query implements Datastore.Query
+// Query implements Datastore.Query. flatfs can only list all of its (encoded) keys so the
+// listing is decoded and the prefix, filters, orders, offset and limit are applied here. Values
+// are only loaded for keys within the prefix, keys which can't be decoded aren't lectiod's and
+// are skipped. Wrapped data keys are internal to the Datastore and never returned.
 func (d *Datastore) Query(q dsq.Query) (dsq.Results, error) {
-	return d.store.Query(q)
+	listing, err := d.store.Query(dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	physical, err := listing.Rest()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]dsq.Entry, 0, len(physical))
+	for _, p := range physical {
+		key, err := decodeKey(p.Key)
+		if err != nil {
+			continue
+		}
+		namespaces := key.Namespaces()
+		if len(namespaces) == 0 || namespaces[0] == string(DataKeysCollection) || !strings.HasPrefix(key.String(), q.Prefix) {
+			continue
+		}
+		entry := dsq.Entry{Key: key.String()}
+		if !q.KeysOnly {
+			entry.Value, err = d.Get(key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return dsq.NaiveQueryApply(q, dsq.ResultsWithEntries(q, entries)), nil
 }
 
 func (d *Datastore) Batch() (datastore.Batch, error) {
@@ -94,5 +170,10 @@ func (d *Datastore) Batch() (datastore.Batch, error) {
 }
 
 func (d *Datastore) Close() error {
+	d.stopRetentionSweeper()
+	if d.lock != nil {
+		d.lock.release()
+		d.lock = nil
+	}
 	return nil
 }
@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	observe "github.com/shah/observe-go"
+	"github.com/stretchr/testify/suite"
+)
+
+// countingStore counts the values loaded from the store it wraps
+type countingStore struct {
+	datastore.Datastore
+	gets int
+}
+
+func (s *countingStore) Get(key datastore.Key) (interface{}, error) {
+	s.gets++
+	return s.Datastore.Get(key)
+}
+
+type DatastoreSuite struct {
+	suite.Suite
+	observatory observe.Observatory
+	span        opentracing.Span
+	paths       []string
+}
+
+func (suite *DatastoreSuite) SetupSuite() {
+	observatory := observe.MakeObservatoryFromEnv()
+	suite.observatory = observatory
+	suite.span = observatory.StartTrace("DatastoreSuite")
+}
+
+func (suite *DatastoreSuite) TearDownSuite() {
+	for _, path := range suite.paths {
+		os.RemoveAll(path)
+	}
+	suite.span.Finish()
+	suite.observatory.Close()
+}
+
+// tempDir returns a new directory removed when the suite is done
+func (suite *DatastoreSuite) tempDir() string {
+	path, err := ioutil.TempDir("", "lectiod-persistence")
+	suite.Nil(err, "Unable to create temp directory")
+	suite.paths = append(suite.paths, path)
+	return path
+}
+
+// newDatastore opens an empty flatfs datastore configured by configure (which may be nil)
+func (suite *DatastoreSuite) newDatastore(configure func(*models.StorageSettings)) *Datastore {
+	config := new(models.StorageSettings)
+	config.Type = models.StorageTypeFileSystem
+	config.Filesys = &models.FileStorageSettings{BasePath: models.DirectoryPath(filepath.Join(suite.tempDir(), "flatfs"))}
+	if configure != nil {
+		configure(config)
+	}
+	store := NewDatastore(suite.observatory, config, suite.span)
+	suite.True(store.IsValid(), "Datastore should be valid: %v", store.GetError())
+	return store
+}
+
+func (suite *DatastoreSuite) TestKeyEncoding() {
+	keys := []string{
+		"/sessions/SIMULATED",
+		"/resources/SESSION_TENANT/reading-list/harvested/0123456789abcdef0123456789abcdef01234567",
+		"/a",
+		"/ab",
+		"/abc",
+		"/jobs/with spaces and ünïcödé",
+	}
+	for _, logical := range keys {
+		encoded := encodeKey(datastore.NewKey(logical)).String()
+		suite.False(strings.Contains(encoded, "="), "Encoded key of %s shouldn't be padded: %s", logical, encoded)
+		for _, b := range encoded[1:] {
+			suite.True(b >= 'A' && b <= 'Z' || b >= '2' && b <= '7', "Encoded key of %s isn't a valid flatfs key: %s", logical, encoded)
+		}
+
+		decoded, err := decodeKey(encoded)
+		suite.Nil(err, "Unable to decode %s", encoded)
+		suite.Equal(datastore.NewKey(logical).String(), decoded.String())
+	}
+
+	_, err := decodeKey("/not-base32")
+	suite.NotNil(err, "Undecodable keys should be an error")
+}
+
+func (suite *DatastoreSuite) TestQueryLoadsOnlyKeysWithinPrefix() {
+	store := suite.newDatastore(nil)
+	counting := &countingStore{Datastore: store.store}
+	store.store = counting
+
+	for i := 0; i < 3; i++ {
+		suite.Nil(store.Put(RecordKey(SessionsCollection, fmt.Sprintf("session-%d", i)), []byte("session")))
+		suite.Nil(store.Put(RecordKey(JobsCollection, fmt.Sprintf("job-%d", i)), []byte("job")))
+	}
+	// keys lectiod didn't write are skipped rather than failing every query
+	suite.Nil(counting.Datastore.Put(datastore.RawKey("/NOT-BASE32-1"), []byte("foreign")))
+	suite.Nil(counting.Datastore.Put(datastore.RawKey("/"+physicalKeyEncoding.EncodeToString([]byte("no-namespace"))), []byte("foreign")))
+
+	tests := []struct {
+		prefix   string
+		keysOnly bool
+		entries  int
+		gets     int
+	}{
+		{prefix: "/sessions/", keysOnly: false, entries: 3, gets: 3},
+		{prefix: "/jobs/", keysOnly: false, entries: 3, gets: 3},
+		{prefix: "/jobs/", keysOnly: true, entries: 3, gets: 0},
+		{prefix: "/identities/", keysOnly: false, entries: 0, gets: 0},
+		{prefix: "", keysOnly: true, entries: 6, gets: 0},
+	}
+	for _, test := range tests {
+		counting.gets = 0
+		results, err := store.Query(dsq.Query{Prefix: test.prefix, KeysOnly: test.keysOnly})
+		suite.Nil(err, "Query of %s failed", test.prefix)
+		entries, err := results.Rest()
+		suite.Nil(err)
+		suite.Len(entries, test.entries, "Entries of %s", test.prefix)
+		suite.Equal(test.gets, counting.gets, "Values loaded by query of %s", test.prefix)
+		for _, entry := range entries {
+			suite.True(strings.HasPrefix(entry.Key, test.prefix), "%s isn't within %s", entry.Key, test.prefix)
+		}
+	}
+}
+
+func (suite *DatastoreSuite) TestRepositoryMigrations() {
+	type recordV1 struct {
+		Name string `json:"name"`
+	}
+	type recordV2 struct {
+		FullName string `json:"fullName"`
+	}
+	renameField := func(data json.RawMessage) (json.RawMessage, error) {
+		var v1 recordV1
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(recordV2{FullName: v1.Name})
+	}
+
+	tests := []struct {
+		name        string
+		stored      RecordVersion
+		current     RecordVersion
+		migrate     bool
+		expected    string
+		expectError bool
+	}{
+		{name: "current version", stored: 2, current: 2, expected: ""},
+		{name: "migrated", stored: 1, current: 2, migrate: true, expected: "Ada"},
+		{name: "missing migration", stored: 1, current: 2, migrate: false, expectError: true},
+		{name: "newer than supported", stored: 3, current: 2, expectError: true},
+	}
+	for _, test := range tests {
+		store := suite.newDatastore(nil)
+		key := RecordKey(IdentitiesCollection, "ada")
+		suite.Nil(NewRepository(store, "test", test.stored).Save(key, recordV1{Name: "Ada"}), test.name)
+
+		repository := NewRepository(store, "test", test.current)
+		if test.migrate {
+			repository.RegisterMigration(1, renameField)
+		}
+		var loaded recordV2
+		err := repository.Load(key, &loaded)
+		if test.expectError {
+			suite.NotNil(err, "%s should fail", test.name)
+			continue
+		}
+		suite.Nil(err, test.name)
+		suite.Equal(test.expected, loaded.FullName, test.name)
+
+		// migrated records are rewritten in the current format
+		stored, _ := store.Get(key)
+		var envelope recordEnvelope
+		suite.Nil(json.Unmarshal(stored.([]byte), &envelope))
+		suite.Equal(test.current, envelope.Version, test.name)
+	}
+
+	store := suite.newDatastore(nil)
+	suite.Nil(NewRepository(store, "test", 1).Save(RecordKey(IdentitiesCollection, "ada"), recordV1{Name: "Ada"}))
+	err := NewRepository(store, "other", 1).Load(RecordKey(IdentitiesCollection, "ada"), new(recordV1))
+	suite.NotNil(err, "Loading a record of another type should fail")
+}
+
+func TestDatastoreSuite(t *testing.T) {
+	suite.Run(t, new(DatastoreSuite))
+}
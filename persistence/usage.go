@@ -0,0 +1,252 @@
+package persistence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"github.com/lectio/lectiod/models"
+)
+
+// storageUsage keeps record counts and sizes per storage destination (see TenantKey). Only the resources
+// users save count, records lectiod keeps for itself (sessions, jobs, caches, ...) don't. It is computed
+// from a Query the first time it's needed and then kept up to date by Put and Delete.
+type storageUsage struct {
+	mutex   sync.Mutex
+	writes  sync.Mutex
+	loaded  bool
+	sizes   map[string]int64
+	tenants map[string]*usageCounter
+}
+
+type usageCounter struct {
+	records int64
+	bytes   int64
+}
+
+// QuotaExceededError is returned when saving records would exceed a bundle or tenant quota
+type QuotaExceededError struct {
+	Scope   string
+	Limit   string
+	Used    int64
+	Adding  int64
+	Maximum int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("Storage quota exceeded: %s already uses %d of its %d %s, saving would add %d more", e.Scope, e.Used, e.Maximum, e.Limit, e.Adding)
+}
+
+func newStorageUsage() *storageUsage {
+	result := new(storageUsage)
+	result.sizes = make(map[string]int64)
+	result.tenants = make(map[string]*usageCounter)
+	return result
+}
+
+// countsTowardsQuota returns true for the resource records users save to storage destinations
+func countsTowardsQuota(key datastore.Key) bool {
+	return key.Namespaces()[0] == string(ResourcesCollection)
+}
+
+// update records that key now holds size bytes; size < 0 means key was deleted. The caller holds the mutex.
+func (u *storageUsage) update(key datastore.Key, size int64) {
+	if !countsTowardsQuota(key) {
+		return
+	}
+	tenant := TenantKey(key).String()
+	counter := u.tenants[tenant]
+	if counter == nil {
+		counter = new(usageCounter)
+		u.tenants[tenant] = counter
+	}
+	previous, exists := u.sizes[key.String()]
+	if exists {
+		counter.records--
+		counter.bytes -= previous
+		delete(u.sizes, key.String())
+	}
+	if size >= 0 {
+		counter.records++
+		counter.bytes += size
+		u.sizes[key.String()] = size
+	}
+	if counter.records == 0 {
+		delete(u.tenants, tenant)
+	}
+}
+
+// valueSize returns the logical (unencrypted) size of a stored value
+func valueSize(value interface{}) int64 {
+	if data, ok := value.([]byte); ok {
+		return int64(len(data))
+	}
+	return 0
+}
+
+// trackPut updates the usage counters after a successful Put, if they've been computed already
+func (d *Datastore) trackPut(key datastore.Key, value interface{}) {
+	d.usage.mutex.Lock()
+	defer d.usage.mutex.Unlock()
+	if d.usage.loaded {
+		d.usage.update(key, valueSize(value))
+	}
+}
+
+// trackDelete updates the usage counters after a successful Delete, if they've been computed already
+func (d *Datastore) trackDelete(key datastore.Key) {
+	d.usage.mutex.Lock()
+	defer d.usage.mutex.Unlock()
+	if d.usage.loaded {
+		d.usage.update(key, -1)
+	}
+}
+
+// loadUsage computes the usage counters from a Query of the resources unless that was done already; the caller holds the mutex
+func (d *Datastore) loadUsage() error {
+	if d.usage.loaded {
+		return nil
+	}
+	results, err := d.Query(dsq.Query{Prefix: CollectionKey(ResourcesCollection).String() + "/"})
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		d.usage.update(datastore.RawKey(entry.Key), valueSize(entry.Value))
+	}
+	d.usage.loaded = true
+	return nil
+}
+
+// PutWithinQuota writes values unless that would exceed the bundle's quota or the quota of a tenant
+// the values belong to, in which case nothing is written and a *QuotaExceededError is returned. Writes
+// through PutWithinQuota are serialized so concurrent saves can't each pass the check and together
+// exceed the quota. Otherwise the first error encountered is returned.
+func (d *Datastore) PutWithinQuota(values map[datastore.Key][]byte) error {
+	d.usage.writes.Lock()
+	defer d.usage.writes.Unlock()
+
+	err := d.checkQuota(values)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for key, value := range values {
+		err = d.Put(key, value)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// checkQuota returns a *QuotaExceededError if writing values would exceed a quota. Values replacing
+// existing records only count their growth, values of records which don't count towards quotas are ignored.
+func (d *Datastore) checkQuota(values map[datastore.Key][]byte) error {
+	bundleQuota := d.config.Quota
+	tenantQuota := d.config.TenantQuota
+	if bundleQuota == nil && tenantQuota == nil {
+		return nil
+	}
+
+	d.usage.mutex.Lock()
+	defer d.usage.mutex.Unlock()
+	err := d.loadUsage()
+	if err != nil {
+		return err
+	}
+
+	adding := make(map[string]*usageCounter)
+	total := new(usageCounter)
+	for key, value := range values {
+		if !countsTowardsQuota(key) {
+			continue
+		}
+		tenant := TenantKey(key).String()
+		counter := adding[tenant]
+		if counter == nil {
+			counter = new(usageCounter)
+			adding[tenant] = counter
+		}
+		previous, exists := d.usage.sizes[key.String()]
+		if !exists {
+			counter.records++
+			total.records++
+		}
+		counter.bytes += int64(len(value)) - previous
+		total.bytes += int64(len(value)) - previous
+	}
+
+	if tenantQuota != nil {
+		for tenant, counter := range adding {
+			used := d.usage.tenants[tenant]
+			if used == nil {
+				used = new(usageCounter)
+			}
+			err = checkQuota(tenantQuota, "'"+strings.TrimPrefix(tenant, "/")+"'", used, counter)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if bundleQuota != nil {
+		used := new(usageCounter)
+		for _, counter := range d.usage.tenants {
+			used.records += counter.records
+			used.bytes += counter.bytes
+		}
+		return checkQuota(bundleQuota, "the settings bundle", used, total)
+	}
+	return nil
+}
+
+func checkQuota(quota *models.StorageQuotaSettings, scope string, used *usageCounter, adding *usageCounter) error {
+	if quota.MaxRecords != nil && adding.records > 0 && used.records+adding.records > int64(*quota.MaxRecords) {
+		return &QuotaExceededError{Scope: scope, Limit: "records", Used: used.records, Adding: adding.records, Maximum: int64(*quota.MaxRecords)}
+	}
+	if quota.MaxBytes != nil && adding.bytes > 0 && used.bytes+adding.bytes > int64(*quota.MaxBytes) {
+		return &QuotaExceededError{Scope: scope, Limit: "bytes", Used: used.bytes, Adding: adding.bytes, Maximum: int64(*quota.MaxBytes)}
+	}
+	return nil
+}
+
+// Usage reports record counts and sizes of the resources saved to the Datastore, in total and per storage destination
+func (d *Datastore) Usage(bundle models.SettingsBundleName) (*models.StorageUsage, error) {
+	d.usage.mutex.Lock()
+	defer d.usage.mutex.Unlock()
+	err := d.loadUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(models.StorageUsage)
+	result.Bundle = bundle
+	result.Quota = d.config.Quota
+	tenants := make([]string, 0, len(d.usage.tenants))
+	for tenant := range d.usage.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	for _, tenant := range tenants {
+		counter := d.usage.tenants[tenant]
+		result.Records += models.StorageRecordsCount(counter.records)
+		result.Bytes += models.StorageBytesCount(counter.bytes)
+		collection := &models.StorageCollectionUsage{
+			Collection: models.StorageKey(strings.TrimPrefix(tenant, "/")),
+			Records:    models.StorageRecordsCount(counter.records),
+			Bytes:      models.StorageBytesCount(counter.bytes),
+		}
+		if strings.HasPrefix(tenant, "/"+string(ResourcesCollection)+"/") {
+			collection.Quota = d.config.TenantQuota
+		}
+		result.Collections = append(result.Collections, collection)
+	}
+	return result, nil
+}
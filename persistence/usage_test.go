@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *DatastoreSuite) TestQuotas() {
+	records := func(n int) *models.StorageQuotaSettings {
+		max := models.StorageRecordsCount(n)
+		return &models.StorageQuotaSettings{MaxRecords: &max}
+	}
+	bytes := func(n int) *models.StorageQuotaSettings {
+		max := models.StorageBytesCount(n)
+		return &models.StorageQuotaSettings{MaxBytes: &max}
+	}
+	readingList := func(i int) datastore.Key {
+		return datastore.NewKey(fmt.Sprintf("/resources/SESSION_TENANT/reading-list/harvested/%d", i))
+	}
+	archive := func(i int) datastore.Key {
+		return datastore.NewKey(fmt.Sprintf("/resources/SESSION_TENANT/archive/harvested/%d", i))
+	}
+
+	tests := []struct {
+		name        string
+		quota       *models.StorageQuotaSettings
+		tenantQuota *models.StorageQuotaSettings
+		existing    map[datastore.Key]string
+		saving      map[datastore.Key]string
+		exceeded    bool
+	}{
+		{name: "no quotas", existing: map[datastore.Key]string{readingList(1): "1"}, saving: map[datastore.Key]string{readingList(2): "2"}},
+		{name: "within tenant records", tenantQuota: records(2), existing: map[datastore.Key]string{readingList(1): "1", archive(1): "1"}, saving: map[datastore.Key]string{readingList(2): "2"}},
+		{name: "over tenant records", tenantQuota: records(2), existing: map[datastore.Key]string{readingList(1): "1", readingList(2): "2"}, saving: map[datastore.Key]string{readingList(3): "3"}, exceeded: true},
+		{name: "replacing doesn't add records", tenantQuota: records(2), existing: map[datastore.Key]string{readingList(1): "1", readingList(2): "2"}, saving: map[datastore.Key]string{readingList(2): "two"}},
+		{name: "over bundle records", quota: records(2), existing: map[datastore.Key]string{readingList(1): "1", archive(1): "1"}, saving: map[datastore.Key]string{archive(2): "2"}, exceeded: true},
+		{name: "over bundle bytes", quota: bytes(4), existing: map[datastore.Key]string{readingList(1): "123"}, saving: map[datastore.Key]string{archive(1): "12"}, exceeded: true},
+		{name: "replacing counts growth", quota: bytes(4), existing: map[datastore.Key]string{readingList(1): "123"}, saving: map[datastore.Key]string{readingList(1): "1234"}},
+		{name: "internal records don't count", quota: records(1), existing: map[datastore.Key]string{RecordKey(SessionsCollection, "session"): "session", RecordKey(JobsCollection, "job"): "job", RecordKey(RobotsCollection, "robots"): "robots"}, saving: map[datastore.Key]string{readingList(1): "1"}},
+	}
+	for _, test := range tests {
+		store := suite.newDatastore(func(config *models.StorageSettings) {
+			config.Quota = test.quota
+			config.TenantQuota = test.tenantQuota
+		})
+		for key, value := range test.existing {
+			suite.Nil(store.Put(key, []byte(value)), test.name)
+		}
+		saving := make(map[datastore.Key][]byte)
+		for key, value := range test.saving {
+			saving[key] = []byte(value)
+		}
+
+		err := store.PutWithinQuota(saving)
+		if !test.exceeded {
+			suite.Nil(err, test.name)
+			continue
+		}
+		_, ok := err.(*QuotaExceededError)
+		suite.True(ok, "%s should exceed the quota: %v", test.name, err)
+		for key := range saving {
+			if _, ok := test.existing[key]; !ok {
+				exists, _ := store.Has(key)
+				suite.False(exists, "%s shouldn't write anything", test.name)
+			}
+		}
+	}
+}
+
+func (suite *DatastoreSuite) TestConcurrentSavesStayWithinQuota() {
+	max := models.StorageRecordsCount(5)
+	store := suite.newDatastore(func(config *models.StorageSettings) {
+		config.TenantQuota = &models.StorageQuotaSettings{MaxRecords: &max}
+	})
+
+	var wait sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			key := datastore.NewKey(fmt.Sprintf("/resources/SESSION_TENANT/reading-list/harvested/%d", i))
+			store.PutWithinQuota(map[datastore.Key][]byte{key: []byte("resource")})
+		}(i)
+	}
+	wait.Wait()
+
+	usage, err := store.Usage("TEST")
+	suite.Nil(err)
+	suite.Equal(models.StorageRecordsCount(max), usage.Records, "Concurrent saves shouldn't exceed the quota")
+}
+
+func (suite *DatastoreSuite) TestUsageCountsOnlyResources() {
+	store := suite.newDatastore(nil)
+	suite.Nil(store.Put(RecordKey(SessionsCollection, "session"), []byte("session")))
+	suite.Nil(store.Put(datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1"), []byte("12345")))
+
+	usage, err := store.Usage("TEST")
+	suite.Nil(err)
+	suite.Equal(models.StorageRecordsCount(1), usage.Records)
+	suite.Equal(models.StorageBytesCount(5), usage.Bytes)
+	suite.Len(usage.Collections, 1)
+	suite.Equal(models.StorageKey("resources/SESSION_TENANT/reading-list"), usage.Collections[0].Collection)
+
+	// usage is kept up to date once computed
+	suite.Nil(store.Put(RecordKey(JobsCollection, "job"), []byte("job")))
+	suite.Nil(store.Delete(datastore.NewKey("/resources/SESSION_TENANT/reading-list/harvested/1")))
+	usage, err = store.Usage("TEST")
+	suite.Nil(err)
+	suite.Equal(models.StorageRecordsCount(0), usage.Records)
+	suite.Len(usage.Collections, 0)
+}
@@ -0,0 +1,370 @@
+package readability
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// DefaultWordsPerMinute is the reading speed ReadingTimeMinutes assumes
+const DefaultWordsPerMinute = 200
+
+// minParagraphLength is the shortest text a paragraph needs to count towards its container's score
+const minParagraphLength = 25
+
+// ErrNoArticle is returned by Extract when a page has no text which looks like an article
+var ErrNoArticle = errors.New("No article found")
+
+// Article is the main content of a page with navigation, ads and other boilerplate removed
+type Article struct {
+	Text string
+	HTML string
+}
+
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|footer|header|legends|menu|modal|nav|newsletter|pager|pagination|popup|promo|related|remark|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|tags|tool|widget|\bad-|advert`)
+	maybeCandidate     = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveWeight     = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story`)
+	negativeWeight     = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|foot|footer|footnote|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+// removedElements never contain article text
+var removedElements = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true, atom.Object: true, atom.Embed: true,
+	atom.Form: true, atom.Button: true, atom.Input: true, atom.Select: true, atom.Textarea: true, atom.Svg: true,
+	atom.Nav: true, atom.Aside: true, atom.Footer: true, atom.Header: true, atom.Link: true, atom.Meta: true,
+}
+
+// keptElements are the elements of the sanitized HTML, other elements are replaced by their children
+var keptElements = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Code: true, atom.Em: true, atom.Strong: true, atom.B: true,
+	atom.I: true, atom.U: true, atom.S: true, atom.Sub: true, atom.Sup: true, atom.A: true, atom.Img: true,
+	atom.Br: true, atom.Hr: true, atom.Figure: true, atom.Figcaption: true, atom.Table: true, atom.Thead: true,
+	atom.Tbody: true, atom.Tr: true, atom.Th: true, atom.Td: true,
+}
+
+// blockElements start a new paragraph of the plain text
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.H1: true, atom.H2: true,
+	atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true, atom.Ul: true, atom.Ol: true, atom.Li: true,
+	atom.Dl: true, atom.Dt: true, atom.Dd: true, atom.Blockquote: true, atom.Pre: true, atom.Figure: true,
+	atom.Figcaption: true, atom.Table: true, atom.Tr: true, atom.Br: true, atom.Hr: true,
+}
+
+// Extract finds the main article of an HTML page the way Readability does: boilerplate is removed, the
+// paragraphs score their containers and the best container, along with siblings which score well too, is
+// the article. Relative links and images are resolved against base.
+func Extract(body []byte, base *url.URL) (*Article, error) {
+	document, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	prune(document)
+
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	var score func(n *html.Node)
+	score = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			score(child)
+		}
+		if n.Type != html.ElementNode || (n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Td) {
+			return
+		}
+		text := textContent(n)
+		if len(text) < minParagraphLength {
+			return
+		}
+		points := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+		for level, ancestor := 0, n.Parent; ancestor != nil && level < 3; level, ancestor = level+1, ancestor.Parent {
+			if ancestor.Type != html.ElementNode {
+				break
+			}
+			if _, scored := scores[ancestor]; !scored {
+				scores[ancestor] = initialScore(ancestor)
+				candidates = append(candidates, ancestor)
+			}
+			switch level {
+			case 0:
+				scores[ancestor] += points
+			case 1:
+				scores[ancestor] += points / 2
+			default:
+				scores[ancestor] += points / float64(level*3)
+			}
+		}
+	}
+	score(document)
+
+	var top *html.Node
+	for _, candidate := range candidates {
+		// containers full of links are navigation rather than content
+		scores[candidate] *= 1 - linkDensity(candidate)
+		if top == nil || scores[candidate] > scores[top] {
+			top = candidate
+		}
+	}
+	if top == nil {
+		return nil, ErrNoArticle
+	}
+
+	// siblings of the top candidate which score well, or are paragraphs of real text, are part of the article
+	threshold := math.Max(10, scores[top]*0.2)
+	var parts []*html.Node
+	if top.Parent == nil {
+		parts = append(parts, top)
+	} else {
+		for sibling := top.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+			switch {
+			case sibling == top:
+				parts = append(parts, sibling)
+			case sibling.Type != html.ElementNode:
+			case scores[sibling] >= threshold:
+				parts = append(parts, sibling)
+			case sibling.DataAtom == atom.P:
+				text := textContent(sibling)
+				if len(text) > 80 && linkDensity(sibling) < 0.25 {
+					parts = append(parts, sibling)
+				}
+			}
+		}
+	}
+
+	var htmlOut bytes.Buffer
+	var textOut strings.Builder
+	for _, part := range parts {
+		writeSanitized(&htmlOut, part, base)
+		writeText(&textOut, part)
+	}
+	result := new(Article)
+	result.HTML = strings.TrimSpace(htmlOut.String())
+	result.Text = collapseParagraphs(textOut.String())
+	if result.Text == "" {
+		return nil, ErrNoArticle
+	}
+	return result, nil
+}
+
+// WordCount counts the words of text
+func WordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+// ReadingTimeMinutes estimates how long reading words takes at wordsPerMinute, rounded up
+func ReadingTimeMinutes(words int, wordsPerMinute int) int {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// attr returns the value of an attribute of n, or ""
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr returns true if n has the attribute, boolean attributes like hidden have no value
+func hasAttr(n *html.Node, name string) bool {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// prune removes the elements which can't be part of the article, and those whose class or id says they're
+// boilerplate unless it also says they might hold content
+func prune(n *html.Node) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.CommentNode {
+			n.RemoveChild(child)
+		} else if child.Type == html.ElementNode {
+			classAndID := attr(child, "class") + " " + attr(child, "id")
+			unlikely := unlikelyCandidates.MatchString(classAndID) && !maybeCandidate.MatchString(classAndID) &&
+				child.DataAtom != atom.Body && child.DataAtom != atom.A
+			if removedElements[child.DataAtom] || unlikely || hasAttr(child, "hidden") || attr(child, "aria-hidden") == "true" {
+				n.RemoveChild(child)
+			} else {
+				prune(child)
+			}
+		}
+		child = next
+	}
+}
+
+// initialScore favours containers whose element, class or id suggests content
+func initialScore(n *html.Node) float64 {
+	result := 0.0
+	switch n.DataAtom {
+	case atom.Article:
+		result += 10
+	case atom.Div, atom.Main, atom.Section:
+		result += 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		result += 3
+	case atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		result -= 3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		result -= 5
+	}
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeWeight.MatchString(value) {
+			result -= 25
+		}
+		if positiveWeight.MatchString(value) {
+			result += 25
+		}
+	}
+	return result
+}
+
+// textContent returns the text of n with whitespace collapsed
+func textContent(n *html.Node) string {
+	var builder strings.Builder
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			builder.WriteString(n.Data)
+			builder.WriteString(" ")
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+// linkDensity is the share of n's text which is link text
+func linkDensity(n *html.Node) float64 {
+	length := len(textContent(n))
+	if length == 0 {
+		return 0
+	}
+	linkLength := 0
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.A {
+			linkLength += len(textContent(n))
+			return
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+	return float64(linkLength) / float64(length)
+}
+
+// safeURL resolves value against base and returns it if it's an http(s) or mailto URL
+func safeURL(value string, base *url.URL) string {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	switch u.Scheme {
+	case "http", "https", "mailto":
+		return u.String()
+	}
+	return ""
+}
+
+// writeSanitized writes n as HTML made only of keptElements, without attributes other than a link's href
+// and an image's src and alt
+func writeSanitized(out *bytes.Buffer, n *html.Node, base *url.URL) {
+	switch n.Type {
+	case html.TextNode:
+		out.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			writeSanitized(out, child, base)
+		}
+		return
+	}
+
+	kept := keptElements[n.DataAtom]
+	if kept {
+		var attrs []html.Attribute
+		switch n.DataAtom {
+		case atom.A:
+			if href := safeURL(attr(n, "href"), base); href != "" {
+				attrs = append(attrs, html.Attribute{Key: "href", Val: href})
+			}
+		case atom.Img:
+			src := safeURL(attr(n, "src"), base)
+			if src == "" {
+				return
+			}
+			attrs = append(attrs, html.Attribute{Key: "src", Val: src})
+			if alt := attr(n, "alt"); alt != "" {
+				attrs = append(attrs, html.Attribute{Key: "alt", Val: alt})
+			}
+		}
+		out.WriteString("<" + n.Data)
+		for _, a := range attrs {
+			out.WriteString(" " + a.Key + `="` + html.EscapeString(a.Val) + `"`)
+		}
+		out.WriteString(">")
+		if n.DataAtom == atom.Img || n.DataAtom == atom.Br || n.DataAtom == atom.Hr {
+			return
+		}
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeSanitized(out, child, base)
+	}
+	if kept {
+		out.WriteString("</" + n.Data + ">")
+	}
+}
+
+// writeText writes the text of n with a paragraph break around each block element
+func writeText(out *strings.Builder, n *html.Node) {
+	if n.Type == html.TextNode {
+		out.WriteString(n.Data)
+		return
+	}
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		out.WriteString("\n\n")
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(out, child)
+	}
+	if block {
+		out.WriteString("\n\n")
+	}
+}
+
+// collapseParagraphs collapses the whitespace within paragraphs and separates them by a blank line
+func collapseParagraphs(text string) string {
+	var paragraphs []string
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if collapsed := strings.Join(strings.Fields(paragraph), " "); collapsed != "" {
+			paragraphs = append(paragraphs, collapsed)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
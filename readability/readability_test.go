@@ -0,0 +1,145 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+const articlePage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>A story</title>
+  <script>var tracking = "script text";</script>
+  <style>body { color: red; }</style>
+</head>
+<body>
+  <header><h1>Site name</h1></header>
+  <nav><a href="/">Home</a> <a href="/about">About</a></nav>
+  <div class="sidebar">Sidebar text about other things entirely</div>
+  <div id="main-content" class="post">
+    <h2>The headline</h2>
+    <p class="lead">The first paragraph of the story is long enough to count towards the container's score, with commas, and more.</p>
+    <p>The second paragraph links to <a href="/related/page">a related page</a> and <a href="javascript:alert(1)">a script</a>, it's long too.</p>
+    <p>An image follows this paragraph, which also has plenty of text in it to be scored, like the others.</p>
+    <img src="/images/photo.jpg" alt="A photo" onerror="alert(1)">
+    <!-- a comment which isn't article text -->
+    <p hidden>Hidden text which shouldn't be part of the article at all.</p>
+  </div>
+  <div class="comments">Comment text from readers which isn't part of the article.</div>
+  <footer>Footer text and copyright</footer>
+</body>
+</html>`
+
+type ReadabilitySuite struct {
+	suite.Suite
+	base *url.URL
+}
+
+func (suite *ReadabilitySuite) SetupSuite() {
+	suite.base, _ = url.Parse("https://example.com/news/story")
+}
+
+func (suite *ReadabilitySuite) TestExtract() {
+	article, err := Extract([]byte(articlePage), suite.base)
+	if !suite.Nil(err, "The page has an article") {
+		return
+	}
+
+	tests := []struct {
+		text     string
+		included bool
+	}{
+		{text: "The headline", included: true},
+		{text: "The first paragraph of the story", included: true},
+		{text: "The second paragraph links to a related page", included: true},
+		{text: "An image follows this paragraph", included: true},
+		{text: "Site name"},
+		{text: "Home"},
+		{text: "Sidebar text"},
+		{text: "Comment text"},
+		{text: "Footer text"},
+		{text: "script text"},
+		{text: "color: red"},
+		{text: "Hidden text"},
+		{text: "a comment"},
+	}
+	for _, test := range tests {
+		if test.included {
+			suite.Contains(article.Text, test.text, "The text should include '%s'", test.text)
+		} else {
+			suite.NotContains(article.Text, test.text, "The text shouldn't include '%s'", test.text)
+			suite.NotContains(article.HTML, test.text, "The HTML shouldn't include '%s'", test.text)
+		}
+	}
+	suite.Equal(4, len(strings.Split(article.Text, "\n\n")), "Each block should be a paragraph of the text")
+}
+
+func (suite *ReadabilitySuite) TestExtractSanitizesHTML() {
+	article, err := Extract([]byte(articlePage), suite.base)
+	if !suite.Nil(err, "The page has an article") {
+		return
+	}
+
+	tests := []struct {
+		html     string
+		included bool
+	}{
+		{html: `<a href="https://example.com/related/page">a related page</a>`, included: true},
+		{html: `<a>a script</a>`, included: true},
+		{html: `<img src="https://example.com/images/photo.jpg" alt="A photo">`, included: true},
+		{html: `<p>The first paragraph`, included: true},
+		{html: "javascript:"},
+		{html: "onerror"},
+		{html: "class="},
+		{html: "<div"},
+		{html: "<script"},
+	}
+	for _, test := range tests {
+		if test.included {
+			suite.Contains(article.HTML, test.html, "The HTML should include '%s'", test.html)
+		} else {
+			suite.NotContains(article.HTML, test.html, "The HTML shouldn't include '%s'", test.html)
+		}
+	}
+}
+
+func (suite *ReadabilitySuite) TestNoArticle() {
+	pages := []string{
+		"",
+		"<html><head><title>Empty</title></head><body></body></html>",
+		"<html><body><nav>Only navigation</nav><footer>and a footer</footer></body></html>",
+	}
+	for _, page := range pages {
+		_, err := Extract([]byte(page), suite.base)
+		suite.Equal(ErrNoArticle, err, "'%s' has no article", page)
+	}
+}
+
+func (suite *ReadabilitySuite) TestReadingTime() {
+	tests := []struct {
+		text           string
+		words          int
+		wordsPerMinute int
+		minutes        int
+	}{
+		{text: "", words: 0, wordsPerMinute: 200, minutes: 0},
+		{text: "one", words: 1, wordsPerMinute: 200, minutes: 1},
+		{text: "  one\ttwo\n\nthree  ", words: 3, wordsPerMinute: 200, minutes: 1},
+		{text: strings.Repeat("word ", 200), words: 200, wordsPerMinute: 200, minutes: 1},
+		{text: strings.Repeat("word ", 201), words: 201, wordsPerMinute: 200, minutes: 2},
+		{text: strings.Repeat("word ", 201), words: 201, wordsPerMinute: 0, minutes: 2},
+		{text: strings.Repeat("word ", 100), words: 100, wordsPerMinute: 50, minutes: 2},
+	}
+	for _, test := range tests {
+		words := WordCount(test.text)
+		suite.Equal(test.words, words, "'%s'", test.text)
+		suite.Equal(test.minutes, ReadingTimeMinutes(words, test.wordsPerMinute), "%d words at %d per minute", words, test.wordsPerMinute)
+	}
+}
+
+func TestReadabilitySuite(t *testing.T) {
+	suite.Run(t, new(ReadabilitySuite))
+}
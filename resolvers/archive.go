@@ -0,0 +1,156 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+	"github.com/lectio/lectiod/warc"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// ArchiveReplayPath is where the daemon serves archived responses, followed by <bundle>/<warc file>/<offset>
+const ArchiveReplayPath = "/archive/"
+
+// ErrArchiveUnauthorized is returned by ArchivedResponse when the replay's session is missing or invalid
+var ErrArchiveUnauthorized = errors.New("A valid session is required to replay archived responses")
+
+// ErrArchiveForbidden is returned by ArchivedResponse when the archive belongs to another settings bundle
+var ErrArchiveForbidden = errors.New("The session's settings bundle doesn't own this archive")
+
+// resourceArchiver writes the final request and response of harvested pages into the settings bundle's WARC
+// files and remembers, keyed by normalized URL, where each page was last archived
+type resourceArchiver struct {
+	config *Configuration
+	files  *persistence.WARCArchive
+}
+
+// newResourceArchiver constructs the archiver of a settings bundle, nothing is written until a page is archived
+func newResourceArchiver(h *ServiceHandler, config *Configuration) *resourceArchiver {
+	result := new(resourceArchiver)
+	result.config = config
+	result.files = persistence.NewWARCArchive(h.observatory, config.settings.Name, &config.settings.Storage)
+	return result
+}
+
+// archive writes the exchange in fetched into a WARC file and sets harvested.ArchiveRecord. Resources resolved
+// from the cache aren't fetched again, they refer to the record written when the page was last archived.
+func (a *resourceArchiver) archive(harvested *models.HarvestedResource, fetched *fetch.Result, span opentracing.Span) {
+	fail := func(err error) {
+		error := fmt.Errorf("Unable to archive %s: %v", harvested.Urls.Resolved, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+	}
+
+	if fetched == nil {
+		record, err := a.config.Store().Archives().LoadArchivedResource(harvested.Urls.Normalized)
+		if err != nil {
+			span.LogFields(log.String("archive", "missing"), log.Error(err))
+			return
+		}
+		harvested.ArchiveRecord = record.Archive
+		return
+	}
+	if fetched.Request == nil {
+		fail(fmt.Errorf("the request wasn't kept"))
+		return
+	}
+
+	requestBlock, err := warc.HTTPRequestBlock(fetched.Request)
+	if err != nil {
+		fail(err)
+		return
+	}
+	response := warc.NewRecord(warc.TypeResponse, fetched.FetchedAt, warc.HTTPResponseBlock(fetched.Proto, fetched.Status, fetched.Header, fetched.Body))
+	response.Set(warc.FieldTargetURI, fetched.URL.String())
+	response.Set(warc.FieldContentType, "application/http; msgtype=response")
+	if fetched.Truncated {
+		response.Set(warc.FieldTruncated, "length")
+	}
+	request := warc.NewRecord(warc.TypeRequest, fetched.FetchedAt, requestBlock)
+	request.Set(warc.FieldTargetURI, fetched.URL.String())
+	request.Set(warc.FieldContentType, "application/http; msgtype=request")
+	request.Set(warc.FieldConcurrentTo, response.Get(warc.FieldRecordID))
+
+	locations, err := a.files.Append([]*warc.Record{response, request}, span)
+	if err != nil {
+		fail(err)
+		return
+	}
+	harvested.ArchiveRecord = &models.ArchiveRecord{
+		RecordID:   models.SmallText(response.Get(warc.FieldRecordID)),
+		WarcFile:   models.SmallText(locations[0].File),
+		Offset:     models.StorageBytesCount(locations[0].Offset),
+		Length:     models.StorageBytesCount(locations[0].Length),
+		TargetURL:  urlToString(fetched.URL),
+		ArchivedAt: models.NewDateTime(fetched.FetchedAt),
+		ReplayPath: archiveReplayPath(a.config.settings.Name, locations[0]),
+	}
+	err = a.config.Store().Archives().SaveArchivedResource(&persistence.ArchivedResourceRecord{URL: harvested.Urls.Normalized, Archive: harvested.ArchiveRecord})
+	if err != nil {
+		fail(err)
+	}
+	span.LogFields(log.String("archive", locations[0].File), log.Int64("offset", locations[0].Offset))
+}
+
+// archiveReplayPath returns the path under which the daemon replays the record at location
+func archiveReplayPath(bundle models.SettingsBundleName, location *persistence.WARCLocation) models.URLText {
+	return models.URLText(ArchiveReplayPath + url.PathEscape(string(bundle)) + "/" + location.File + "/" + strconv.FormatInt(location.Offset, 10))
+}
+
+// ArchivedResponse returns the WARC response record a replay path (without ArchiveReplayPath) refers to, the
+// authorization is validated like the GraphQL API's and its session must belong to the path's settings bundle
+func (h *ServiceHandler) ArchivedResponse(ctx context.Context, authorization models.AuthorizationInput, path string, parent opentracing.Span) (*warc.Record, error) {
+	span := h.observatory.StartChildTrace("resolvers.ArchivedResponse", parent)
+	defer span.Finish()
+	span.LogFields(log.String("path", path))
+
+	fail := func(err error) (*warc.Record, error) {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+		return nil, err
+	}
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return fail(fmt.Errorf("Invalid archive path '%s', expected <bundle>/<warc file>/<offset>", path))
+	}
+	bundle, err := url.PathUnescape(parts[0])
+	if err != nil {
+		return fail(fmt.Errorf("Invalid settings bundle name '%s': %v", parts[0], err))
+	}
+	if authorization.SessionID == nil {
+		return fail(ErrArchiveUnauthorized)
+	}
+	session, err := h.ValidateAuthorization(ctx, authorization)
+	if err != nil {
+		return fail(ErrArchiveUnauthorized)
+	}
+	if session.GetSettingsBundleName() != models.SettingsBundleName(bundle) {
+		return fail(ErrArchiveForbidden)
+	}
+	config, err := h.bundleConfiguration(models.SettingsBundleName(bundle), span)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return fail(fmt.Errorf("Invalid archive offset '%s': %v", parts[2], err))
+	}
+	record, err := config.archives.files.Read(parts[1], offset)
+	if err != nil {
+		return fail(err)
+	}
+	if record.Get(warc.FieldType) != warc.TypeResponse {
+		return fail(fmt.Errorf("No archived response at offset %d of '%s'", offset, parts[1]))
+	}
+	return record, nil
+}
@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/warc"
+)
+
+func (suite *ResolversSuite) TestArchivedResponse() {
+	config := suite.newConfiguration(nil)
+	suite.handler.configs["TEST"] = config
+	defer delete(suite.handler.configs, "TEST")
+	suite.handler.sessions = AuthenticatedSessionsMap{
+		"SIMULATED": NewSimulatedSession("TEST"),
+		"OTHER":     &simulatedSession{sessionID: "OTHER", settingsName: "OTHER"},
+	}
+	defer func() { suite.handler.sessions = nil }()
+
+	response := warc.NewRecord(warc.TypeResponse, time.Now(), warc.HTTPResponseBlock("HTTP/1.1", "200 OK", nil, []byte("page")))
+	request := warc.NewRecord(warc.TypeRequest, time.Now(), nil)
+	locations, err := config.archives.files.Append([]*warc.Record{response, request}, suite.span)
+	if !suite.Nil(err, "Unable to archive the records") {
+		return
+	}
+	path := strings.TrimPrefix(string(archiveReplayPath("TEST", locations[0])), ArchiveReplayPath)
+	requestPath := strings.TrimPrefix(string(archiveReplayPath("TEST", locations[1])), ArchiveReplayPath)
+
+	session := func(id models.AuthenticatedSessionID) models.AuthorizationInput {
+		return models.AuthorizationInput{ClaimType: models.AuthorizationClaimTypeSessionId, ClaimMedium: models.AuthorizationClaimMediumParamValue, SessionID: &id}
+	}
+	tests := []struct {
+		name          string
+		authorization models.AuthorizationInput
+		path          string
+		err           error
+		found         bool
+	}{
+		{name: "archived response", authorization: session("SIMULATED"), path: path, found: true},
+		{name: "no session", path: path, err: ErrArchiveUnauthorized},
+		{name: "invalid session", authorization: session("INVALID"), path: path, err: ErrArchiveUnauthorized},
+		{name: "other bundle's session", authorization: session("OTHER"), path: path, err: ErrArchiveForbidden},
+		{name: "request record", authorization: session("SIMULATED"), path: requestPath},
+		{name: "unknown bundle", authorization: session("SIMULATED"), path: "OTHER/" + locations[0].File + "/0", err: ErrArchiveForbidden},
+		{name: "invalid path", authorization: session("SIMULATED"), path: "TEST/" + locations[0].File},
+		{name: "invalid file", authorization: session("SIMULATED"), path: "TEST/..%2Fstorage.json/0"},
+	}
+	for _, test := range tests {
+		record, err := suite.handler.ArchivedResponse(context.Background(), test.authorization, test.path, suite.span)
+		if test.found {
+			if suite.Nil(err, test.name) {
+				suite.Equal(response.Get(warc.FieldRecordID), record.Get(warc.FieldRecordID), test.name)
+			}
+			continue
+		}
+		suite.Nil(record, test.name)
+		if suite.NotNil(err, test.name) && test.err != nil {
+			suite.Equal(test.err, err, test.name)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DefaultBatchConcurrency is used when a settings bundle doesn't configure harvest.batchConcurrency
+const DefaultBatchConcurrency = 8
+
+// harvestTexts harvests the URLs in all texts, resolving each unique URL only once with at most
+// harvest.batchConcurrency resolutions running at the same time. The results are in the order of texts
+// and, like harvestText, report every place a URL was discovered.
+func (c *Configuration) harvestTexts(h *ServiceHandler, texts []models.LargeText, parent opentracing.Span) []*models.HarvestedResources {
+	span := h.observatory.StartChildTrace("resolvers.harvestTexts", parent)
+	defer span.Finish()
+
+	textURLs := make([][]*discoveredURL, len(texts))
+	unique := make(map[string]*models.HarvestedResources)
+	for i, text := range texts {
+		textURLs[i] = discoverURLs(text)
+		for _, discovered := range textURLs[i] {
+			unique[discovered.url] = nil
+		}
+	}
+
+	concurrency := DefaultBatchConcurrency
+	if c.settings.Harvest.BatchConcurrency != nil && *c.settings.Harvest.BatchConcurrency > 0 {
+		concurrency = int(*c.settings.Harvest.BatchConcurrency)
+	}
+	span.LogFields(log.Int("texts", len(texts)), log.Int("uniqueURLs", len(unique)), log.Int("concurrency", concurrency))
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for url := range unique {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(url string) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			result := new(models.HarvestedResources)
+			c.contentHarvester.harvestURL(result, url, nil, span)
+			mutex.Lock()
+			unique[url] = result
+			mutex.Unlock()
+		}(url)
+	}
+	wg.Wait()
+
+	results := make([]*models.HarvestedResources, len(texts))
+	for i, text := range texts {
+		result := new(models.HarvestedResources)
+		result.Text = text
+		for _, discovered := range textURLs[i] {
+			// URLs are resolved once for all texts so each text gets copies saying where it has them
+			resolved, discovery := unique[discovered.url], discovered.discovery()
+			for _, resource := range resolved.Harvested {
+				harvested := *resource
+				harvested.Discovery = discovery
+				result.Harvested = append(result.Harvested, &harvested)
+			}
+			for _, resource := range resolved.Ignored {
+				ignored := *resource
+				ignored.Discovery = discovery
+				result.Ignored = append(result.Ignored, &ignored)
+			}
+			for _, resource := range resolved.Invalid {
+				invalid := *resource
+				invalid.Discovery = discovery
+				result.Invalid = append(result.Invalid, &invalid)
+			}
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// UrlsInTexts is the batch version of urlsInText, URLs appearing in several texts are only resolved once
+func (q *query) UrlsInTexts(ctx context.Context, authorization models.AuthorizationInput, texts []models.LargeText) ([]*models.HarvestedResources, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_urlsInTexts")
+	defer span.Finish()
+
+	authSess, sessErr := q.handler.ValidateAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf := q.handler.configs[authSess.GetSettingsBundleName()]
+	if conf == nil {
+		error := fmt.Errorf("Unable to run query: config '%s' not found", authSess.GetSettingsBundleName())
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+	return conf.harvestTexts(q.handler, texts, span), nil
+}
+
+// SaveURLsinTexts is the batch version of saveURLsinText, the resources of all texts are saved together
+func (m *mutation) SaveURLsinTexts(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, texts []models.LargeText) ([]*models.HarvestedResources, error) {
+	span, ctx := m.handler.observatory.StartTraceFromContext(ctx, "Mutation_saveURLsinTexts")
+	defer span.Finish()
+
+	results, err := m.handler.queries.UrlsInTexts(ctx, authorization, texts)
+	if err != nil {
+		return results, err
+	}
+
+	combined := new(models.HarvestedResources)
+	for _, result := range results {
+		combined.Harvested = append(combined.Harvested, result.Harvested...)
+		combined.Ignored = append(combined.Ignored, result.Ignored...)
+		combined.Invalid = append(combined.Invalid, result.Invalid...)
+	}
+	err = m.saveResources(ctx, span, authorization, destination, combined)
+	return results, err
+}
@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestHarvestTexts() {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><head><title>Page</title></head><body>page</body></html>")
+	}))
+	defer server.Close()
+	config := suite.newConfiguration(nil)
+
+	page := server.URL + "/page"
+	texts := []models.LargeText{
+		models.LargeText("First " + page + " and again " + page),
+		models.LargeText("Only " + page),
+	}
+	results := config.harvestTexts(suite.handler, texts, suite.span)
+	suite.Equal(int32(1), atomic.LoadInt32(&requests), "A URL in several texts should be resolved once")
+	if !suite.Len(results, len(texts)) {
+		return
+	}
+	for i, text := range texts {
+		suite.Equal(text, results[i].Text)
+		if !suite.Len(results[i].Harvested, strings.Count(string(text), page), "Every discovery of text %d should be reported", i) {
+			continue
+		}
+		offset := 0
+		for _, harvested := range results[i].Harvested {
+			offset += strings.Index(string(text)[offset:], page)
+			if suite.NotNil(harvested.Discovery) {
+				suite.Equal(models.TextOffset(offset), harvested.Discovery.Offset, "Text %d", i)
+			}
+			offset += len(page)
+		}
+	}
+}
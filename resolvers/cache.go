@@ -0,0 +1,110 @@
+package resolvers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// resolutionCache keeps where URLs led to in the settings bundle's datastore for harvest.resolutionCacheMinutes
+// so shortened links seen again aren't resolved again
+type resolutionCache struct {
+	config *Configuration
+	ttl    time.Duration
+}
+
+// newResolutionCache returns nil unless the settings bundle configures harvest.resolutionCacheMinutes
+func newResolutionCache(config *Configuration) *resolutionCache {
+	minutes := config.settings.Harvest.ResolutionCacheMinutes
+	if minutes == nil || *minutes == 0 {
+		return nil
+	}
+	result := new(resolutionCache)
+	result.config = config
+	result.ttl = time.Duration(*minutes) * time.Minute
+	return result
+}
+
+// resolutionPolicyID identifies the settings a resolution depends on: whether HTML redirects are followed,
+// including per-domain overrides, and the fetch policy's redirect limit and accepted content types. URLs
+// resolved under one policy aren't reused under another.
+func resolutionPolicyID(policy *fetch.Policy, domains *domainRules, followHTMLRedirects bool) string {
+	var overrides []string
+	if domains != nil {
+		for _, override := range domains.overrides {
+			if override.followHTMLRedirects != nil {
+				overrides = append(overrides, fmt.Sprintf("%s=%t", override.domain, *override.followHTMLRedirects))
+			}
+		}
+	}
+	sort.Strings(overrides)
+	accepted := append([]string{}, policy.AcceptedContentTypes...)
+	sort.Strings(accepted)
+
+	description := fmt.Sprintf("followHTMLRedirects=%t;domains=%s;maxRedirects=%d;acceptedContentTypes=%s", followHTMLRedirects, strings.Join(overrides, ","), policy.MaxRedirects, strings.Join(accepted, ","))
+	hash := sha1.Sum([]byte(description))
+	return hex.EncodeToString(hash[:8])
+}
+
+// load returns the cached resolution of urlText under policy, or nil if there's none or it's older than the TTL
+func (c *resolutionCache) load(urlText string, policy string, span opentracing.Span) *resolution {
+	record, err := c.config.Store().Resolutions().LoadResolution(models.URLText(urlText), policy)
+	if err != nil {
+		span.LogFields(log.String("resolution.cache", "miss"))
+		return nil
+	}
+	if time.Since(record.ResolvedAt) >= c.ttl {
+		span.LogFields(log.String("resolution.cache", "expired"), log.String("resolvedAt", record.ResolvedAt.String()))
+		return nil
+	}
+	resolved, err := url.Parse(string(record.Resolved))
+	if err != nil {
+		span.LogFields(log.String("resolution.cache", "miss"), log.Error(err))
+		return nil
+	}
+
+	span.LogFields(log.String("resolution.cache", "hit"))
+	return &resolution{
+		resolved:       resolved,
+		isHTMLRedirect: record.IsHTMLRedirect,
+		redirectURL:    string(record.RedirectURL),
+		redirectChain:  record.RedirectChain,
+		canonicalURL:   string(record.Canonical),
+		metadata:       record.Metadata,
+		cacheStatus:    models.ResolutionCacheStatusCached,
+	}
+}
+
+// save stores resolved as the resolution of urlText under policy and marks it FRESH if that worked
+func (c *resolutionCache) save(urlText string, policy string, resolved *resolution, span opentracing.Span) {
+	record := &persistence.ResolutionRecord{
+		URL:            models.URLText(urlText),
+		Policy:         policy,
+		Resolved:       urlToString(resolved.resolved),
+		IsHTMLRedirect: resolved.isHTMLRedirect,
+		RedirectURL:    models.URLText(resolved.redirectURL),
+		RedirectChain:  resolved.redirectChain,
+		Canonical:      models.URLText(resolved.canonicalURL),
+		Metadata:       resolved.metadata,
+		ResolvedAt:     time.Now(),
+	}
+	err := c.config.Store().Resolutions().SaveResolution(record)
+	if err != nil {
+		error := fmt.Errorf("Unable to cache resolution of %s: %v", urlText, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return
+	}
+	resolved.cacheStatus = models.ResolutionCacheStatusFresh
+}
@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestResolutionPolicyID() {
+	follow, dontFollow := true, false
+	domains := func(overrides ...*domainOverride) *domainRules {
+		return &domainRules{overrides: overrides}
+	}
+	policy := func(maxRedirects int, accepted ...string) *fetch.Policy {
+		return &fetch.Policy{MaxRedirects: maxRedirects, AcceptedContentTypes: accepted}
+	}
+	base := resolutionPolicyID(policy(10), nil, true)
+
+	tests := []struct {
+		name                string
+		policy              *fetch.Policy
+		domains             *domainRules
+		followHTMLRedirects bool
+		same                bool
+	}{
+		{name: "same settings", policy: policy(10), followHTMLRedirects: true, same: true},
+		{name: "domains without follow overrides", policy: policy(10), domains: domains(&domainOverride{domain: "example.com", cleanURLs: true}), followHTMLRedirects: true, same: true},
+		{name: "not following HTML redirects", policy: policy(10)},
+		{name: "domain follow override", policy: policy(10), domains: domains(&domainOverride{domain: "example.com", followHTMLRedirects: &dontFollow}), followHTMLRedirects: true},
+		{name: "max redirects", policy: policy(5), followHTMLRedirects: true},
+		{name: "accepted content types", policy: policy(10, "text/html"), followHTMLRedirects: true},
+	}
+	for _, test := range tests {
+		id := resolutionPolicyID(test.policy, test.domains, test.followHTMLRedirects)
+		suite.Equal(test.same, id == base, test.name)
+	}
+
+	suite.Equal(
+		resolutionPolicyID(policy(10, "text/html", "text/plain"), domains(&domainOverride{domain: "a.com", followHTMLRedirects: &follow}, &domainOverride{domain: "b.com", followHTMLRedirects: &dontFollow}), true),
+		resolutionPolicyID(policy(10, "text/plain", "text/html"), domains(&domainOverride{domain: "b.com", followHTMLRedirects: &dontFollow}, &domainOverride{domain: "a.com", followHTMLRedirects: &follow}), true),
+		"The order of overrides and content types shouldn't matter")
+}
+
+func (suite *ResolversSuite) TestResolutionCache() {
+	server := newHarvestServer()
+	defer server.Close()
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		minutes := models.CacheTTLMinutes(60)
+		settings.Harvest.ResolutionCacheMinutes = &minutes
+		settings.Harvest.AllowRequestDirectives = true
+	})
+	dontFollow := false
+	notFollowing, err := config.harvesterWithDirectives(suite.handler, &models.HarvestDirectivesInput{FollowHTMLRedirects: &dontFollow}, suite.span)
+	suite.Nil(err, "Directives should be allowed")
+
+	tests := []struct {
+		name      string
+		harvester *resourceHarvester
+		status    models.ResolutionCacheStatus
+		final     string
+	}{
+		{name: "first resolution", harvester: config.contentHarvester, status: models.ResolutionCacheStatusFresh, final: "/page"},
+		{name: "cached resolution", harvester: config.contentHarvester, status: models.ResolutionCacheStatusCached, final: "/page"},
+		{name: "other policy isn't served from the cache", harvester: notFollowing, status: models.ResolutionCacheStatusFresh, final: "/refresh"},
+		{name: "other policy's cached resolution", harvester: notFollowing, status: models.ResolutionCacheStatusCached, final: "/refresh"},
+		{name: "first policy still cached", harvester: config.contentHarvester, status: models.ResolutionCacheStatusCached, final: "/page"},
+	}
+	for _, test := range tests {
+		result := test.harvester.harvestText(models.LargeText(server.URL+"/refresh"), suite.span)
+		if !suite.Len(result.Harvested, 1, test.name) {
+			continue
+		}
+		suite.Equal(test.status, result.Harvested[0].CacheStatus, test.name)
+		suite.Equal(models.URLText(server.URL+test.final), result.Harvested[0].Urls.Final, test.name)
+	}
+}
@@ -0,0 +1,280 @@
+package resolvers
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/lectio/lectiod/models"
+	"golang.org/x/net/publicsuffix"
+)
+
+// canonicalizer rewrites the URLs of a site to their canonical form, canonicalize returns nil for URLs it
+// doesn't recognise or which are already canonical. declared is the canonical URL the page at u declares, nil
+// if it isn't known.
+type canonicalizer interface {
+	canonicalize(u *url.URL, declared *url.URL) *url.URL
+}
+
+// canonicalizerFunc adapts a function which only needs the URL to the canonicalizer interface
+type canonicalizerFunc func(u *url.URL) *url.URL
+
+func (f canonicalizerFunc) canonicalize(u *url.URL, declared *url.URL) *url.URL {
+	return f(u)
+}
+
+type registeredCanonicalizer struct {
+	name models.CanonicalizerName
+	canonicalizer
+}
+
+// canonicalizerRegistry holds the built-in canonicalizers in the order they're applied
+var canonicalizerRegistry []*registeredCanonicalizer
+
+// registerCanonicalizer makes a canonicalizer available to settings bundles, it's off unless a bundle turns it on
+func registerCanonicalizer(name models.CanonicalizerName, c canonicalizer) {
+	canonicalizerRegistry = append(canonicalizerRegistry, &registeredCanonicalizer{name: name, canonicalizer: c})
+}
+
+func init() {
+	registerCanonicalizer(models.CanonicalizerNameGoogleRedirect, canonicalizerFunc(canonicalGoogleRedirectURL))
+	registerCanonicalizer(models.CanonicalizerNameAmp, ampCanonicalizer{})
+	registerCanonicalizer(models.CanonicalizerNameYoutube, canonicalizerFunc(canonicalYouTubeURL))
+	registerCanonicalizer(models.CanonicalizerNameAmazon, canonicalizerFunc(canonicalAmazonURL))
+	registerCanonicalizer(models.CanonicalizerNameMedium, canonicalizerFunc(canonicalMediumURL))
+}
+
+// canonicalizerList is the list of canonicalizers enabled for a settings bundle
+type canonicalizerList []*registeredCanonicalizer
+
+// newCanonicalizerList returns the registered canonicalizers settings turns on
+func newCanonicalizerList(settings []*models.CanonicalizerSettings) canonicalizerList {
+	enabled := make(map[models.CanonicalizerName]bool)
+	for _, setting := range settings {
+		if setting != nil {
+			enabled[setting.Name] = setting.Enabled
+		}
+	}
+	var result canonicalizerList
+	for _, registered := range canonicalizerRegistry {
+		if enabled[registered.name] {
+			result = append(result, registered)
+		}
+	}
+	return result
+}
+
+// maxCanonicalizerRounds stops canonicalizers which keep rewriting each other's URLs
+const maxCanonicalizerRounds = 5
+
+// canonicalURL applies the canonicalizers to u until none of them changes it any more, it returns the canonical
+// URL and the canonicalizers which rewrote it, in order. declared is the canonical URL the page at u declares,
+// nil if it isn't known, it no longer applies once u is rewritten.
+func (l canonicalizerList) canonicalURL(u *url.URL, declared *url.URL) (*url.URL, []models.CanonicalizerName) {
+	var applied []models.CanonicalizerName
+	for round := 0; round < maxCanonicalizerRounds; round++ {
+		changed := false
+		for _, registered := range l {
+			if canonical := registered.canonicalize(u, declared); canonical != nil && canonical.String() != u.String() {
+				u = canonical
+				declared = nil
+				changed = true
+				if len(applied) == 0 || applied[len(applied)-1] != registered.name {
+					applied = append(applied, registered.name)
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return u, applied
+}
+
+// ruleURLs returns the URLs the domain and ignore rules are checked against: resolved, which existing rules
+// were written for, and canonical if the canonicalizers rewrote it
+func ruleURLs(resolved *url.URL, canonical *url.URL) []*url.URL {
+	if canonical.String() == resolved.String() {
+		return []*url.URL{resolved}
+	}
+	return []*url.URL{resolved, canonical}
+}
+
+// siteName returns the label before the public suffix of host, so "google" for www.google.co.uk
+func siteName(host string) string {
+	domain, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(host))
+	if err != nil {
+		return ""
+	}
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	return strings.TrimSuffix(strings.TrimSuffix(domain, suffix), ".")
+}
+
+// withoutQueryParams returns a copy of u without the query parameters for which remove returns true, or nil
+// if there were none
+func withoutQueryParams(u *url.URL, remove func(param string) bool) *url.URL {
+	query := u.Query()
+	removed := false
+	for param := range query {
+		if remove(param) {
+			query.Del(param)
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+	result := *u
+	result.RawQuery = query.Encode()
+	return &result
+}
+
+// canonicalGoogleRedirectURL unwraps google.com/url?q= links, as found in search results and Google Alerts
+func canonicalGoogleRedirectURL(u *url.URL) *url.URL {
+	if siteName(u.Hostname()) != "google" || u.Path != "/url" {
+		return nil
+	}
+	query := u.Query()
+	for _, param := range []string{"q", "url"} {
+		target, err := url.Parse(query.Get(param))
+		if err == nil && target.IsAbs() && (target.Scheme == "http" || target.Scheme == "https") {
+			return target
+		}
+	}
+	return nil
+}
+
+var ampCachePath = regexp.MustCompile(`^/(?:[cvi]/)?(s/)?([^/]+)(/.*)?$`)
+
+// ampCanonicalizer turns AMP cache URLs into the URL of the page they cache. Publishers' own AMP pages, those
+// with an amp. subdomain, a trailing /amp or .amp or an amp query parameter, are only rewritten to the canonical
+// URL the page declares, and only if it's on the same site, since those markers mean nothing on other sites.
+type ampCanonicalizer struct{}
+
+func (ampCanonicalizer) canonicalize(u *url.URL, declared *url.URL) *url.URL {
+	if cached := ampCachedURL(u); cached != nil {
+		return cached
+	}
+	if declared == nil || !isAMPURL(u) || (declared.Scheme != "http" && declared.Scheme != "https") {
+		return nil
+	}
+	site, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(u.Hostname()))
+	if err != nil {
+		return nil
+	}
+	if declaredSite, err := publicsuffix.EffectiveTLDPlusOne(strings.ToLower(declared.Hostname())); err != nil || declaredSite != site {
+		return nil
+	}
+	return declared
+}
+
+// ampCachedURL returns the URL of the page an AMP cache URL (cdn.ampproject.org or google.com/amp/) caches, or
+// nil if u isn't one
+func ampCachedURL(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	cachedPath := ""
+	switch {
+	case strings.HasSuffix(host, ".cdn.ampproject.org"):
+		cachedPath = u.EscapedPath()
+	case siteName(host) == "google" && strings.HasPrefix(u.Path, "/amp/"):
+		cachedPath = strings.TrimPrefix(u.EscapedPath(), "/amp")
+	}
+	if cachedPath == "" {
+		return nil
+	}
+	match := ampCachePath.FindStringSubmatch(cachedPath)
+	if match == nil || !strings.Contains(match[2], ".") {
+		return nil
+	}
+	result := &url.URL{Scheme: "http", Host: match[2], RawQuery: u.RawQuery}
+	if match[1] != "" {
+		result.Scheme = "https"
+	}
+	path, err := url.PathUnescape(match[3])
+	if err != nil {
+		return nil
+	}
+	result.Path = path
+	return result
+}
+
+// isAMPURL returns true if u has one of the markers publishers add to the URLs of their AMP pages
+func isAMPURL(u *url.URL) bool {
+	if strings.HasPrefix(strings.ToLower(u.Hostname()), "amp.") {
+		return true
+	}
+	for _, marker := range []string{"/amp/", "/amp", ".amp.html", ".amp"} {
+		if strings.HasSuffix(u.Path, marker) && len(u.Path) > len(marker) {
+			return true
+		}
+	}
+	query := u.Query()
+	_, hasAMPParam := query["amp"]
+	return hasAMPParam || query.Get("outputType") == "amp"
+}
+
+var youTubeVideoPath = regexp.MustCompile(`^/(?:embed|v|shorts|live)/([A-Za-z0-9_-]{11})`)
+
+// canonicalYouTubeURL turns youtu.be, embed and shorts links into www.youtube.com/watch?v= URLs, keeping the
+// t= timestamp they link to
+func canonicalYouTubeURL(u *url.URL) *url.URL {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	video := ""
+	switch {
+	case host == "youtu.be":
+		video = strings.Trim(u.Path, "/")
+	case host == "youtube.com" || host == "m.youtube.com" || host == "music.youtube.com" || host == "youtube-nocookie.com":
+		if u.Path == "/watch" {
+			video = u.Query().Get("v")
+		} else if match := youTubeVideoPath.FindStringSubmatch(u.Path); match != nil {
+			video = match[1]
+		}
+	}
+	if video == "" || strings.Contains(video, "/") {
+		return nil
+	}
+	query := url.Values{"v": {video}}.Encode()
+	if timestamp := u.Query().Get("t"); timestamp != "" {
+		query += "&" + url.Values{"t": {timestamp}}.Encode()
+	}
+	return &url.URL{Scheme: "https", Host: "www.youtube.com", Path: "/watch", RawQuery: query}
+}
+
+var amazonProductPath = regexp.MustCompile(`/(?:dp|gp/product|gp/aw/d|exec/obidos/ASIN|o/ASIN)/([A-Z0-9]{10})(?:[/?]|$)`)
+
+// amazonAffiliateParams are the query parameters Amazon uses for referral and affiliate tracking
+var amazonAffiliateParams = regexp.MustCompile(`^(?:tag|ref|ref_|linkCode|linkId|camp|creative|creativeASIN|ascsubtag|pd_rd_.*|pf_rd_.*)$`)
+
+// canonicalAmazonURL turns Amazon product links into /dp/ASIN URLs and strips ref= paths and affiliate
+// parameters from other Amazon links
+func canonicalAmazonURL(u *url.URL) *url.URL {
+	if siteName(u.Hostname()) != "amazon" {
+		return nil
+	}
+	if match := amazonProductPath.FindStringSubmatch(u.Path); match != nil {
+		return &url.URL{Scheme: "https", Host: u.Host, Path: "/dp/" + match[1]}
+	}
+
+	result := *u
+	if index := strings.Index(result.Path, "/ref="); index >= 0 {
+		result.Path = result.Path[:index+1]
+		result.RawPath = ""
+	}
+	if stripped := withoutQueryParams(&result, amazonAffiliateParams.MatchString); stripped != nil {
+		result = *stripped
+	}
+	if result.String() == u.String() {
+		return nil
+	}
+	return &result
+}
+
+// canonicalMediumURL removes the tracking parameters Medium adds to its story links
+func canonicalMediumURL(u *url.URL) *url.URL {
+	if !domainMatches(strings.ToLower(u.Hostname()), "medium.com") {
+		return nil
+	}
+	return withoutQueryParams(u, func(param string) bool {
+		return param == "source" || param == "gi" || param == "_branch_match_id" || param == "_branch_referrer"
+	})
+}
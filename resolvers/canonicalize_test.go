@@ -0,0 +1,124 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lectio/lectiod/models"
+)
+
+// enabledCanonicalizers returns settings turning on every registered canonicalizer
+func enabledCanonicalizers() []*models.CanonicalizerSettings {
+	var result []*models.CanonicalizerSettings
+	for _, registered := range canonicalizerRegistry {
+		result = append(result, &models.CanonicalizerSettings{Name: registered.name, Enabled: true})
+	}
+	return result
+}
+
+func (suite *ResolversSuite) TestCanonicalURL() {
+	tests := []struct {
+		url       string
+		declared  string
+		canonical string
+		applied   []models.CanonicalizerName
+	}{
+		{url: "https://example.com/page?id=1", canonical: "https://example.com/page?id=1"},
+		{url: "https://www.google.com/url?q=https://example.com/page&sa=D", canonical: "https://example.com/page", applied: []models.CanonicalizerName{models.CanonicalizerNameGoogleRedirect}},
+		{url: "https://www.google.co.uk/url?url=https://example.com/page", canonical: "https://example.com/page", applied: []models.CanonicalizerName{models.CanonicalizerNameGoogleRedirect}},
+		{url: "https://www.google.com/url?q=javascript:alert(1)", canonical: "https://www.google.com/url?q=javascript:alert(1)"},
+		{url: "https://www-example-com.cdn.ampproject.org/c/s/www.example.com/news/story", canonical: "https://www.example.com/news/story", applied: []models.CanonicalizerName{models.CanonicalizerNameAmp}},
+		{url: "https://www.google.com/amp/s/www.example.com/news/story/amp", canonical: "https://www.example.com/news/story/amp", applied: []models.CanonicalizerName{models.CanonicalizerNameAmp}},
+		{url: "https://amp.example.com/news/story.amp.html", declared: "https://example.com/news/story.html", canonical: "https://example.com/news/story.html", applied: []models.CanonicalizerName{models.CanonicalizerNameAmp}},
+		{url: "https://example.com/story?amp=1&id=2", declared: "https://www.example.com/story?id=2", canonical: "https://www.example.com/story?id=2", applied: []models.CanonicalizerName{models.CanonicalizerNameAmp}},
+		{url: "https://amp.example.com/news/story.amp.html", canonical: "https://amp.example.com/news/story.amp.html"},
+		{url: "https://example.com/news/story/amp", declared: "https://other.example.org/news/story", canonical: "https://example.com/news/story/amp"},
+		{url: "https://example.com/news/story", declared: "https://example.com/news/other", canonical: "https://example.com/news/story"},
+		{url: "https://example.com/glossary/amp", canonical: "https://example.com/glossary/amp"},
+		{url: "https://youtu.be/dQw4w9WgXcQ", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", applied: []models.CanonicalizerName{models.CanonicalizerNameYoutube}},
+		{url: "https://youtu.be/dQw4w9WgXcQ?t=42", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42", applied: []models.CanonicalizerName{models.CanonicalizerNameYoutube}},
+		{url: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1m30s&list=PL1", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1m30s", applied: []models.CanonicalizerName{models.CanonicalizerNameYoutube}},
+		{url: "https://www.youtube.com/shorts/dQw4w9WgXcQ", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", applied: []models.CanonicalizerName{models.CanonicalizerNameYoutube}},
+		{url: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{url: "https://www.amazon.com/Some-Book/dp/B000000001/ref=sr_1_1?tag=aff-20", canonical: "https://www.amazon.com/dp/B000000001", applied: []models.CanonicalizerName{models.CanonicalizerNameAmazon}},
+		{url: "https://www.amazon.co.uk/s/ref=nb_sb?k=books&tag=aff-21", canonical: "https://www.amazon.co.uk/s/?k=books", applied: []models.CanonicalizerName{models.CanonicalizerNameAmazon}},
+		{url: "https://medium.com/@writer/story-123?source=rss", canonical: "https://medium.com/@writer/story-123", applied: []models.CanonicalizerName{models.CanonicalizerNameMedium}},
+		{url: "https://www.google.com/url?q=https://youtu.be/dQw4w9WgXcQ", canonical: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", applied: []models.CanonicalizerName{models.CanonicalizerNameGoogleRedirect, models.CanonicalizerNameYoutube}},
+	}
+	canonicalizers := newCanonicalizerList(enabledCanonicalizers())
+	for _, test := range tests {
+		u, err := url.Parse(test.url)
+		suite.Nil(err, test.url)
+		var declared *url.URL
+		if test.declared != "" {
+			declared, _ = url.Parse(test.declared)
+		}
+		canonical, applied := canonicalizers.canonicalURL(u, declared)
+		suite.Equal(test.canonical, canonical.String(), "%s (%s)", test.url, test.declared)
+		suite.Equal(test.applied, applied, "%s (%s)", test.url, test.declared)
+	}
+
+	u, _ := url.Parse("https://youtu.be/dQw4w9WgXcQ")
+	for name, settings := range map[string][]*models.CanonicalizerSettings{
+		"unlisted": nil,
+		"disabled": {{Name: models.CanonicalizerNameYoutube, Enabled: false}},
+	} {
+		canonical, applied := newCanonicalizerList(settings).canonicalURL(u, nil)
+		suite.Equal("https://youtu.be/dQw4w9WgXcQ", canonical.String(), "%s canonicalizers shouldn't apply", name)
+		suite.Nil(applied, "%s canonicalizers shouldn't apply", name)
+	}
+}
+
+// TestRulesApplyToCanonicalURL harvests URLs whose resolutions are cached, so the wrapped URLs aren't fetched
+func (suite *ResolversSuite) TestRulesApplyToCanonicalURL() {
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		minutes := models.CacheTTLMinutes(60)
+		settings.Harvest.ResolutionCacheMinutes = &minutes
+		denied := models.DomainName("ads.example.com")
+		settings.Harvest.DenyDomains = []*models.DomainName{&denied}
+		ignored := models.RegularExpression(`^https://www\.google\.com/url\?q=https://example\.com/old`)
+		settings.Harvest.IgnoreURLsRegExprs = append(settings.Harvest.IgnoreURLsRegExprs, &ignored)
+		settings.Harvest.Canonicalizers = enabledCanonicalizers()
+	})
+	harvester := config.contentHarvester
+
+	tests := []struct {
+		name     string
+		resolved string
+		declared string
+		code     models.ReasonCode
+		rule     string
+		final    string
+	}{
+		{name: "denied domain", resolved: "https://www.google.com/url?q=https://ads.example.com/banner", code: models.ReasonCodeDomainDenied, rule: "ads.example.com", final: "https://ads.example.com/banner"},
+		{name: "ignore rule", resolved: "https://www.google.com/url?q=https://twitter.com/lectio/status/1", code: models.ReasonCodeMatchedIgnoreRule, rule: `^https://twitter.com/(.*?)/status/(.*)$`, final: "https://twitter.com/lectio/status/1"},
+		{name: "ignore rule for the resolved URL", resolved: "https://www.google.com/url?q=https://example.com/old", code: models.ReasonCodeMatchedIgnoreRule, rule: `^https://www\.google\.com/url\?q=https://example\.com/old`, final: "https://www.google.com/url?q=https://example.com/old"},
+		{name: "harvested", resolved: "https://www.google.com/url?q=https://example.com/page", final: "https://example.com/page"},
+		{name: "declared canonical URL", resolved: "https://example.com/news/story/amp", declared: "https://example.com/news/story", final: "https://example.com/news/story"},
+	}
+	for i, test := range tests {
+		resolved, _ := url.Parse(test.resolved)
+		original := fmt.Sprintf("https://short.example.com/%d", i)
+		harvester.cache.save(original, harvester.resolutionPolicy, &resolution{resolved: resolved, canonicalURL: test.declared}, suite.span)
+
+		result := new(models.HarvestedResources)
+		harvester.harvestURL(result, original, nil, suite.span)
+		if test.code == "" {
+			if suite.Len(result.Harvested, 1, test.name) {
+				suite.Equal(models.URLText(test.final), result.Harvested[0].Urls.Final, test.name)
+				suite.Equal(models.URLText(test.resolved), result.Harvested[0].Urls.Resolved, test.name)
+			}
+			continue
+		}
+		if !suite.Len(result.Ignored, 1, test.name) {
+			continue
+		}
+		ignored := result.Ignored[0]
+		suite.Equal(test.code, ignored.ReasonCode, test.name)
+		suite.Equal(models.URLText(test.final), ignored.Urls.Final, test.name)
+		suite.Equal(models.URLText(test.resolved), ignored.Urls.Resolved, test.name)
+		if suite.NotNil(ignored.MatchedRule, test.name) {
+			suite.Equal(models.RuleIdentifier(test.rule), *ignored.MatchedRule, test.name)
+		}
+	}
+}
@@ -2,15 +2,18 @@ package resolvers
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
+	"github.com/lectio/lectiod/fetch"
 	"github.com/lectio/lectiod/models"
 	"github.com/lectio/lectiod/persistence"
 	"github.com/spf13/viper"
+	"golang.org/x/net/publicsuffix"
 
-	"github.com/lectio/harvester"
 	opentracing "github.com/opentracing/opentracing-go"
 	opentrext "github.com/opentracing/opentracing-go/ext"
 	"github.com/opentracing/opentracing-go/log"
@@ -25,18 +28,56 @@ const (
 	DefaultSettingsBundleName models.SettingsBundleName = "DEFAULT"
 )
 
-type ignoreURLsRegExList []*regexp.Regexp
-type cleanURLsRegExList []*regexp.Regexp
+// harvestRule is a named regular expression in an ignore or cleaner list, rules configured as plain
+// regular expressions are named after their expression. hits counts how often the rule fired.
+type harvestRule struct {
+	name        models.RuleIdentifier
+	description *models.MediumText
+	enabled     bool
+	regEx       *regexp.Regexp
+	hits        uint64
+}
+
+type ignoreURLsRegExList []*harvestRule
+type cleanURLsRegExList []*harvestRule
+
+// newHarvestRule compiles a rule, enabled defaults to true
+func newHarvestRule(name models.RuleIdentifier, description *models.MediumText, enabled *bool, value models.RegularExpression) (*harvestRule, error) {
+	re, err := regexp.Compile(string(value))
+	if err != nil {
+		return nil, err
+	}
+	result := new(harvestRule)
+	result.name = name
+	if result.name == "" {
+		result.name = models.RuleIdentifier(value)
+	}
+	result.description = description
+	result.enabled = enabled == nil || *enabled
+	result.regEx = re
+	return result, nil
+}
+
+// matches returns true if the rule is enabled and matches text
+func (r *harvestRule) matches(text string) bool {
+	return r.enabled && r.regEx.MatchString(text)
+}
+
+// hit counts a match of the rule and returns it
+func (r *harvestRule) hit() *harvestRule {
+	atomic.AddUint64(&r.hits, 1)
+	return r
+}
 
 func (l *ignoreURLsRegExList) Add(settings *models.SettingsBundle, value models.RegularExpression) {
 	if value != "" {
-		re, error := regexp.Compile(string(value))
+		rule, error := newHarvestRule("", nil, nil, value)
 		if error != nil {
 			message := models.ErrorMessage(fmt.Sprintf(`Error adding regexp '%s' to ignore list: %s`, value, error.Error()))
 			settings.Errors = append(settings.Errors, &message)
 			return
 		}
-		*l = append(*l, re)
+		*l = append(*l, rule)
 	}
 }
 
@@ -46,25 +87,48 @@ func (l *ignoreURLsRegExList) AddSeveral(settings *models.SettingsBundle, values
 	}
 }
 
-func (l ignoreURLsRegExList) IgnoreDiscoveredResource(url *url.URL) (bool, string) {
+// AddRules adds the named rules of a settings bundle
+func (l *ignoreURLsRegExList) AddRules(settings *models.SettingsBundle, rules []*models.HarvestRule) {
+	for _, value := range rules {
+		rule, error := newHarvestRule(value.Name, value.Description, value.Enabled, value.RegEx)
+		if error != nil {
+			message := models.ErrorMessage(fmt.Sprintf(`Error adding rule '%s' to ignore list: %s`, value.Name, error.Error()))
+			settings.Errors = append(settings.Errors, &message)
+			continue
+		}
+		*l = append(*l, rule)
+	}
+}
+
+// ignoreRule returns the first rule matching url, counting the hit, or nil
+func (l ignoreURLsRegExList) ignoreRule(url *url.URL) *harvestRule {
+	rule := l.match(url)
+	if rule != nil {
+		return rule.hit()
+	}
+	return nil
+}
+
+// match returns the first rule matching url without counting the hit, or nil
+func (l ignoreURLsRegExList) match(url *url.URL) *harvestRule {
 	URLtext := url.String()
-	for _, regEx := range l {
-		if regEx.MatchString(URLtext) {
-			return true, fmt.Sprintf("Matched Ignore Rule `%s`", regEx.String())
+	for _, rule := range l {
+		if rule.matches(URLtext) {
+			return rule
 		}
 	}
-	return false, ""
+	return nil
 }
 
 func (l *cleanURLsRegExList) Add(settings *models.SettingsBundle, value models.RegularExpression) {
 	if value != "" {
-		re, error := regexp.Compile(string(value))
+		rule, error := newHarvestRule("", nil, nil, value)
 		if error != nil {
 			message := models.ErrorMessage(fmt.Sprintf(`Error adding regexp '%s' to ignore list: %s`, value, error.Error()))
 			settings.Errors = append(settings.Errors, &message)
 			return
 		}
-		*l = append(*l, re)
+		*l = append(*l, rule)
 	}
 }
 
@@ -74,26 +138,199 @@ func (l *cleanURLsRegExList) AddSeveral(settings *models.SettingsBundle, values
 	}
 }
 
-func (l cleanURLsRegExList) CleanDiscoveredResource(url *url.URL) bool {
-	// we try to clean all URLs, not specific ones
-	return true
+// AddRules adds the named rules of a settings bundle
+func (l *cleanURLsRegExList) AddRules(settings *models.SettingsBundle, rules []*models.HarvestRule) {
+	for _, value := range rules {
+		rule, error := newHarvestRule(value.Name, value.Description, value.Enabled, value.RegEx)
+		if error != nil {
+			message := models.ErrorMessage(fmt.Sprintf(`Error adding rule '%s' to cleaner list: %s`, value.Name, error.Error()))
+			settings.Errors = append(settings.Errors, &message)
+			continue
+		}
+		*l = append(*l, rule)
+	}
 }
 
 func (l cleanURLsRegExList) RemoveQueryParamFromResource(paramName string) (bool, string) {
-	for _, regEx := range l {
-		if regEx.MatchString(paramName) {
-			return true, fmt.Sprintf("Matched cleaner rule `%s`", regEx.String())
-		}
+	rule := l.match(paramName)
+	if rule != nil {
+		rule.hit()
+		return true, fmt.Sprintf("Matched cleaner rule `%s`", rule.name)
 	}
 	return false, ""
 }
 
+// match returns the first rule matching paramName without counting the hit, or nil
+func (l cleanURLsRegExList) match(paramName string) *harvestRule {
+	for _, rule := range l {
+		if rule.matches(paramName) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// domainRules holds a settings bundle's domain allow and deny lists and its per-domain overrides. Entries
+// are lowercase host names which match themselves and their subdomains.
+type domainRules struct {
+	allow     []string
+	deny      []string
+	overrides []*domainOverride
+}
+
+// domainOverride is a DomainHarvestRules entry with its cleaner rules compiled
+type domainOverride struct {
+	domain                    string
+	cleanURLs                 bool
+	removeParamsFromURLsRegEx cleanURLsRegExList
+	followHTMLRedirects       *bool
+}
+
+// normalizeDomain lowercases value and strips any scheme, port, path or trailing dot. Public suffixes like
+// com or co.uk are rejected because they'd match unrelated sites; privately registered suffixes like
+// github.io are allowed.
+func normalizeDomain(value models.DomainName) (string, error) {
+	domain := strings.ToLower(strings.TrimSpace(string(value)))
+	if strings.Contains(domain, "://") {
+		u, err := url.Parse(domain)
+		if err != nil {
+			return "", err
+		}
+		domain = u.Host
+	}
+	domain = strings.TrimSuffix(strings.SplitN(domain, "/", 2)[0], ".")
+	if host, _, err := net.SplitHostPort(domain); err == nil {
+		domain = host
+	}
+	if domain == "" {
+		return "", fmt.Errorf("empty domain")
+	}
+	if suffix, icann := publicsuffix.PublicSuffix(domain); icann && suffix == domain {
+		return "", fmt.Errorf("'%s' is a public suffix", domain)
+	}
+	return domain, nil
+}
+
+// domainMatches returns true if host is domain or one of its subdomains
+func domainMatches(host string, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// mostSpecificDomain returns the longest entry in domains matching host, or ""
+func mostSpecificDomain(host string, domains []string) string {
+	result := ""
+	for _, domain := range domains {
+		if len(domain) > len(result) && domainMatches(host, domain) {
+			result = domain
+		}
+	}
+	return result
+}
+
+// normalizeDomains normalizes the entries of a domain list, invalid entries are reported in settings.Errors
+func normalizeDomains(settings *models.SettingsBundle, list string, values []*models.DomainName) []string {
+	var result []string
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		domain, error := normalizeDomain(*value)
+		if error != nil {
+			message := models.ErrorMessage(fmt.Sprintf(`Error adding domain '%s' to %s: %s`, *value, list, error.Error()))
+			settings.Errors = append(settings.Errors, &message)
+			continue
+		}
+		result = append(result, domain)
+	}
+	return result
+}
+
+// AddSettings adds the domain lists and per-domain overrides of a settings bundle
+func (d *domainRules) AddSettings(settings *models.SettingsBundle) {
+	d.allow = append(d.allow, normalizeDomains(settings, "allowDomains", settings.Harvest.AllowDomains)...)
+	d.deny = append(d.deny, normalizeDomains(settings, "denyDomains", settings.Harvest.DenyDomains)...)
+	for _, value := range settings.Harvest.DomainRules {
+		domain, error := normalizeDomain(value.Domain)
+		if error != nil {
+			message := models.ErrorMessage(fmt.Sprintf(`Error adding rules for domain '%s': %s`, value.Domain, error.Error()))
+			settings.Errors = append(settings.Errors, &message)
+			continue
+		}
+		override := new(domainOverride)
+		override.domain = domain
+		override.cleanURLs = value.CleanURLs == nil || *value.CleanURLs
+		override.removeParamsFromURLsRegEx.AddSeveral(settings, value.RemoveParamsFromURLsRegEx)
+		override.followHTMLRedirects = value.FollowHTMLRedirects
+		d.overrides = append(d.overrides, override)
+	}
+}
+
+// ignoreReason returns why url's host may not be harvested, or nil if it may
+func (d *domainRules) ignoreReason(url *url.URL) *unharvestedReason {
+	if d == nil {
+		return nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(url.Hostname()), ".")
+	denied := mostSpecificDomain(host, d.deny)
+	allowed := mostSpecificDomain(host, d.allow)
+	switch {
+	case denied != "" && len(denied) >= len(allowed):
+		return &unharvestedReason{code: models.ReasonCodeDomainDenied, rule: denied, message: fmt.Sprintf("Matched Denied Domain `%s`", denied)}
+	case allowed == "" && len(d.allow) > 0:
+		return &unharvestedReason{code: models.ReasonCodeDomainNotAllowed, message: fmt.Sprintf("Domain `%s` is not allowed", host)}
+	}
+	return nil
+}
+
+// override returns the most specific per-domain override for url, or nil
+func (d *domainRules) override(url *url.URL) *domainOverride {
+	if d == nil {
+		return nil
+	}
+	host := strings.TrimSuffix(strings.ToLower(url.Hostname()), ".")
+	var result *domainOverride
+	for _, override := range d.overrides {
+		if domainMatches(host, override.domain) && (result == nil || len(override.domain) > len(result.domain)) {
+			result = override
+		}
+	}
+	return result
+}
+
+// cleaner returns the cleaner rules which apply to url: none if its domain keeps URLs as they are, otherwise
+// rules plus those of its domain
+func (d *domainRules) cleaner(url *url.URL, rules cleanURLsRegExList) cleanURLsRegExList {
+	override := d.override(url)
+	if override == nil {
+		return rules
+	}
+	if !override.cleanURLs {
+		return nil
+	}
+	return append(append(cleanURLsRegExList{}, rules...), override.removeParamsFromURLsRegEx...)
+}
+
+// followHTMLRedirects returns whether HTML redirects found on url should be followed
+func (d *domainRules) followHTMLRedirects(url *url.URL, follow bool) bool {
+	override := d.override(url)
+	if override == nil || override.followHTMLRedirects == nil {
+		return follow
+	}
+	return *override.followHTMLRedirects
+}
+
 type Configuration struct {
 	settings                  *models.SettingsBundle
 	store                     *persistence.Datastore
-	contentHarvester          *harvester.ContentHarvester
+	contentHarvester          *resourceHarvester
+	robots                    *robotsChecker
+	resolutions               *resolutionCache
+	archives                  *resourceArchiver
 	ignoreURLsRegEx           ignoreURLsRegExList
 	removeParamsFromURLsRegEx cleanURLsRegExList
+	domains                   *domainRules
+	jobs                      *harvestJobQueue
+	sweeping                  bool
 }
 
 func createDefaultSettings(name models.SettingsBundleName) *models.SettingsBundle {
@@ -156,6 +393,8 @@ func NewDefaultConfiguration(h *ServiceHandler, name models.SettingsBundleName,
 }
 
 func (c *Configuration) Close() {
+	c.jobs.stop()
+	c.archives.files.Close()
 	c.store.Close()
 }
 
@@ -172,8 +411,56 @@ func (c *Configuration) ConfigureContentHarvester(h *ServiceHandler, parent open
 	span := h.observatory.StartChildTrace("resolvers.ConfigureContentHarvester", parent)
 	defer span.Finish()
 
-	c.store = persistence.NewDatastore(h.observatory, &c.settings.Storage, span)
+	c.OpenStore(h, span)
 	c.ignoreURLsRegEx.AddSeveral(c.settings, c.settings.Harvest.IgnoreURLsRegExprs)
+	c.ignoreURLsRegEx.AddRules(c.settings, c.settings.Harvest.IgnoreURLsRules)
 	c.removeParamsFromURLsRegEx.AddSeveral(c.settings, c.settings.Harvest.RemoveParamsFromURLsRegEx)
-	c.contentHarvester = harvester.MakeContentHarvester(h.observatory, c.ignoreURLsRegEx, c.removeParamsFromURLsRegEx, c.settings.Harvest.FollowHTMLRedirects)
+	c.removeParamsFromURLsRegEx.AddRules(c.settings, c.settings.Harvest.RemoveParamsRules)
+	c.domains = new(domainRules)
+	c.domains.AddSettings(c.settings)
+
+	policy, err := fetch.NewPolicy(c.settings.Harvest.Fetch)
+	if err != nil {
+		message := models.ErrorMessage(err.Error())
+		c.settings.Errors = append(c.settings.Errors, &message)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+		policy, _ = fetch.NewPolicy(nil)
+	}
+	span.LogFields(log.String("fetch.timeout", policy.Timeout.String()), log.Int("fetch.maxRedirects", policy.MaxRedirects), log.String("fetch.userAgent", policy.UserAgent))
+
+	fetcher := fetch.NewClient(h.observatory, policy)
+	c.robots = newRobotsChecker(c, fetcher)
+	var robots *robotsChecker
+	if c.settings.Harvest.RespectRobotsTxt {
+		robots = c.robots
+	}
+	c.resolutions = newResolutionCache(c)
+	c.archives = newResourceArchiver(h, c)
+	var archives *resourceArchiver
+	if c.settings.Harvest.Archive {
+		archives = c.archives
+	}
+	c.contentHarvester = newResourceHarvester(h.observatory, fetcher, c.ignoreURLsRegEx, c.removeParamsFromURLsRegEx, c.domains, newURLNormalizer(c.settings.Harvest.Normalization), newCanonicalizerList(c.settings.Harvest.Canonicalizers), c.settings.Harvest.CanonicalURLs, c.settings.Harvest.ExtractMetadata, newContentStore(c), archives, c.settings.Harvest.FollowHTMLRedirects, robots, c.resolutions)
+	c.jobs = newHarvestJobQueue(h, c)
+}
+
+// OpenStore (re)opens the datastore described by Configuration().Storage
+func (c *Configuration) OpenStore(h *ServiceHandler, parent opentracing.Span) {
+	if c.store != nil {
+		c.store.Close()
+	}
+	c.store = persistence.NewDatastore(h.observatory, &c.settings.Storage, parent)
+	if c.sweeping {
+		c.store.StartRetentionSweeper(c.settings.Name)
+	}
+}
+
+// StartRetentionSweepers starts the retention sweeper of every settings bundle's store, it's left to
+// the server so commands like rotate-storage-keys don't delete records while they run
+func (h *ServiceHandler) StartRetentionSweepers() {
+	for _, config := range h.configs {
+		config.sweeping = true
+		config.store.StartRetentionSweeper(config.settings.Name)
+	}
 }
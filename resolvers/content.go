@@ -0,0 +1,93 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+	"github.com/lectio/lectiod/readability"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// contentStore extracts the readable article text of harvested pages and keeps it in the settings bundle's
+// datastore, keyed by the normalized URL of the resource
+type contentStore struct {
+	config *Configuration
+}
+
+// newContentStore returns nil unless the settings bundle sets harvest.extractContent
+func newContentStore(config *Configuration) *contentStore {
+	if !config.settings.Harvest.ExtractContent {
+		return nil
+	}
+	result := new(contentStore)
+	result.config = config
+	return result
+}
+
+// save extracts the article of the page in fetched and stores it as the content of harvested
+func (c *contentStore) save(harvested *models.HarvestedResource, fetched *fetch.Result, span opentracing.Span) {
+	if fetched == nil {
+		// resolved from the cache, the content was saved when the URL was resolved
+		span.LogFields(log.String("content", "cached"))
+		return
+	}
+	if metaRefreshURL(fetched) != nil || !isHTML(fetched) {
+		span.LogFields(log.String("content", "skipped"), log.String("contentType", fetched.ContentType))
+		return
+	}
+
+	article, err := readability.Extract(fetched.Body, fetched.URL)
+	if err != nil {
+		span.LogFields(log.String("content", "none"), log.Error(err))
+		return
+	}
+	record := &persistence.ContentRecord{URL: harvested.Urls.Normalized, Text: article.Text, HTML: article.HTML, ExtractedAt: time.Now()}
+	err = c.config.Store().Contents().SaveContent(record)
+	if err != nil {
+		error := fmt.Errorf("Unable to save content of %s: %v", harvested.Urls.Normalized, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return
+	}
+	span.LogFields(log.Int("words", readability.WordCount(article.Text)))
+}
+
+type harvestedResource struct {
+	handler *ServiceHandler
+}
+
+// Content loads the article text saved when obj was harvested from the datastore of the settings bundle the
+// request was authorized for, it's null if the bundle doesn't extract content or the page had no article
+func (r *harvestedResource) Content(ctx context.Context, obj *models.HarvestedResource) (*models.HarvestedContent, error) {
+	bundle := requestScopeFromContext(ctx).settingsBundle()
+	if bundle == "" {
+		return nil, nil
+	}
+	span, _ := r.handler.observatory.StartTraceFromContext(ctx, "HarvestedResource_content")
+	defer span.Finish()
+
+	conf, err := r.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	record, err := conf.Store().Contents().LoadContent(obj.Urls.Normalized)
+	if err != nil {
+		span.LogFields(log.String("content", "missing"), log.Error(err))
+		return nil, nil
+	}
+
+	words := readability.WordCount(record.Text)
+	return &models.HarvestedContent{
+		Text:               models.ExtraLargeText(record.Text),
+		HTML:               models.ExtraLargeText(record.HTML),
+		WordCount:          models.WordsCount(words),
+		ReadingTimeMinutes: models.ReadingTimeMinutes(readability.ReadingTimeMinutes(words, readability.DefaultWordsPerMinute)),
+		ExtractedAt:        models.NewDateTime(record.ExtractedAt),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestHarvestedContent() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		paragraph := "<p>This paragraph of the article is long enough to be scored, with a comma, like a real one.</p>"
+		fmt.Fprintf(w, "<html><body><nav>Navigation</nav><article>%s</article></body></html>", strings.Repeat(paragraph, 3))
+	}))
+	defer server.Close()
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		settings.Harvest.ExtractContent = true
+	})
+	suite.handler.configs["TEST"] = config
+	defer delete(suite.handler.configs, "TEST")
+
+	result := config.contentHarvester.harvestText(models.LargeText(server.URL+"/story"), suite.span)
+	if !suite.Len(result.Harvested, 1, "The story should be harvested") {
+		return
+	}
+	harvested := result.Harvested[0]
+	resolver := &harvestedResource{handler: suite.handler}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		bundle models.SettingsBundleName
+		found  bool
+	}{
+		{name: "no request scope", ctx: context.Background()},
+		{name: "unauthorized request", ctx: WithRequestScope(context.Background())},
+		{name: "authorized request", ctx: WithRequestScope(context.Background()), bundle: "TEST", found: true},
+	}
+	for _, test := range tests {
+		requestScopeFromContext(test.ctx).setSettingsBundle(test.bundle)
+		content, err := resolver.Content(test.ctx, harvested)
+		suite.Nil(err, test.name)
+		if !test.found {
+			suite.Nil(content, test.name)
+			continue
+		}
+		if suite.NotNil(content, test.name) {
+			suite.Contains(string(content.Text), "This paragraph of the article", test.name)
+			suite.NotContains(string(content.Text), "Navigation", test.name)
+			suite.Equal(models.WordsCount(54), content.WordCount, test.name)
+			suite.Equal(models.ReadingTimeMinutes(1), content.ReadingTimeMinutes, test.name)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+package resolvers
+
+import (
+	"mime"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lectio/lectiod/models"
+	"golang.org/x/net/html"
+	"mvdan.cc/xurls"
+)
+
+// discoveredURL is a URL found in a document, offset and length are the bytes of the whole link, anchorText is the
+// text it was linked from if there was any and snippet is the text around it
+type discoveredURL struct {
+	url        string
+	anchorText string
+	offset     int
+	length     int
+	snippet    string
+}
+
+// discovery returns where d was found, as reported with the resources harvested from it
+func (d *discoveredURL) discovery() *models.ResourceDiscovery {
+	result := new(models.ResourceDiscovery)
+	result.Offset = models.TextOffset(d.offset)
+	result.Length = models.TextLength(d.length)
+	if d.anchorText != "" {
+		anchorText := models.SmallText(d.anchorText)
+		result.AnchorText = &anchorText
+	}
+	result.ContextSnippet = models.MediumText(d.snippet)
+	return result
+}
+
+// discoverDocumentURLs finds the URLs in document, in the order they appear
+func discoverDocumentURLs(document string, format models.DocumentFormat) []*discoveredURL {
+	var result []*discoveredURL
+	switch format {
+	case models.DocumentFormatHtml:
+		result = discoverHTMLURLs(document)
+	case models.DocumentFormatMarkdown:
+		result = discoverMarkdownURLs(document)
+	default:
+		result = discoverTextURLs(document)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].offset < result[j].offset })
+	for _, discovered := range result {
+		// snippets of HTML are taken from its text rather than its markup
+		if discovered.snippet == "" && format != models.DocumentFormatHtml {
+			discovered.snippet = contextSnippet(document, discovered.offset, discovered.offset+discovered.length)
+		}
+	}
+	return result
+}
+
+// contextSnippetBytes is about how much text contextSnippet keeps on either side of a link
+const contextSnippetBytes = 80
+
+var paragraphBreak = regexp.MustCompile(`\n[ \t\r]*\n`)
+
+// contextSnippet returns the words around text[start:end], within its paragraph, with whitespace collapsed and
+// an ellipsis where the paragraph was cut
+func contextSnippet(text string, start, end int) string {
+	from, to := start-contextSnippetBytes, end+contextSnippetBytes
+	if from < 0 {
+		from = 0
+	}
+	if to > len(text) {
+		to = len(text)
+	}
+
+	cutFrom, cutTo := false, false
+	if breaks := paragraphBreak.FindAllStringIndex(text[from:start], -1); len(breaks) > 0 {
+		from += breaks[len(breaks)-1][1]
+	} else if from > 0 {
+		cutFrom = true
+		for from < start && !utf8.RuneStart(text[from]) {
+			from++
+		}
+		// don't start in the middle of a word
+		if space := strings.IndexAny(text[from:start], " \t\r\n"); space >= 0 && !isSpace(text[from-1]) {
+			from += space
+		}
+	}
+	if paragraph := paragraphBreak.FindStringIndex(text[end:to]); paragraph != nil {
+		to = end + paragraph[0]
+	} else if to < len(text) {
+		cutTo = true
+		for to > end && !utf8.RuneStart(text[to]) {
+			to--
+		}
+		if space := strings.LastIndexAny(text[end:to], " \t\r\n"); space >= 0 && !isSpace(text[to]) {
+			to = end + space
+		}
+	}
+
+	result := anchorText(text[from:to])
+	if cutFrom {
+		result = "…" + result
+	}
+	if cutTo {
+		result += "…"
+	}
+	return result
+}
+
+// isSpace tells whether b is whitespace that separates words
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// discoverTextURLs finds the URLs in plain text, like discoverURLs
+func discoverTextURLs(text string) []*discoveredURL {
+	var result []*discoveredURL
+	for _, match := range xurls.Relaxed.FindAllStringIndex(text, -1) {
+		result = append(result, &discoveredURL{url: text[match[0]:match[1]], offset: match[0], length: match[1] - match[0]})
+	}
+	return result
+}
+
+// anchorText collapses the whitespace of the text a link was made from
+func anchorText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// linkTarget returns target resolved against base (which may be nil) if that's an http or https URL, links to
+// fragments of the document itself are skipped
+func linkTarget(base *url.URL, target string) (string, bool) {
+	target = strings.TrimSpace(target)
+	u, err := url.Parse(target)
+	if err != nil || target == "" || strings.HasPrefix(target, "#") {
+		return "", false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", false
+	}
+	return u.String(), true
+}
+
+// htmlTextlessElements hold no text whose URLs should be discovered, links inside them are still discovered
+var htmlTextlessElements = map[string]bool{"script": true, "style": true, "textarea": true, "code": true, "pre": true, "title": true}
+
+// htmlHiddenElements hold text that isn't read so it's left out of context snippets
+var htmlHiddenElements = map[string]bool{"script": true, "style": true, "template": true}
+
+// htmlBlockElements separate paragraphs of the text context snippets are taken from
+var htmlBlockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "hr": true, "li": true, "ul": true, "ol": true, "dl": true, "dt": true, "dd": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "blockquote": true, "pre": true,
+	"table": true, "tr": true, "td": true, "th": true, "section": true, "article": true, "header": true, "footer": true,
+	"nav": true, "aside": true, "main": true, "figure": true, "figcaption": true, "title": true, "body": true,
+}
+
+// discoverHTMLURLs finds the targets of <a> and <area> elements, resolved against the document's <base> if it
+// has one, and the URLs in text outside links. Offsets are of the elements, or of URLs in the markup of the text,
+// and context snippets are taken from the document's text without its markup.
+func discoverHTMLURLs(document string) []*discoveredURL {
+	var result []*discoveredURL
+	var base *url.URL
+	var link *discoveredURL
+	var linkText strings.Builder
+	var text strings.Builder
+	textSpans := make(map[*discoveredURL][2]int)
+	textless, hidden := 0, 0
+	offset := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := string(tokenizer.Raw())
+		start := offset
+		offset += len(raw)
+
+		switch tokenType {
+		case html.TextToken:
+			decoded := html.UnescapeString(raw)
+			textStart := text.Len()
+			if hidden == 0 {
+				text.WriteString(decoded)
+			}
+			if link != nil {
+				linkText.WriteString(decoded)
+			} else if textless == 0 {
+				searched := 0
+				for _, found := range discoverTextURLs(decoded) {
+					textSpans[found] = [2]int{textStart + found.offset, textStart + found.offset + found.length}
+					// the URL is located in the markup as it is or with its characters escaped, if neither is
+					// found its offset in the decoded text is used
+					found.offset += start
+					for _, markup := range []string{found.url, html.EscapeString(found.url)} {
+						if index := strings.Index(raw[searched:], markup); index >= 0 {
+							found.offset, found.length = start+searched+index, len(markup)
+							searched += index + len(markup)
+							break
+						}
+					}
+					result = append(result, found)
+				}
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = string(value)
+			}
+			if htmlBlockElements[string(name)] {
+				text.WriteString("\n\n")
+			}
+			switch string(name) {
+			case "base":
+				if u, err := url.Parse(strings.TrimSpace(attrs["href"])); err == nil && base == nil && u.IsAbs() {
+					base = u
+				}
+			case "a":
+				if target, ok := linkTarget(base, attrs["href"]); ok && tokenType == html.StartTagToken {
+					link = &discoveredURL{url: target, offset: start}
+					textSpans[link] = [2]int{text.Len(), text.Len()}
+					linkText.Reset()
+				}
+			case "area":
+				if target, ok := linkTarget(base, attrs["href"]); ok {
+					area := &discoveredURL{url: target, anchorText: anchorText(attrs["alt"]), offset: start, length: len(raw)}
+					textSpans[area] = [2]int{text.Len(), text.Len() + len(attrs["alt"])}
+					text.WriteString(attrs["alt"])
+					result = append(result, area)
+				}
+			case "img":
+				if link != nil {
+					linkText.WriteString(" " + attrs["alt"] + " ")
+					text.WriteString(" " + attrs["alt"] + " ")
+				}
+			default:
+				if htmlTextlessElements[string(name)] && tokenType == html.StartTagToken {
+					textless++
+				}
+				if htmlHiddenElements[string(name)] && tokenType == html.StartTagToken {
+					hidden++
+				}
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if htmlBlockElements[string(name)] {
+				text.WriteString("\n\n")
+			}
+			if string(name) == "a" && link != nil {
+				link.anchorText = anchorText(linkText.String())
+				link.length = offset - link.offset
+				textSpans[link] = [2]int{textSpans[link][0], text.Len()}
+				result = append(result, link)
+				link = nil
+			}
+			if htmlTextlessElements[string(name)] && textless > 0 {
+				textless--
+			}
+			if htmlHiddenElements[string(name)] && hidden > 0 {
+				hidden--
+			}
+		}
+	}
+	if link != nil {
+		link.anchorText = anchorText(linkText.String())
+		link.length = offset - link.offset
+		textSpans[link] = [2]int{textSpans[link][0], text.Len()}
+		result = append(result, link)
+	}
+	readable := text.String()
+	for discovered, span := range textSpans {
+		discovered.snippet = contextSnippet(readable, span[0], span[1])
+	}
+	return result
+}
+
+var (
+	markdownFence          = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	markdownListItem       = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d{1,9}[.)])(?:[ \t]|$)`)
+	markdownImage          = regexp.MustCompile(`!\[([^\]]*)\]\((?:[^()]|\([^()]*\))*\)`)
+	markdownInlineLink     = regexp.MustCompile(`\[([^\]]*)\]\(\s*(<[^>]*>|(?:[^\s()]|\([^\s()]*\))+)(?:\s+(?:"[^"]*"|'[^']*'|\([^)]*\)))?\s*\)`)
+	markdownReferenceLink  = regexp.MustCompile(`\[([^\]]+)\]\[([^\]]*)\]`)
+	markdownLinkDefinition = regexp.MustCompile(`(?m)^ {0,3}\[([^\]]+)\]:[ \t]*(<[^>]*>|\S+)`)
+	markdownAutolink       = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+)
+
+// discoverMarkdownURLs finds the targets of inline, reference and autolinks and the URLs in the rest of the
+// text, ignoring fenced and indented code blocks, code spans and images
+func discoverMarkdownURLs(document string) []*discoveredURL {
+	masked := []byte(maskMarkdownCode(document))
+	mask := func(start, end int) {
+		for i := start; i < end; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+	for _, match := range markdownImage.FindAllIndex(masked, -1) {
+		mask(match[0], match[1])
+	}
+
+	var result []*discoveredURL
+	add := func(target string, text string, start, end int) {
+		if target, ok := linkTarget(nil, strings.Trim(target, "<>")); ok {
+			// a linked image is named by its alt text
+			text = markdownImage.ReplaceAllString(text, "$1")
+			result = append(result, &discoveredURL{url: target, anchorText: anchorText(text), offset: start, length: end - start})
+		}
+	}
+
+	definitions := make(map[string]string)
+	used := make(map[string]bool)
+	label := func(text string) string {
+		return strings.ToLower(anchorText(text))
+	}
+	definitionMatches := markdownLinkDefinition.FindAllSubmatchIndex(masked, -1)
+	for _, match := range definitionMatches {
+		if _, seen := definitions[label(document[match[2]:match[3]])]; !seen {
+			definitions[label(document[match[2]:match[3]])] = document[match[4]:match[5]]
+		}
+	}
+	for _, match := range markdownInlineLink.FindAllSubmatchIndex(masked, -1) {
+		add(document[match[4]:match[5]], document[match[2]:match[3]], match[0], match[1])
+		mask(match[0], match[1])
+	}
+	for _, match := range markdownReferenceLink.FindAllSubmatchIndex(masked, -1) {
+		text := document[match[2]:match[3]]
+		reference := label(document[match[4]:match[5]])
+		if reference == "" {
+			reference = label(text)
+		}
+		if target, defined := definitions[reference]; defined {
+			add(target, text, match[0], match[1])
+			used[reference] = true
+		}
+		mask(match[0], match[1])
+	}
+	for _, match := range definitionMatches {
+		// definitions no link refers to are reported where they are
+		if reference := label(document[match[2]:match[3]]); !used[reference] {
+			add(document[match[4]:match[5]], "", match[0], match[1])
+			used[reference] = true
+		}
+		mask(match[0], match[1])
+	}
+	for _, match := range markdownAutolink.FindAllSubmatchIndex(masked, -1) {
+		add(document[match[2]:match[3]], "", match[0], match[1])
+		mask(match[0], match[1])
+	}
+	return append(result, discoverTextURLs(string(masked))...)
+}
+
+// maskMarkdownCode returns document with its code blocks and code spans replaced by spaces, so the offsets of
+// everything else are kept
+func maskMarkdownCode(document string) string {
+	lines := strings.SplitAfter(document, "\n")
+	var result strings.Builder
+	blank := func(line string) string {
+		return strings.Repeat(" ", len(strings.TrimRight(line, "\r\n"))) + line[len(strings.TrimRight(line, "\r\n")):]
+	}
+
+	fence := ""
+	previousBlank, inIndentedCode, inList := true, false, false
+	for _, line := range lines {
+		content := strings.TrimRight(line, "\r\n")
+		isBlank := strings.TrimSpace(content) == ""
+		switch {
+		case fence != "":
+			if match := markdownFence.FindStringSubmatch(content); match != nil && match[1][0] == fence[0] && len(match[1]) >= len(fence) && strings.TrimSpace(content[len(match[0]):]) == "" {
+				fence = ""
+			}
+			result.WriteString(blank(line))
+		case markdownFence.MatchString(content):
+			fence = markdownFence.FindStringSubmatch(content)[1]
+			result.WriteString(blank(line))
+		case !isBlank && (strings.HasPrefix(content, "    ") || strings.HasPrefix(content, "\t")) && (inIndentedCode || previousBlank) && !inList:
+			inIndentedCode = true
+			result.WriteString(blank(line))
+		default:
+			if !isBlank {
+				inIndentedCode = false
+				if markdownListItem.MatchString(content) {
+					inList = true
+				} else if previousBlank && !strings.HasPrefix(content, " ") && !strings.HasPrefix(content, "\t") {
+					inList = false
+				}
+			}
+			result.WriteString(line)
+		}
+		previousBlank = isBlank
+	}
+	return maskMarkdownCodeSpans(result.String())
+}
+
+var markdownBackticks = regexp.MustCompile("`+")
+
+// maskMarkdownCodeSpans replaces code spans, from a run of backticks to the next run of the same length, with spaces
+func maskMarkdownCodeSpans(text string) string {
+	masked := []byte(text)
+	runs := markdownBackticks.FindAllStringIndex(text, -1)
+	for i := 0; i < len(runs); i++ {
+		opening := runs[i][1] - runs[i][0]
+		for j := i + 1; j < len(runs); j++ {
+			if runs[j][1]-runs[j][0] != opening {
+				continue
+			}
+			for k := runs[i][0]; k < runs[j][1]; k++ {
+				if masked[k] != '\n' {
+					masked[k] = ' '
+				}
+			}
+			i = j
+			break
+		}
+	}
+	return string(masked)
+}
+
+// documentFormat guesses the format of an uploaded file from its content type or, failing that, its name
+func documentFormat(file *models.File) models.DocumentFormat {
+	mediaType, _, _ := mime.ParseMediaType(file.ContentType)
+	switch mediaType {
+	case "text/html", "application/xhtml+xml":
+		return models.DocumentFormatHtml
+	case "text/markdown", "text/x-markdown":
+		return models.DocumentFormatMarkdown
+	}
+	switch strings.ToLower(path.Ext(file.Name)) {
+	case ".html", ".htm", ".xhtml":
+		return models.DocumentFormatHtml
+	case ".md", ".markdown", ".mdown", ".mkd":
+		return models.DocumentFormatMarkdown
+	}
+	return models.DocumentFormatText
+}
@@ -0,0 +1,163 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestDiscoverDocumentURLs() {
+	type discovered struct {
+		url        string
+		anchorText string
+		link       string
+	}
+	tests := []struct {
+		name       string
+		format     models.DocumentFormat
+		document   string
+		discovered []discovered
+	}{
+		{name: "text", format: models.DocumentFormatText, document: "Read https://example.com/a and example.org/b.", discovered: []discovered{
+			{url: "https://example.com/a", link: "https://example.com/a"},
+			{url: "example.org/b", link: "example.org/b"},
+		}},
+		{name: "html links", format: models.DocumentFormatHtml, document: `<p>See <a href="https://example.com/a">the <b>first</b> page</a> and https://example.com/text.</p>`, discovered: []discovered{
+			{url: "https://example.com/a", anchorText: "the first page", link: `<a href="https://example.com/a">the <b>first</b> page</a>`},
+			{url: "https://example.com/text", link: "https://example.com/text"},
+		}},
+		{name: "html base and area", format: models.DocumentFormatHtml, document: `<base href="https://example.com/docs/"><a href="guide">Guide</a><a href="#top">Top</a><area href="/map" alt="The map">`, discovered: []discovered{
+			{url: "https://example.com/docs/guide", anchorText: "Guide", link: `<a href="guide">Guide</a>`},
+			{url: "https://example.com/map", anchorText: "The map", link: `<area href="/map" alt="The map">`},
+		}},
+		{name: "html ignored text", format: models.DocumentFormatHtml, document: `<script>load("https://example.com/script.js")</script><pre>https://example.com/pre</pre><code>https://example.com/code</code><a href="javascript:alert(1)">script</a>`},
+		{name: "html escaped text URL", format: models.DocumentFormatHtml, document: `<p>https://example.com/?a=1&amp;b=2</p>`, discovered: []discovered{
+			{url: "https://example.com/?a=1&b=2", link: "https://example.com/?a=1&amp;b=2"},
+		}},
+		{name: "markdown links", format: models.DocumentFormatMarkdown, document: "See [the docs](https://example.com/docs \"Docs\"), [the guide][guide] and <https://example.com/auto>.\n\n[guide]: https://example.com/guide\n[unused]: https://example.com/unused\n", discovered: []discovered{
+			{url: "https://example.com/docs", anchorText: "the docs", link: `[the docs](https://example.com/docs "Docs")`},
+			{url: "https://example.com/guide", anchorText: "the guide", link: "[the guide][guide]"},
+			{url: "https://example.com/auto", link: "<https://example.com/auto>"},
+			{url: "https://example.com/unused", link: "[unused]: https://example.com/unused"},
+		}},
+		{name: "markdown linked image", format: models.DocumentFormatMarkdown, document: "[![Logo](https://example.com/logo.png)](https://example.com/home) ![Photo](https://example.com/photo.jpg)", discovered: []discovered{
+			{url: "https://example.com/home", anchorText: "Logo", link: "[![Logo](https://example.com/logo.png)](https://example.com/home)"},
+		}},
+		{name: "markdown code", format: models.DocumentFormatMarkdown, document: "Text `https://example.com/span` text.\n\n```\nhttps://example.com/fenced\n```\n\n    https://example.com/indented\n\nhttps://example.com/after", discovered: []discovered{
+			{url: "https://example.com/after", link: "https://example.com/after"},
+		}},
+		{name: "markdown relative link", format: models.DocumentFormatMarkdown, document: "[Relative](/docs) and [fragment](#top)"},
+	}
+	for _, test := range tests {
+		result := discoverDocumentURLs(test.document, test.format)
+		if !suite.Len(result, len(test.discovered), test.name) {
+			continue
+		}
+		for i, expected := range test.discovered {
+			suite.Equal(expected.url, result[i].url, test.name)
+			suite.Equal(expected.anchorText, result[i].anchorText, test.name)
+			suite.Equal(expected.link, test.document[result[i].offset:result[i].offset+result[i].length], test.name)
+		}
+	}
+}
+
+func (suite *ResolversSuite) TestDiscoveryContextSnippet() {
+	long := strings.Repeat("word ", 30)
+	tests := []struct {
+		name     string
+		format   models.DocumentFormat
+		document string
+		snippet  string
+	}{
+		{name: "whole paragraph", format: models.DocumentFormatText, document: "First paragraph.\n\nRead  https://example.com/a\tnow.\n\nLast paragraph.", snippet: "Read https://example.com/a now."},
+		{name: "cut paragraph", format: models.DocumentFormatText, document: long + "https://example.com/a " + long, snippet: "…" + strings.TrimSpace(strings.Repeat("word ", 16)) + " https://example.com/a " + strings.TrimSpace(strings.Repeat("word ", 16)) + "…"},
+		{name: "html text", format: models.DocumentFormatHtml, document: `<div>Menu</div><p>Read <a href="https://example.com/a">the <i>page</i></a> now.</p><script>var x = 1;</script>`, snippet: "Read the page now."},
+		{name: "markdown", format: models.DocumentFormatMarkdown, document: "# Title\n\nRead [the page](https://example.com/a) now.", snippet: "Read [the page](https://example.com/a) now."},
+	}
+	for _, test := range tests {
+		result := discoverDocumentURLs(test.document, test.format)
+		if suite.Len(result, 1, test.name) {
+			suite.Equal(test.snippet, result[0].snippet, test.name)
+			suite.Equal(models.MediumText(test.snippet), result[0].discovery().ContextSnippet, test.name)
+		}
+	}
+}
+
+func (suite *ResolversSuite) TestHarvestDocumentDiscovery() {
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		denied := models.DomainName("ads.example.com")
+		settings.Harvest.DenyDomains = []*models.DomainName{&denied}
+	})
+	document := `<p><a href="https://ads.example.com/banner">An ad</a> and <a href="http://[::1">a broken link</a></p>`
+	result := config.contentHarvester.harvestDocument(document, models.DocumentFormatHtml, suite.span)
+
+	if suite.Len(result.Ignored, 1, "The denied domain should be ignored") {
+		discovery := result.Ignored[0].Discovery
+		if suite.NotNil(discovery, "The ignored resource should report where it was found") {
+			suite.Equal(models.TextOffset(3), discovery.Offset)
+			suite.Equal(models.TextLength(len(`<a href="https://ads.example.com/banner">An ad</a>`)), discovery.Length)
+			if suite.NotNil(discovery.AnchorText) {
+				suite.Equal(models.SmallText("An ad"), *discovery.AnchorText)
+			}
+		}
+	}
+	suite.Len(result.Invalid, 0, "Links to invalid URLs aren't discovered")
+	suite.Len(result.Harvested, 0)
+}
+
+func (suite *ResolversSuite) TestDocumentFormat() {
+	tests := []struct {
+		name        string
+		contentType string
+		format      models.DocumentFormat
+	}{
+		{name: "page.html", format: models.DocumentFormatHtml},
+		{name: "PAGE.HTM", format: models.DocumentFormatHtml},
+		{name: "notes.md", format: models.DocumentFormatMarkdown},
+		{name: "notes.txt", format: models.DocumentFormatText},
+		{name: "notes", format: models.DocumentFormatText},
+		{name: "notes.txt", contentType: "text/html; charset=utf-8", format: models.DocumentFormatHtml},
+		{name: "page.html", contentType: "text/markdown", format: models.DocumentFormatMarkdown},
+		{name: "page.html", contentType: "application/octet-stream", format: models.DocumentFormatHtml},
+	}
+	for _, test := range tests {
+		suite.Equal(test.format, documentFormat(&models.File{Name: test.name, ContentType: test.contentType}), "%s (%s)", test.name, test.contentType)
+	}
+}
+
+func (suite *ResolversSuite) TestUploadedFile() {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="0"; filename="notes.md"`)
+	header.Set("Content-Type", "text/markdown")
+	part, err := writer.CreatePart(header)
+	suite.Nil(err, "Unable to create the file's part")
+	part.Write([]byte("[Docs](https://example.com/docs)"))
+	writer.Close()
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1024)
+	if !suite.Nil(err, "Unable to read the multipart form") {
+		return
+	}
+	defer form.RemoveAll()
+
+	var file models.File
+	suite.Nil(file.UnmarshalGQL("0"), "A File variable is the name of its part")
+	suite.NotNil(new(models.File).UnmarshalGQL(map[string]interface{}{"name": "notes.md", "content": "W0RvY3Nd"}), "Files can't be given in JSON")
+
+	ctx := WithUploadedFiles(context.Background(), map[string]*multipart.FileHeader{"0": form.File["0"][0]})
+	uploaded, err := uploadedFile(ctx, file)
+	if suite.Nil(err, "The uploaded file should be found") {
+		suite.Equal("notes.md", uploaded.Name)
+		suite.Equal("text/markdown", uploaded.ContentType)
+		suite.Equal("[Docs](https://example.com/docs)", string(uploaded.Content))
+	}
+	_, err = uploadedFile(ctx, models.File{Part: "1"})
+	suite.NotNil(err, "Parts which weren't uploaded shouldn't be found")
+	_, err = uploadedFile(context.Background(), file)
+	suite.NotNil(err, "Requests without uploads have no files")
+}
@@ -0,0 +1,91 @@
+package resolvers
+
+import (
+	"net/url"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestNormalizeDomain() {
+	tests := []struct {
+		value  models.DomainName
+		domain string
+		valid  bool
+	}{
+		{value: "Example.COM", domain: "example.com", valid: true},
+		{value: "https://www.example.com:8080/path", domain: "www.example.com", valid: true},
+		{value: "example.com.", domain: "example.com", valid: true},
+		{value: "lectio.github.io", domain: "lectio.github.io", valid: true},
+		{value: "com"},
+		{value: "co.uk"},
+		{value: "  "},
+	}
+	for _, test := range tests {
+		domain, err := normalizeDomain(test.value)
+		if !test.valid {
+			suite.NotNil(err, "'%s' should be rejected", test.value)
+			continue
+		}
+		suite.Nil(err, string(test.value))
+		suite.Equal(test.domain, domain, string(test.value))
+	}
+}
+
+func (suite *ResolversSuite) TestDomainRules() {
+	settings := createDefaultSettings("TEST")
+	domainNames := func(values ...string) []*models.DomainName {
+		var result []*models.DomainName
+		for _, value := range values {
+			name := models.DomainName(value)
+			result = append(result, &name)
+		}
+		return result
+	}
+	dontFollow := false
+	settings.Harvest.AllowDomains = domainNames("example.com", "example.org")
+	settings.Harvest.DenyDomains = domainNames("ads.example.com", "com")
+	settings.Harvest.DomainRules = []*models.DomainHarvestRules{
+		{Domain: "example.com"},
+		{Domain: "docs.example.com", FollowHTMLRedirects: &dontFollow},
+	}
+	rules := new(domainRules)
+	rules.AddSettings(settings)
+	suite.Len(settings.Errors, 1, "The public suffix should be reported")
+
+	tests := []struct {
+		url      string
+		code     models.ReasonCode
+		rule     string
+		override string
+		follow   bool
+	}{
+		{url: "https://example.com/page", override: "example.com", follow: true},
+		{url: "https://WWW.Example.com./page", override: "example.com", follow: true},
+		{url: "https://ads.example.com/banner", code: models.ReasonCodeDomainDenied, rule: "ads.example.com", override: "example.com", follow: true},
+		{url: "https://tracker.ads.example.com/pixel", code: models.ReasonCodeDomainDenied, rule: "ads.example.com", override: "example.com", follow: true},
+		{url: "https://docs.example.com/guide", override: "docs.example.com"},
+		{url: "https://example.org/", follow: true},
+		{url: "https://notexample.com/", code: models.ReasonCodeDomainNotAllowed, follow: true},
+		{url: "https://other.net/", code: models.ReasonCodeDomainNotAllowed, follow: true},
+	}
+	for _, test := range tests {
+		u, err := url.Parse(test.url)
+		suite.Nil(err, test.url)
+
+		reason := rules.ignoreReason(u)
+		if test.code == "" {
+			suite.Nil(reason, test.url)
+		} else if suite.NotNil(reason, test.url) {
+			suite.Equal(test.code, reason.code, test.url)
+			suite.Equal(test.rule, reason.rule, test.url)
+		}
+
+		override := rules.override(u)
+		if test.override == "" {
+			suite.Nil(override, test.url)
+		} else if suite.NotNil(override, test.url) {
+			suite.Equal(test.override, override.domain, test.url)
+		}
+		suite.Equal(test.follow, rules.followHTMLRedirects(u, true), test.url)
+	}
+}
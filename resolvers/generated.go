@@ -29,25 +29,45 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	HarvestedResource() HarvestedResourceResolver
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 }
 
 type DirectiveRoot struct {
 }
+type HarvestedResourceResolver interface {
+	Content(ctx context.Context, obj *models.HarvestedResource) (*models.HarvestedContent, error)
+}
 type MutationResolver interface {
 	EstablishSimulatedSession(ctx context.Context, authorization models.PrivilegedAuthorizationInput, settings models.SettingsBundleName) (models.AuthenticatedSession, error)
 	RefreshSession(ctx context.Context, privilegedAuthz models.PrivilegedAuthorizationInput, authorization models.AuthorizationInput) (models.AuthenticatedSession, error)
 	DestroySession(ctx context.Context, privilegedAuthz models.PrivilegedAuthorizationInput, authorization models.AuthorizationInput) (bool, error)
 	DestroyAllSessions(ctx context.Context, authorization models.PrivilegedAuthorizationInput) (models.AuthenticatedSessionsCount, error)
-	SaveURLsinText(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, text models.LargeText) (*models.HarvestedResources, error)
+	SaveURLsinText(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, text models.LargeText, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error)
+	SaveURLsinTexts(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, texts []models.LargeText) ([]*models.HarvestedResources, error)
+	SubmitHarvestJob(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText, destination *models.StorageDestinationInput) (*models.HarvestJob, error)
+	BackupStorage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageBackup, error)
+	RestoreStorage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName, file models.FilePathAndName) (*models.StorageBackup, error)
 }
 type QueryResolver interface {
 	AsymmetricCryptoPublicKey(ctx context.Context, claimType models.AuthorizationClaimType, keyId models.AsymmetricCryptoPublicKeyName) (models.AuthorizationClaimCryptoKey, error)
 	AsymmetricCryptoPublicKeys(ctx context.Context, claimType *models.AuthorizationClaimType) ([]*models.AuthorizationClaimCryptoKey, error)
 	SettingsBundles(ctx context.Context, authorization models.PrivilegedAuthorizationInput) ([]*models.SettingsBundle, error)
 	SettingsBundle(ctx context.Context, authorization models.PrivilegedAuthorizationInput, name models.SettingsBundleName) (*models.SettingsBundle, error)
-	UrlsInText(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText) (*models.HarvestedResources, error)
+	UrlsInText(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error)
+	UrlsInTexts(ctx context.Context, authorization models.AuthorizationInput, texts []models.LargeText) ([]*models.HarvestedResources, error)
+	UrlsInDocument(ctx context.Context, authorization models.AuthorizationInput, document models.Document, format models.DocumentFormat, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error)
+	UrlsInFile(ctx context.Context, authorization models.AuthorizationInput, file models.File, format *models.DocumentFormat, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error)
+	StorageUsage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageUsage, error)
+	StorageRetentionReport(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageRetentionReport, error)
+	HarvestJob(ctx context.Context, authorization models.AuthorizationInput, id models.HarvestJobID) (*models.HarvestJob, error)
+	HarvestRuleStats(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) ([]*models.HarvestRuleStats, error)
+	TestHarvestRules(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName, urls []models.URLText) ([]*models.HarvestRulesTest, error)
+}
+type SubscriptionResolver interface {
+	HarvestProgress(ctx context.Context, authorization models.AuthorizationInput, jobId models.HarvestJobID) (<-chan models.HarvestProgressEvent, error)
 }
 
 type executableSchema struct {
@@ -92,59 +112,47 @@ func (e *executableSchema) Mutation(ctx context.Context, op *ast.OperationDefini
 }
 
 func (e *executableSchema) Subscription(ctx context.Context, op *ast.OperationDefinition) func() *graphql.Response {
-	return graphql.OneShot(graphql.ErrorResponse(ctx, "subscriptions are not supported"))
-}
+	ec := executionContext{graphql.GetRequestContext(ctx), e}
 
-type executionContext struct {
-	*graphql.RequestContext
-	*executableSchema
-}
+	next := ec._Subscription(ctx, op.SelectionSet)
+	if ec.Errors != nil {
+		return graphql.OneShot(&graphql.Response{Data: []byte("null"), Errors: ec.Errors})
+	}
 
-var fileStorageSettingsImplementors = []string{"FileStorageSettings"}
+	var buf bytes.Buffer
+	return func() *graphql.Response {
+		buf := ec.RequestMiddleware(ctx, func(ctx context.Context) []byte {
+			buf.Reset()
+			data := next()
 
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _FileStorageSettings(ctx context.Context, sel ast.SelectionSet, obj *models.FileStorageSettings) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, fileStorageSettingsImplementors)
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+			return buf.Bytes()
+		})
 
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
+		if buf == nil {
+			return nil
+		}
 
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("FileStorageSettings")
-		case "basePath":
-			out.Values[i] = ec._FileStorageSettings_basePath(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
+		return &graphql.Response{
+			Data:   buf,
+			Errors: ec.Errors,
 		}
 	}
-
-	return out
 }
 
-func (ec *executionContext) _FileStorageSettings_basePath(ctx context.Context, field graphql.CollectedField, obj *models.FileStorageSettings) graphql.Marshaler {
-	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "FileStorageSettings"
-	rctx.Args = nil
-	rctx.Field = field
-	rctx.PushField(field.Alias)
-	defer rctx.Pop()
-	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.BasePath, nil
-	})
-	if resTmp == nil {
-		return graphql.Null
-	}
-	res := resTmp.(models.DirectoryPath)
-	return res
+type executionContext struct {
+	*graphql.RequestContext
+	*executableSchema
 }
 
-var harvestDirectivesSettingsImplementors = []string{"HarvestDirectivesSettings"}
+var archiveRecordImplementors = []string{"ArchiveRecord"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _HarvestDirectivesSettings(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, harvestDirectivesSettingsImplementors)
+func (ec *executionContext) _ArchiveRecord(ctx context.Context, sel ast.SelectionSet, obj *models.ArchiveRecord) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, archiveRecordImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -152,13 +160,21 @@ func (ec *executionContext) _HarvestDirectivesSettings(ctx context.Context, sel
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("HarvestDirectivesSettings")
-		case "ignoreURLsRegExprs":
-			out.Values[i] = ec._HarvestDirectivesSettings_ignoreURLsRegExprs(ctx, field, obj)
-		case "removeParamsFromURLsRegEx":
-			out.Values[i] = ec._HarvestDirectivesSettings_removeParamsFromURLsRegEx(ctx, field, obj)
-		case "followHTMLRedirects":
-			out.Values[i] = ec._HarvestDirectivesSettings_followHTMLRedirects(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("ArchiveRecord")
+		case "recordId":
+			out.Values[i] = ec._ArchiveRecord_recordId(ctx, field, obj)
+		case "warcFile":
+			out.Values[i] = ec._ArchiveRecord_warcFile(ctx, field, obj)
+		case "offset":
+			out.Values[i] = ec._ArchiveRecord_offset(ctx, field, obj)
+		case "length":
+			out.Values[i] = ec._ArchiveRecord_length(ctx, field, obj)
+		case "targetURL":
+			out.Values[i] = ec._ArchiveRecord_targetURL(ctx, field, obj)
+		case "archivedAt":
+			out.Values[i] = ec._ArchiveRecord_archivedAt(ctx, field, obj)
+		case "replayPath":
+			out.Values[i] = ec._ArchiveRecord_replayPath(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -167,186 +183,130 @@ func (ec *executionContext) _HarvestDirectivesSettings(ctx context.Context, sel
 	return out
 }
 
-func (ec *executionContext) _HarvestDirectivesSettings_ignoreURLsRegExprs(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_recordId(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.IgnoreURLsRegExprs, nil
+		return obj.RecordID, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.RegularExpression)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return *res[idx1]
-		}())
-	}
-	return arr1
+	res := resTmp.(models.SmallText)
+	return res
 }
 
-func (ec *executionContext) _HarvestDirectivesSettings_removeParamsFromURLsRegEx(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_warcFile(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.RemoveParamsFromURLsRegEx, nil
+		return obj.WarcFile, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.RegularExpression)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return *res[idx1]
-		}())
-	}
-	return arr1
+	res := resTmp.(models.SmallText)
+	return res
 }
 
-func (ec *executionContext) _HarvestDirectivesSettings_followHTMLRedirects(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_offset(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.FollowHTMLRedirects, nil
+		return obj.Offset, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
-	return graphql.MarshalBoolean(res)
-}
-
-var harvestedResourceImplementors = []string{"HarvestedResource"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _HarvestedResource(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResource) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, harvestedResourceImplementors)
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("HarvestedResource")
-		case "urls":
-			out.Values[i] = ec._HarvestedResource_urls(ctx, field, obj)
-		case "isHTMLRedirect":
-			out.Values[i] = ec._HarvestedResource_isHTMLRedirect(ctx, field, obj)
-		case "isCleaned":
-			out.Values[i] = ec._HarvestedResource_isCleaned(ctx, field, obj)
-		case "redirectURL":
-			out.Values[i] = ec._HarvestedResource_redirectURL(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
-	}
-
-	return out
+	res := resTmp.(models.StorageBytesCount)
+	return res
 }
 
-func (ec *executionContext) _HarvestedResource_urls(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_length(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResource"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Urls, nil
+		return obj.Length, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.HarvestedResourceUrls)
-	return ec._HarvestedResourceUrls(ctx, field.Selections, &res)
+	res := resTmp.(models.StorageBytesCount)
+	return res
 }
 
-func (ec *executionContext) _HarvestedResource_isHTMLRedirect(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_targetURL(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResource"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.IsHTMLRedirect, nil
+		return obj.TargetURL, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
-	return graphql.MarshalBoolean(res)
+	res := resTmp.(models.URLText)
+	return res
 }
 
-func (ec *executionContext) _HarvestedResource_isCleaned(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_archivedAt(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResource"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.IsCleaned, nil
+		return obj.ArchivedAt, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
-	return graphql.MarshalBoolean(res)
+	res := resTmp.(models.DateTime)
+	return res
 }
 
-func (ec *executionContext) _HarvestedResource_redirectURL(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+func (ec *executionContext) _ArchiveRecord_replayPath(ctx context.Context, field graphql.CollectedField, obj *models.ArchiveRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResource"
+	rctx.Object = "ArchiveRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.RedirectURL, nil
+		return obj.ReplayPath, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*models.URLText)
-	if res == nil {
-		return graphql.Null
-	}
-	return *res
+	res := resTmp.(models.URLText)
+	return res
 }
 
-var harvestedResourceUrlsImplementors = []string{"HarvestedResourceUrls"}
+var canonicalURLSettingsImplementors = []string{"CanonicalURLSettings"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _HarvestedResourceUrls(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResourceUrls) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, harvestedResourceUrlsImplementors)
+func (ec *executionContext) _CanonicalURLSettings(ctx context.Context, sel ast.SelectionSet, obj *models.CanonicalURLSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, canonicalURLSettingsImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -354,15 +314,11 @@ func (ec *executionContext) _HarvestedResourceUrls(ctx context.Context, sel ast.
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("HarvestedResourceUrls")
-		case "original":
-			out.Values[i] = ec._HarvestedResourceUrls_original(ctx, field, obj)
-		case "final":
-			out.Values[i] = ec._HarvestedResourceUrls_final(ctx, field, obj)
-		case "cleaned":
-			out.Values[i] = ec._HarvestedResourceUrls_cleaned(ctx, field, obj)
-		case "resolved":
-			out.Values[i] = ec._HarvestedResourceUrls_resolved(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("CanonicalURLSettings")
+		case "detect":
+			out.Values[i] = ec._CanonicalURLSettings_detect(ctx, field, obj)
+		case "dedupe":
+			out.Values[i] = ec._CanonicalURLSettings_dedupe(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -371,79 +327,104 @@ func (ec *executionContext) _HarvestedResourceUrls(ctx context.Context, sel ast.
 	return out
 }
 
-func (ec *executionContext) _HarvestedResourceUrls_original(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+func (ec *executionContext) _CanonicalURLSettings_detect(ctx context.Context, field graphql.CollectedField, obj *models.CanonicalURLSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResourceUrls"
+	rctx.Object = "CanonicalURLSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Original, nil
+		return obj.Detect, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.URLText)
-	return res
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-func (ec *executionContext) _HarvestedResourceUrls_final(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+func (ec *executionContext) _CanonicalURLSettings_dedupe(ctx context.Context, field graphql.CollectedField, obj *models.CanonicalURLSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResourceUrls"
+	rctx.Object = "CanonicalURLSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Final, nil
+		return obj.Dedupe, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.URLText)
-	return res
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-func (ec *executionContext) _HarvestedResourceUrls_cleaned(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+var canonicalizerSettingsImplementors = []string{"CanonicalizerSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _CanonicalizerSettings(ctx context.Context, sel ast.SelectionSet, obj *models.CanonicalizerSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, canonicalizerSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("CanonicalizerSettings")
+		case "name":
+			out.Values[i] = ec._CanonicalizerSettings_name(ctx, field, obj)
+		case "enabled":
+			out.Values[i] = ec._CanonicalizerSettings_enabled(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _CanonicalizerSettings_name(ctx context.Context, field graphql.CollectedField, obj *models.CanonicalizerSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResourceUrls"
+	rctx.Object = "CanonicalizerSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Cleaned, nil
+		return obj.Name, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.URLText)
+	res := resTmp.(models.CanonicalizerName)
 	return res
 }
 
-func (ec *executionContext) _HarvestedResourceUrls_resolved(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+func (ec *executionContext) _CanonicalizerSettings_enabled(ctx context.Context, field graphql.CollectedField, obj *models.CanonicalizerSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResourceUrls"
+	rctx.Object = "CanonicalizerSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Resolved, nil
+		return obj.Enabled, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.URLText)
-	return res
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-var harvestedResourcesImplementors = []string{"HarvestedResources"}
+var domainHarvestRulesImplementors = []string{"DomainHarvestRules"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _HarvestedResources(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResources) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, harvestedResourcesImplementors)
+func (ec *executionContext) _DomainHarvestRules(ctx context.Context, sel ast.SelectionSet, obj *models.DomainHarvestRules) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, domainHarvestRulesImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -451,15 +432,15 @@ func (ec *executionContext) _HarvestedResources(ctx context.Context, sel ast.Sel
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("HarvestedResources")
-		case "text":
-			out.Values[i] = ec._HarvestedResources_text(ctx, field, obj)
-		case "harvested":
-			out.Values[i] = ec._HarvestedResources_harvested(ctx, field, obj)
-		case "ignored":
-			out.Values[i] = ec._HarvestedResources_ignored(ctx, field, obj)
-		case "invalid":
-			out.Values[i] = ec._HarvestedResources_invalid(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("DomainHarvestRules")
+		case "domain":
+			out.Values[i] = ec._DomainHarvestRules_domain(ctx, field, obj)
+		case "cleanURLs":
+			out.Values[i] = ec._DomainHarvestRules_cleanURLs(ctx, field, obj)
+		case "removeParamsFromURLsRegEx":
+			out.Values[i] = ec._DomainHarvestRules_removeParamsFromURLsRegEx(ctx, field, obj)
+		case "followHTMLRedirects":
+			out.Values[i] = ec._DomainHarvestRules_followHTMLRedirects(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -468,66 +449,57 @@ func (ec *executionContext) _HarvestedResources(ctx context.Context, sel ast.Sel
 	return out
 }
 
-func (ec *executionContext) _HarvestedResources_text(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+func (ec *executionContext) _DomainHarvestRules_domain(ctx context.Context, field graphql.CollectedField, obj *models.DomainHarvestRules) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResources"
+	rctx.Object = "DomainHarvestRules"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Text, nil
+		return obj.Domain, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.LargeText)
+	res := resTmp.(models.DomainName)
 	return res
 }
 
-func (ec *executionContext) _HarvestedResources_harvested(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+func (ec *executionContext) _DomainHarvestRules_cleanURLs(ctx context.Context, field graphql.CollectedField, obj *models.DomainHarvestRules) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResources"
+	rctx.Object = "DomainHarvestRules"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Harvested, nil
+		return obj.CleanURLs, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.HarvestedResource)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return ec._HarvestedResource(ctx, field.Selections, res[idx1])
-		}())
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
 	}
-	return arr1
+	return graphql.MarshalBoolean(*res)
 }
 
-func (ec *executionContext) _HarvestedResources_ignored(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+func (ec *executionContext) _DomainHarvestRules_removeParamsFromURLsRegEx(ctx context.Context, field graphql.CollectedField, obj *models.DomainHarvestRules) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResources"
+	rctx.Object = "DomainHarvestRules"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Ignored, nil
+		return obj.RemoveParamsFromURLsRegEx, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.IgnoredResource)
+	res := resTmp.([]*models.RegularExpression)
 	arr1 := graphql.Array{}
 	for idx1 := range res {
 		arr1 = append(arr1, func() graphql.Marshaler {
@@ -537,46 +509,37 @@ func (ec *executionContext) _HarvestedResources_ignored(ctx context.Context, fie
 			if res[idx1] == nil {
 				return graphql.Null
 			}
-			return ec._IgnoredResource(ctx, field.Selections, res[idx1])
+			return *res[idx1]
 		}())
 	}
 	return arr1
 }
 
-func (ec *executionContext) _HarvestedResources_invalid(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+func (ec *executionContext) _DomainHarvestRules_followHTMLRedirects(ctx context.Context, field graphql.CollectedField, obj *models.DomainHarvestRules) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "HarvestedResources"
+	rctx.Object = "DomainHarvestRules"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Invalid, nil
+		return obj.FollowHTMLRedirects, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.UnharvestedResource)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return ec._UnharvestedResource(ctx, field.Selections, res[idx1])
-		}())
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
 	}
-	return arr1
+	return graphql.MarshalBoolean(*res)
 }
 
-var ignoredResourceImplementors = []string{"IgnoredResource"}
+var expiredStorageRecordImplementors = []string{"ExpiredStorageRecord"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _IgnoredResource(ctx context.Context, sel ast.SelectionSet, obj *models.IgnoredResource) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, ignoredResourceImplementors)
+func (ec *executionContext) _ExpiredStorageRecord(ctx context.Context, sel ast.SelectionSet, obj *models.ExpiredStorageRecord) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, expiredStorageRecordImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -584,11 +547,19 @@ func (ec *executionContext) _IgnoredResource(ctx context.Context, sel ast.Select
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("IgnoredResource")
-		case "urls":
-			out.Values[i] = ec._IgnoredResource_urls(ctx, field, obj)
-		case "reason":
-			out.Values[i] = ec._IgnoredResource_reason(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("ExpiredStorageRecord")
+		case "collection":
+			out.Values[i] = ec._ExpiredStorageRecord_collection(ctx, field, obj)
+		case "key":
+			out.Values[i] = ec._ExpiredStorageRecord_key(ctx, field, obj)
+		case "kind":
+			out.Values[i] = ec._ExpiredStorageRecord_kind(ctx, field, obj)
+		case "url":
+			out.Values[i] = ec._ExpiredStorageRecord_url(ctx, field, obj)
+		case "savedAt":
+			out.Values[i] = ec._ExpiredStorageRecord_savedAt(ctx, field, obj)
+		case "expiresAt":
+			out.Values[i] = ec._ExpiredStorageRecord_expiresAt(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -597,49 +568,113 @@ func (ec *executionContext) _IgnoredResource(ctx context.Context, sel ast.Select
 	return out
 }
 
-func (ec *executionContext) _IgnoredResource_urls(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+func (ec *executionContext) _ExpiredStorageRecord_collection(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "IgnoredResource"
+	rctx.Object = "ExpiredStorageRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Urls, nil
+		return obj.Collection, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.HarvestedResourceUrls)
-	return ec._HarvestedResourceUrls(ctx, field.Selections, &res)
+	res := resTmp.(models.StorageDestinationCollection)
+	return res
 }
 
-func (ec *executionContext) _IgnoredResource_reason(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+func (ec *executionContext) _ExpiredStorageRecord_key(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "IgnoredResource"
+	rctx.Object = "ExpiredStorageRecord"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Reason, nil
+		return obj.Key, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.SmallText)
+	res := resTmp.(models.StorageKey)
 	return res
 }
 
-var mutationImplementors = []string{"Mutation"}
+func (ec *executionContext) _ExpiredStorageRecord_kind(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ExpiredStorageRecord"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Kind, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SavedResourceKind)
+	return res
+}
 
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, mutationImplementors)
+func (ec *executionContext) _ExpiredStorageRecord_url(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ExpiredStorageRecord"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.URL, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
 
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Mutation",
+func (ec *executionContext) _ExpiredStorageRecord_savedAt(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ExpiredStorageRecord"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.SavedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+func (ec *executionContext) _ExpiredStorageRecord_expiresAt(ctx context.Context, field graphql.CollectedField, obj *models.ExpiredStorageRecord) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ExpiredStorageRecord"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ExpiresAt, nil
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+var fileStorageSettingsImplementors = []string{"FileStorageSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _FileStorageSettings(ctx context.Context, sel ast.SelectionSet, obj *models.FileStorageSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, fileStorageSettingsImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -647,17 +682,9 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Mutation")
-		case "establishSimulatedSession":
-			out.Values[i] = ec._Mutation_establishSimulatedSession(ctx, field)
-		case "refreshSession":
-			out.Values[i] = ec._Mutation_refreshSession(ctx, field)
-		case "destroySession":
-			out.Values[i] = ec._Mutation_destroySession(ctx, field)
-		case "destroyAllSessions":
-			out.Values[i] = ec._Mutation_destroyAllSessions(ctx, field)
-		case "saveURLsinText":
-			out.Values[i] = ec._Mutation_saveURLsinText(ctx, field)
+			out.Values[i] = graphql.MarshalString("FileStorageSettings")
+		case "basePath":
+			out.Values[i] = ec._FileStorageSettings_basePath(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -666,281 +693,328 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 	return out
 }
 
-func (ec *executionContext) _Mutation_establishSimulatedSession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["authorization"] = arg0
-	var arg1 models.SettingsBundleName
-	if tmp, ok := rawArgs["settings"]; ok {
-		var err error
-		err = (&arg1).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["settings"] = arg1
+func (ec *executionContext) _FileStorageSettings_basePath(ctx context.Context, field graphql.CollectedField, obj *models.FileStorageSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Mutation"
-	rctx.Args = args
+	rctx.Object = "FileStorageSettings"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Mutation().EstablishSimulatedSession(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["settings"].(models.SettingsBundleName))
+		return obj.BasePath, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.AuthenticatedSession)
-	return ec._AuthenticatedSession(ctx, field.Selections, &res)
+	res := resTmp.(models.DirectoryPath)
+	return res
 }
 
-func (ec *executionContext) _Mutation_refreshSession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["privilegedAuthz"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["privilegedAuthz"] = arg0
-	var arg1 models.AuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg1, err = UnmarshalAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
+var hTTPFetchSettingsImplementors = []string{"HTTPFetchSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HTTPFetchSettings(ctx context.Context, sel ast.SelectionSet, obj *models.HTTPFetchSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, hTTPFetchSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HTTPFetchSettings")
+		case "timeoutSeconds":
+			out.Values[i] = ec._HTTPFetchSettings_timeoutSeconds(ctx, field, obj)
+		case "maxRedirects":
+			out.Values[i] = ec._HTTPFetchSettings_maxRedirects(ctx, field, obj)
+		case "userAgent":
+			out.Values[i] = ec._HTTPFetchSettings_userAgent(ctx, field, obj)
+		case "acceptedContentTypes":
+			out.Values[i] = ec._HTTPFetchSettings_acceptedContentTypes(ctx, field, obj)
+		case "maxBodyBytes":
+			out.Values[i] = ec._HTTPFetchSettings_maxBodyBytes(ctx, field, obj)
+		case "proxy":
+			out.Values[i] = ec._HTTPFetchSettings_proxy(ctx, field, obj)
+		case "maxConcurrentRequests":
+			out.Values[i] = ec._HTTPFetchSettings_maxConcurrentRequests(ctx, field, obj)
+		case "maxConcurrentRequestsPerHost":
+			out.Values[i] = ec._HTTPFetchSettings_maxConcurrentRequestsPerHost(ctx, field, obj)
+		case "hostDelayMilliseconds":
+			out.Values[i] = ec._HTTPFetchSettings_hostDelayMilliseconds(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
-	args["authorization"] = arg1
+
+	return out
+}
+
+func (ec *executionContext) _HTTPFetchSettings_timeoutSeconds(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Mutation"
-	rctx.Args = args
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Mutation().RefreshSession(ctx, args["privilegedAuthz"].(models.PrivilegedAuthorizationInput), args["authorization"].(models.AuthorizationInput))
+		return obj.TimeoutSeconds, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.AuthenticatedSession)
-	return ec._AuthenticatedSession(ctx, field.Selections, &res)
+	res := resTmp.(*models.TimeoutSeconds)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Mutation_destroySession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["privilegedAuthz"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["privilegedAuthz"] = arg0
-	var arg1 models.AuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg1, err = UnmarshalAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["authorization"] = arg1
+func (ec *executionContext) _HTTPFetchSettings_maxRedirects(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Mutation"
-	rctx.Args = args
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Mutation().DestroySession(ctx, args["privilegedAuthz"].(models.PrivilegedAuthorizationInput), args["authorization"].(models.AuthorizationInput))
+		return obj.MaxRedirects, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
-	return graphql.MarshalBoolean(res)
+	res := resTmp.(*models.RedirectsCount)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Mutation_destroyAllSessions(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["authorization"] = arg0
+func (ec *executionContext) _HTTPFetchSettings_userAgent(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Mutation"
-	rctx.Args = args
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Mutation().DestroyAllSessions(ctx, args["authorization"].(models.PrivilegedAuthorizationInput))
+		return obj.UserAgent, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.AuthenticatedSessionsCount)
-	return res
+	res := resTmp.(*models.UserAgent)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Mutation_saveURLsinText(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.AuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["authorization"] = arg0
-	var arg1 models.StorageDestinationInput
-	if tmp, ok := rawArgs["destination"]; ok {
-		var err error
-		arg1, err = UnmarshalStorageDestinationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+func (ec *executionContext) _HTTPFetchSettings_acceptedContentTypes(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.AcceptedContentTypes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
 	}
-	args["destination"] = arg1
-	var arg2 models.LargeText
-	if tmp, ok := rawArgs["text"]; ok {
-		var err error
-		err = (&arg2).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+	res := resTmp.([]*models.ContentType)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return *res[idx1]
+		}())
 	}
-	args["text"] = arg2
+	return arr1
+}
+
+func (ec *executionContext) _HTTPFetchSettings_maxBodyBytes(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Mutation"
-	rctx.Args = args
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.resolvers.Mutation().SaveURLsinText(ctx, args["authorization"].(models.AuthorizationInput), args["destination"].(models.StorageDestinationInput), args["text"].(models.LargeText))
+		return obj.MaxBodyBytes, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*models.HarvestedResources)
+	res := resTmp.(*models.BodySizeBytes)
 	if res == nil {
 		return graphql.Null
 	}
-	return ec._HarvestedResources(ctx, field.Selections, res)
+	return *res
 }
 
-var organizationImplementors = []string{"Organization", "Party"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Organization(ctx context.Context, sel ast.SelectionSet, obj *models.Organization) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, organizationImplementors)
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Organization")
-		case "id":
-			out.Values[i] = ec._Organization_id(ctx, field, obj)
-		case "name":
-			out.Values[i] = ec._Organization_name(ctx, field, obj)
-		case "units":
-			out.Values[i] = ec._Organization_units(ctx, field, obj)
-		case "services":
-			out.Values[i] = ec._Organization_services(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
+func (ec *executionContext) _HTTPFetchSettings_proxy(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Proxy, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
 	}
+	return *res
+}
 
-	return out
+func (ec *executionContext) _HTTPFetchSettings_maxConcurrentRequests(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HTTPFetchSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.MaxConcurrentRequests, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ConcurrencyLimit)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Organization_id(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+func (ec *executionContext) _HTTPFetchSettings_maxConcurrentRequestsPerHost(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Organization"
+	rctx.Object = "HTTPFetchSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.ID, nil
+		return obj.MaxConcurrentRequestsPerHost, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
-	return graphql.MarshalID(res)
+	res := resTmp.(*models.ConcurrencyLimit)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Organization_name(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+func (ec *executionContext) _HTTPFetchSettings_hostDelayMilliseconds(ctx context.Context, field graphql.CollectedField, obj *models.HTTPFetchSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Organization"
+	rctx.Object = "HTTPFetchSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Name, nil
+		return obj.HostDelayMilliseconds, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.(*models.DelayMilliseconds)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Organization_units(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+var harvestDirectivesSettingsImplementors = []string{"HarvestDirectivesSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestDirectivesSettings(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestDirectivesSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestDirectivesSettings")
+		case "ignoreURLsRegExprs":
+			out.Values[i] = ec._HarvestDirectivesSettings_ignoreURLsRegExprs(ctx, field, obj)
+		case "removeParamsFromURLsRegEx":
+			out.Values[i] = ec._HarvestDirectivesSettings_removeParamsFromURLsRegEx(ctx, field, obj)
+		case "followHTMLRedirects":
+			out.Values[i] = ec._HarvestDirectivesSettings_followHTMLRedirects(ctx, field, obj)
+		case "jobWorkers":
+			out.Values[i] = ec._HarvestDirectivesSettings_jobWorkers(ctx, field, obj)
+		case "batchConcurrency":
+			out.Values[i] = ec._HarvestDirectivesSettings_batchConcurrency(ctx, field, obj)
+		case "allowRequestDirectives":
+			out.Values[i] = ec._HarvestDirectivesSettings_allowRequestDirectives(ctx, field, obj)
+		case "fetch":
+			out.Values[i] = ec._HarvestDirectivesSettings_fetch(ctx, field, obj)
+		case "respectRobotsTxt":
+			out.Values[i] = ec._HarvestDirectivesSettings_respectRobotsTxt(ctx, field, obj)
+		case "robotsTxtCacheMinutes":
+			out.Values[i] = ec._HarvestDirectivesSettings_robotsTxtCacheMinutes(ctx, field, obj)
+		case "resolutionCacheMinutes":
+			out.Values[i] = ec._HarvestDirectivesSettings_resolutionCacheMinutes(ctx, field, obj)
+		case "ignoreURLsRules":
+			out.Values[i] = ec._HarvestDirectivesSettings_ignoreURLsRules(ctx, field, obj)
+		case "removeParamsRules":
+			out.Values[i] = ec._HarvestDirectivesSettings_removeParamsRules(ctx, field, obj)
+		case "allowDomains":
+			out.Values[i] = ec._HarvestDirectivesSettings_allowDomains(ctx, field, obj)
+		case "denyDomains":
+			out.Values[i] = ec._HarvestDirectivesSettings_denyDomains(ctx, field, obj)
+		case "domainRules":
+			out.Values[i] = ec._HarvestDirectivesSettings_domainRules(ctx, field, obj)
+		case "normalization":
+			out.Values[i] = ec._HarvestDirectivesSettings_normalization(ctx, field, obj)
+		case "canonicalizers":
+			out.Values[i] = ec._HarvestDirectivesSettings_canonicalizers(ctx, field, obj)
+		case "canonicalURLs":
+			out.Values[i] = ec._HarvestDirectivesSettings_canonicalURLs(ctx, field, obj)
+		case "extractMetadata":
+			out.Values[i] = ec._HarvestDirectivesSettings_extractMetadata(ctx, field, obj)
+		case "extractContent":
+			out.Values[i] = ec._HarvestDirectivesSettings_extractContent(ctx, field, obj)
+		case "archive":
+			out.Values[i] = ec._HarvestDirectivesSettings_archive(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestDirectivesSettings_ignoreURLsRegExprs(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Organization"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Units, nil
+		return obj.IgnoreURLsRegExprs, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.OrganizationalUnit)
+	res := resTmp.([]*models.RegularExpression)
 	arr1 := graphql.Array{}
 	for idx1 := range res {
 		arr1 = append(arr1, func() graphql.Marshaler {
@@ -950,26 +1024,26 @@ func (ec *executionContext) _Organization_units(ctx context.Context, field graph
 			if res[idx1] == nil {
 				return graphql.Null
 			}
-			return ec._OrganizationalUnit(ctx, field.Selections, res[idx1])
+			return *res[idx1]
 		}())
 	}
 	return arr1
 }
 
-func (ec *executionContext) _Organization_services(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_removeParamsFromURLsRegEx(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Organization"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Services, nil
+		return obj.RemoveParamsFromURLsRegEx, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.ServiceIdentity)
+	res := resTmp.([]*models.RegularExpression)
 	arr1 := graphql.Array{}
 	for idx1 := range res {
 		arr1 = append(arr1, func() graphql.Marshaler {
@@ -979,248 +1053,206 @@ func (ec *executionContext) _Organization_services(ctx context.Context, field gr
 			if res[idx1] == nil {
 				return graphql.Null
 			}
-			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
+			return *res[idx1]
 		}())
 	}
 	return arr1
 }
 
-var organizationalUnitImplementors = []string{"OrganizationalUnit", "Party"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _OrganizationalUnit(ctx context.Context, sel ast.SelectionSet, obj *models.OrganizationalUnit) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, organizationalUnitImplementors)
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("OrganizationalUnit")
-		case "id":
-			out.Values[i] = ec._OrganizationalUnit_id(ctx, field, obj)
-		case "name":
-			out.Values[i] = ec._OrganizationalUnit_name(ctx, field, obj)
-		case "units":
-			out.Values[i] = ec._OrganizationalUnit_units(ctx, field, obj)
-		case "services":
-			out.Values[i] = ec._OrganizationalUnit_services(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
-	}
-
-	return out
-}
-
-func (ec *executionContext) _OrganizationalUnit_id(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_followHTMLRedirects(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "OrganizationalUnit"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.ID, nil
+		return obj.FollowHTMLRedirects, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
-	return graphql.MarshalID(res)
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-func (ec *executionContext) _OrganizationalUnit_name(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_jobWorkers(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "OrganizationalUnit"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Name, nil
+		return obj.JobWorkers, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.(*models.HarvestJobWorkersCount)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _OrganizationalUnit_units(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_batchConcurrency(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "OrganizationalUnit"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Units, nil
+		return obj.BatchConcurrency, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.OrganizationalUnit)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return ec._OrganizationalUnit(ctx, field.Selections, res[idx1])
-		}())
+	res := resTmp.(*models.ConcurrencyLimit)
+	if res == nil {
+		return graphql.Null
 	}
-	return arr1
+	return *res
 }
 
-func (ec *executionContext) _OrganizationalUnit_services(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_allowRequestDirectives(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "OrganizationalUnit"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Services, nil
+		return obj.AllowRequestDirectives, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.ServiceIdentity)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
-		}())
-	}
-	return arr1
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-var personImplementors = []string{"Person", "Party"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Person(ctx context.Context, sel ast.SelectionSet, obj *models.Person) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, personImplementors)
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Person")
-		case "id":
-			out.Values[i] = ec._Person_id(ctx, field, obj)
-		case "name":
-			out.Values[i] = ec._Person_name(ctx, field, obj)
-		case "firstName":
-			out.Values[i] = ec._Person_firstName(ctx, field, obj)
-		case "lastName":
-			out.Values[i] = ec._Person_lastName(ctx, field, obj)
-		case "users":
-			out.Values[i] = ec._Person_users(ctx, field, obj)
-		case "services":
-			out.Values[i] = ec._Person_services(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
+func (ec *executionContext) _HarvestDirectivesSettings_fetch(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Fetch, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
 	}
-
-	return out
+	res := resTmp.(*models.HTTPFetchSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._HTTPFetchSettings(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Person_id(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_respectRobotsTxt(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.ID, nil
+		return obj.RespectRobotsTxt, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
-	return graphql.MarshalID(res)
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
 }
 
-func (ec *executionContext) _Person_name(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_robotsTxtCacheMinutes(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Name, nil
+		return obj.RobotsTxtCacheMinutes, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.(*models.CacheTTLMinutes)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Person_firstName(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_resolutionCacheMinutes(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.FirstName, nil
+		return obj.ResolutionCacheMinutes, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.(*models.CacheTTLMinutes)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Person_lastName(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_ignoreURLsRules(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.LastName, nil
+		return obj.IgnoreURLsRules, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.([]*models.HarvestRule)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._HarvestRule(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
 }
 
-func (ec *executionContext) _Person_users(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_removeParamsRules(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Users, nil
+		return obj.RemoveParamsRules, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.UserIdentity)
+	res := resTmp.([]*models.HarvestRule)
 	arr1 := graphql.Array{}
 	for idx1 := range res {
 		arr1 = append(arr1, func() graphql.Marshaler {
@@ -1230,26 +1262,26 @@ func (ec *executionContext) _Person_users(ctx context.Context, field graphql.Col
 			if res[idx1] == nil {
 				return graphql.Null
 			}
-			return ec._UserIdentity(ctx, field.Selections, res[idx1])
+			return ec._HarvestRule(ctx, field.Selections, res[idx1])
 		}())
 	}
 	return arr1
 }
 
-func (ec *executionContext) _Person_services(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+func (ec *executionContext) _HarvestDirectivesSettings_allowDomains(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Person"
+	rctx.Object = "HarvestDirectivesSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Services, nil
+		return obj.AllowDomains, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.ServiceIdentity)
+	res := resTmp.([]*models.DomainName)
 	arr1 := graphql.Array{}
 	for idx1 := range res {
 		arr1 = append(arr1, func() graphql.Marshaler {
@@ -1259,363 +1291,5464 @@ func (ec *executionContext) _Person_services(ctx context.Context, field graphql.
 			if res[idx1] == nil {
 				return graphql.Null
 			}
-			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
+			return *res[idx1]
 		}())
 	}
 	return arr1
 }
 
-var queryImplementors = []string{"Query"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, queryImplementors)
-
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
+func (ec *executionContext) _HarvestDirectivesSettings_denyDomains(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.DenyDomains, nil
 	})
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Query")
-		case "asymmetricCryptoPublicKey":
-			out.Values[i] = ec._Query_asymmetricCryptoPublicKey(ctx, field)
-		case "asymmetricCryptoPublicKeys":
-			out.Values[i] = ec._Query_asymmetricCryptoPublicKeys(ctx, field)
-		case "settingsBundles":
-			out.Values[i] = ec._Query_settingsBundles(ctx, field)
-		case "settingsBundle":
-			out.Values[i] = ec._Query_settingsBundle(ctx, field)
-		case "urlsInText":
-			out.Values[i] = ec._Query_urlsInText(ctx, field)
-		case "__type":
-			out.Values[i] = ec._Query___type(ctx, field)
-		case "__schema":
-			out.Values[i] = ec._Query___schema(ctx, field)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
+	if resTmp == nil {
+		return graphql.Null
 	}
-
-	return out
+	res := resTmp.([]*models.DomainName)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return *res[idx1]
+		}())
+	}
+	return arr1
 }
 
-func (ec *executionContext) _Query_asymmetricCryptoPublicKey(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.AuthorizationClaimType
-	if tmp, ok := rawArgs["claimType"]; ok {
-		var err error
-		err = (&arg0).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["claimType"] = arg0
-	var arg1 models.AsymmetricCryptoPublicKeyName
-	if tmp, ok := rawArgs["keyId"]; ok {
-		var err error
-		err = (&arg1).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["keyId"] = arg1
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
-		Field:  field,
+func (ec *executionContext) _HarvestDirectivesSettings_domainRules(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.DomainRules, nil
 	})
-	return graphql.Defer(func() (ret graphql.Marshaler) {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := ec.Recover(ctx, r)
-				ec.Error(ctx, userErr)
-				ret = graphql.Null
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.DomainHarvestRules)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
 			}
-		}()
+			return ec._DomainHarvestRules(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
 
-		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.Query().AsymmetricCryptoPublicKey(ctx, args["claimType"].(models.AuthorizationClaimType), args["keyId"].(models.AsymmetricCryptoPublicKeyName))
-		})
-		if resTmp == nil {
-			return graphql.Null
-		}
-		res := resTmp.(models.AuthorizationClaimCryptoKey)
-		return ec._AuthorizationClaimCryptoKey(ctx, field.Selections, &res)
+func (ec *executionContext) _HarvestDirectivesSettings_normalization(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Normalization, nil
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLNormalizationSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._URLNormalizationSettings(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_asymmetricCryptoPublicKeys(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 *models.AuthorizationClaimType
-	if tmp, ok := rawArgs["claimType"]; ok {
-		var err error
-		var ptr1 models.AuthorizationClaimType
-		if tmp != nil {
+func (ec *executionContext) _HarvestDirectivesSettings_canonicalizers(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Canonicalizers, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.CanonicalizerSettings)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._CanonicalizerSettings(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestDirectivesSettings_canonicalURLs(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.CanonicalURLs, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.CanonicalURLSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._CanonicalURLSettings(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _HarvestDirectivesSettings_extractMetadata(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ExtractMetadata, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestDirectivesSettings_extractContent(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ExtractContent, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestDirectivesSettings_archive(ctx context.Context, field graphql.CollectedField, obj *models.HarvestDirectivesSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestDirectivesSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Archive, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+var harvestJobImplementors = []string{"HarvestJob"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestJob(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestJob) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestJobImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestJob")
+		case "id":
+			out.Values[i] = ec._HarvestJob_id(ctx, field, obj)
+		case "status":
+			out.Values[i] = ec._HarvestJob_status(ctx, field, obj)
+		case "destination":
+			out.Values[i] = ec._HarvestJob_destination(ctx, field, obj)
+		case "submittedAt":
+			out.Values[i] = ec._HarvestJob_submittedAt(ctx, field, obj)
+		case "startedAt":
+			out.Values[i] = ec._HarvestJob_startedAt(ctx, field, obj)
+		case "finishedAt":
+			out.Values[i] = ec._HarvestJob_finishedAt(ctx, field, obj)
+		case "discovered":
+			out.Values[i] = ec._HarvestJob_discovered(ctx, field, obj)
+		case "processed":
+			out.Values[i] = ec._HarvestJob_processed(ctx, field, obj)
+		case "harvested":
+			out.Values[i] = ec._HarvestJob_harvested(ctx, field, obj)
+		case "ignored":
+			out.Values[i] = ec._HarvestJob_ignored(ctx, field, obj)
+		case "invalid":
+			out.Values[i] = ec._HarvestJob_invalid(ctx, field, obj)
+		case "error":
+			out.Values[i] = ec._HarvestJob_error(ctx, field, obj)
+		case "resources":
+			out.Values[i] = ec._HarvestJob_resources(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestJob_id(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestJobID)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_status(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Status, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestJobStatus)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_destination(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Destination, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageKey)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestJob_submittedAt(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.SubmittedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_startedAt(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.StartedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.DateTime)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestJob_finishedAt(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.FinishedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.DateTime)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestJob_discovered(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Discovered, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResourcesCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_processed(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Processed, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResourcesCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_harvested(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Harvested, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResourcesCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_ignored(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Ignored, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResourcesCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_invalid(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Invalid, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResourcesCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestJob_error(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Error, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ErrorMessage)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestJob_resources(ctx context.Context, field graphql.CollectedField, obj *models.HarvestJob) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestJob"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Resources, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.HarvestedResources)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._HarvestedResources(ctx, field.Selections, res)
+}
+
+var harvestRuleImplementors = []string{"HarvestRule"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestRule(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestRule) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestRuleImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestRule")
+		case "name":
+			out.Values[i] = ec._HarvestRule_name(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._HarvestRule_description(ctx, field, obj)
+		case "enabled":
+			out.Values[i] = ec._HarvestRule_enabled(ctx, field, obj)
+		case "regEx":
+			out.Values[i] = ec._HarvestRule_regEx(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestRule_name(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RuleIdentifier)
+	return res
+}
+
+func (ec *executionContext) _HarvestRule_description(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Description, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.MediumText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestRule_enabled(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Enabled, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalBoolean(*res)
+}
+
+func (ec *executionContext) _HarvestRule_regEx(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RegEx, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RegularExpression)
+	return res
+}
+
+var harvestRuleStatsImplementors = []string{"HarvestRuleStats"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestRuleStats(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestRuleStats) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestRuleStatsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestRuleStats")
+		case "kind":
+			out.Values[i] = ec._HarvestRuleStats_kind(ctx, field, obj)
+		case "domain":
+			out.Values[i] = ec._HarvestRuleStats_domain(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._HarvestRuleStats_name(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._HarvestRuleStats_description(ctx, field, obj)
+		case "enabled":
+			out.Values[i] = ec._HarvestRuleStats_enabled(ctx, field, obj)
+		case "regEx":
+			out.Values[i] = ec._HarvestRuleStats_regEx(ctx, field, obj)
+		case "hits":
+			out.Values[i] = ec._HarvestRuleStats_hits(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestRuleStats_kind(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Kind, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestRuleKind)
+	return res
+}
+
+func (ec *executionContext) _HarvestRuleStats_domain(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Domain, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.DomainName)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestRuleStats_name(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RuleIdentifier)
+	return res
+}
+
+func (ec *executionContext) _HarvestRuleStats_description(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Description, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.MediumText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestRuleStats_enabled(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Enabled, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestRuleStats_regEx(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RegEx, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RegularExpression)
+	return res
+}
+
+func (ec *executionContext) _HarvestRuleStats_hits(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRuleStats) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRuleStats"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Hits, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HitsCount)
+	return res
+}
+
+var harvestRulesTestImplementors = []string{"HarvestRulesTest"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestRulesTest(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestRulesTest) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestRulesTestImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestRulesTest")
+		case "url":
+			out.Values[i] = ec._HarvestRulesTest_url(ctx, field, obj)
+		case "isValid":
+			out.Values[i] = ec._HarvestRulesTest_isValid(ctx, field, obj)
+		case "ignoreRule":
+			out.Values[i] = ec._HarvestRulesTest_ignoreRule(ctx, field, obj)
+		case "removedParams":
+			out.Values[i] = ec._HarvestRulesTest_removedParams(ctx, field, obj)
+		case "cleaned":
+			out.Values[i] = ec._HarvestRulesTest_cleaned(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestRulesTest_url(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRulesTest) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRulesTest"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.URL, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestRulesTest_isValid(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRulesTest) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRulesTest"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.IsValid, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestRulesTest_ignoreRule(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRulesTest) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRulesTest"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.IgnoreRule, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.RuleIdentifier)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestRulesTest_removedParams(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRulesTest) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRulesTest"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RemovedParams, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.RemovedQueryParam)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._RemovedQueryParam(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestRulesTest_cleaned(ctx context.Context, field graphql.CollectedField, obj *models.HarvestRulesTest) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestRulesTest"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Cleaned, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var harvestedContentImplementors = []string{"HarvestedContent"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestedContent(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedContent) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestedContentImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestedContent")
+		case "text":
+			out.Values[i] = ec._HarvestedContent_text(ctx, field, obj)
+		case "html":
+			out.Values[i] = ec._HarvestedContent_html(ctx, field, obj)
+		case "wordCount":
+			out.Values[i] = ec._HarvestedContent_wordCount(ctx, field, obj)
+		case "readingTimeMinutes":
+			out.Values[i] = ec._HarvestedContent_readingTimeMinutes(ctx, field, obj)
+		case "extractedAt":
+			out.Values[i] = ec._HarvestedContent_extractedAt(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestedContent_text(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedContent) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedContent"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Text, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ExtraLargeText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedContent_html(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedContent) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedContent"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.HTML, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ExtraLargeText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedContent_wordCount(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedContent) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedContent"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.WordCount, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.WordsCount)
+	return res
+}
+
+func (ec *executionContext) _HarvestedContent_readingTimeMinutes(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedContent) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedContent"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ReadingTimeMinutes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ReadingTimeMinutes)
+	return res
+}
+
+func (ec *executionContext) _HarvestedContent_extractedAt(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedContent) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedContent"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ExtractedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+var harvestedResourceImplementors = []string{"HarvestedResource"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestedResource(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResource) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestedResourceImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestedResource")
+		case "urls":
+			out.Values[i] = ec._HarvestedResource_urls(ctx, field, obj)
+		case "isHTMLRedirect":
+			out.Values[i] = ec._HarvestedResource_isHTMLRedirect(ctx, field, obj)
+		case "isCleaned":
+			out.Values[i] = ec._HarvestedResource_isCleaned(ctx, field, obj)
+		case "redirectURL":
+			out.Values[i] = ec._HarvestedResource_redirectURL(ctx, field, obj)
+		case "redirectChain":
+			out.Values[i] = ec._HarvestedResource_redirectChain(ctx, field, obj)
+		case "cacheStatus":
+			out.Values[i] = ec._HarvestedResource_cacheStatus(ctx, field, obj)
+		case "canonicalizedBy":
+			out.Values[i] = ec._HarvestedResource_canonicalizedBy(ctx, field, obj)
+		case "metadata":
+			out.Values[i] = ec._HarvestedResource_metadata(ctx, field, obj)
+		case "content":
+			out.Values[i] = ec._HarvestedResource_content(ctx, field, obj)
+		case "archiveRecord":
+			out.Values[i] = ec._HarvestedResource_archiveRecord(ctx, field, obj)
+		case "discovery":
+			out.Values[i] = ec._HarvestedResource_discovery(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestedResource_urls(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Urls, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestedResourceUrls)
+	return ec._HarvestedResourceUrls(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _HarvestedResource_isHTMLRedirect(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.IsHTMLRedirect, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestedResource_isCleaned(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.IsCleaned, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _HarvestedResource_redirectURL(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RedirectURL, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _HarvestedResource_redirectChain(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RedirectChain, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.RedirectHop)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._RedirectHop(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestedResource_cacheStatus(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.CacheStatus, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ResolutionCacheStatus)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResource_canonicalizedBy(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.CanonicalizedBy, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]models.CanonicalizerName)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			return res[idx1]
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestedResource_metadata(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Metadata, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.PageMetadata)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._PageMetadata(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _HarvestedResource_content(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "HarvestedResource",
+		Args:   nil,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.HarvestedResource().Content(ctx, obj)
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.HarvestedContent)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._HarvestedContent(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _HarvestedResource_archiveRecord(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ArchiveRecord, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ArchiveRecord)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._ArchiveRecord(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _HarvestedResource_discovery(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Discovery, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ResourceDiscovery)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._ResourceDiscovery(ctx, field.Selections, res)
+}
+
+var harvestedResourceUrlsImplementors = []string{"HarvestedResourceUrls"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestedResourceUrls(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestedResourceUrlsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestedResourceUrls")
+		case "original":
+			out.Values[i] = ec._HarvestedResourceUrls_original(ctx, field, obj)
+		case "final":
+			out.Values[i] = ec._HarvestedResourceUrls_final(ctx, field, obj)
+		case "cleaned":
+			out.Values[i] = ec._HarvestedResourceUrls_cleaned(ctx, field, obj)
+		case "resolved":
+			out.Values[i] = ec._HarvestedResourceUrls_resolved(ctx, field, obj)
+		case "normalized":
+			out.Values[i] = ec._HarvestedResourceUrls_normalized(ctx, field, obj)
+		case "canonical":
+			out.Values[i] = ec._HarvestedResourceUrls_canonical(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_original(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Original, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_final(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Final, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_cleaned(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Cleaned, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_resolved(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Resolved, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_normalized(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Normalized, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResourceUrls_canonical(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResourceUrls) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResourceUrls"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Canonical, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var harvestedResourcesImplementors = []string{"HarvestedResources"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _HarvestedResources(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestedResources) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, harvestedResourcesImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HarvestedResources")
+		case "text":
+			out.Values[i] = ec._HarvestedResources_text(ctx, field, obj)
+		case "harvested":
+			out.Values[i] = ec._HarvestedResources_harvested(ctx, field, obj)
+		case "ignored":
+			out.Values[i] = ec._HarvestedResources_ignored(ctx, field, obj)
+		case "invalid":
+			out.Values[i] = ec._HarvestedResources_invalid(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _HarvestedResources_text(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResources"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Text, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.LargeText)
+	return res
+}
+
+func (ec *executionContext) _HarvestedResources_harvested(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResources"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Harvested, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.HarvestedResource)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._HarvestedResource(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestedResources_ignored(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResources"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Ignored, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.IgnoredResource)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._IgnoredResource(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _HarvestedResources_invalid(ctx context.Context, field graphql.CollectedField, obj *models.HarvestedResources) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "HarvestedResources"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Invalid, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.UnharvestedResource)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._UnharvestedResource(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+var ignoredResourceImplementors = []string{"IgnoredResource"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _IgnoredResource(ctx context.Context, sel ast.SelectionSet, obj *models.IgnoredResource) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, ignoredResourceImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("IgnoredResource")
+		case "urls":
+			out.Values[i] = ec._IgnoredResource_urls(ctx, field, obj)
+		case "reason":
+			out.Values[i] = ec._IgnoredResource_reason(ctx, field, obj)
+		case "reasonCode":
+			out.Values[i] = ec._IgnoredResource_reasonCode(ctx, field, obj)
+		case "matchedRule":
+			out.Values[i] = ec._IgnoredResource_matchedRule(ctx, field, obj)
+		case "redirectChain":
+			out.Values[i] = ec._IgnoredResource_redirectChain(ctx, field, obj)
+		case "discovery":
+			out.Values[i] = ec._IgnoredResource_discovery(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _IgnoredResource_urls(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Urls, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestedResourceUrls)
+	return ec._HarvestedResourceUrls(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _IgnoredResource_reason(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Reason, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SmallText)
+	return res
+}
+
+func (ec *executionContext) _IgnoredResource_reasonCode(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ReasonCode, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ReasonCode)
+	return res
+}
+
+func (ec *executionContext) _IgnoredResource_matchedRule(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.MatchedRule, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.RuleIdentifier)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _IgnoredResource_redirectChain(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RedirectChain, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.RedirectHop)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._RedirectHop(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _IgnoredResource_discovery(ctx context.Context, field graphql.CollectedField, obj *models.IgnoredResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "IgnoredResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Discovery, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ResourceDiscovery)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._ResourceDiscovery(ctx, field.Selections, res)
+}
+
+var mutationImplementors = []string{"Mutation"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, mutationImplementors)
+
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Mutation",
+	})
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Mutation")
+		case "establishSimulatedSession":
+			out.Values[i] = ec._Mutation_establishSimulatedSession(ctx, field)
+		case "refreshSession":
+			out.Values[i] = ec._Mutation_refreshSession(ctx, field)
+		case "destroySession":
+			out.Values[i] = ec._Mutation_destroySession(ctx, field)
+		case "destroyAllSessions":
+			out.Values[i] = ec._Mutation_destroyAllSessions(ctx, field)
+		case "saveURLsinText":
+			out.Values[i] = ec._Mutation_saveURLsinText(ctx, field)
+		case "saveURLsinTexts":
+			out.Values[i] = ec._Mutation_saveURLsinTexts(ctx, field)
+		case "submitHarvestJob":
+			out.Values[i] = ec._Mutation_submitHarvestJob(ctx, field)
+		case "backupStorage":
+			out.Values[i] = ec._Mutation_backupStorage(ctx, field)
+		case "restoreStorage":
+			out.Values[i] = ec._Mutation_restoreStorage(ctx, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _Mutation_establishSimulatedSession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["settings"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["settings"] = arg1
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().EstablishSimulatedSession(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["settings"].(models.SettingsBundleName))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.AuthenticatedSession)
+	return ec._AuthenticatedSession(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _Mutation_refreshSession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["privilegedAuthz"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["privilegedAuthz"] = arg0
+	var arg1 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg1, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg1
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().RefreshSession(ctx, args["privilegedAuthz"].(models.PrivilegedAuthorizationInput), args["authorization"].(models.AuthorizationInput))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.AuthenticatedSession)
+	return ec._AuthenticatedSession(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _Mutation_destroySession(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["privilegedAuthz"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["privilegedAuthz"] = arg0
+	var arg1 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg1, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg1
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().DestroySession(ctx, args["privilegedAuthz"].(models.PrivilegedAuthorizationInput), args["authorization"].(models.AuthorizationInput))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _Mutation_destroyAllSessions(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().DestroyAllSessions(ctx, args["authorization"].(models.PrivilegedAuthorizationInput))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.AuthenticatedSessionsCount)
+	return res
+}
+
+func (ec *executionContext) _Mutation_saveURLsinText(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.StorageDestinationInput
+	if tmp, ok := rawArgs["destination"]; ok {
+		var err error
+		arg1, err = UnmarshalStorageDestinationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["destination"] = arg1
+	var arg2 models.LargeText
+	if tmp, ok := rawArgs["text"]; ok {
+		var err error
+		err = (&arg2).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["text"] = arg2
+	var arg3 *models.HarvestDirectivesInput
+	if tmp, ok := rawArgs["directives"]; ok {
+		var err error
+		var ptr1 models.HarvestDirectivesInput
+		if tmp != nil {
+			ptr1, err = UnmarshalHarvestDirectivesInput(tmp)
+			arg3 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["directives"] = arg3
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().SaveURLsinText(ctx, args["authorization"].(models.AuthorizationInput), args["destination"].(models.StorageDestinationInput), args["text"].(models.LargeText), args["directives"].(*models.HarvestDirectivesInput))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.HarvestedResources)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._HarvestedResources(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_saveURLsinTexts(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.StorageDestinationInput
+	if tmp, ok := rawArgs["destination"]; ok {
+		var err error
+		arg1, err = UnmarshalStorageDestinationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["destination"] = arg1
+	var arg2 []models.LargeText
+	if tmp, ok := rawArgs["texts"]; ok {
+		var err error
+		var rawIf1 []interface{}
+		if tmp != nil {
+			if tmp1, ok := tmp.([]interface{}); ok {
+				rawIf1 = tmp1
+			} else {
+				rawIf1 = []interface{}{tmp}
+			}
+		}
+		arg2 = make([]models.LargeText, len(rawIf1))
+		for idx1 := range rawIf1 {
+			err = (&arg2[idx1]).UnmarshalGQL(rawIf1[idx1])
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["texts"] = arg2
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().SaveURLsinTexts(ctx, args["authorization"].(models.AuthorizationInput), args["destination"].(models.StorageDestinationInput), args["texts"].([]models.LargeText))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.HarvestedResources)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._HarvestedResources(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _Mutation_submitHarvestJob(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.LargeText
+	if tmp, ok := rawArgs["text"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["text"] = arg1
+	var arg2 *models.StorageDestinationInput
+	if tmp, ok := rawArgs["destination"]; ok {
+		var err error
+		var ptr1 models.StorageDestinationInput
+		if tmp != nil {
+			ptr1, err = UnmarshalStorageDestinationInput(tmp)
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["destination"] = arg2
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().SubmitHarvestJob(ctx, args["authorization"].(models.AuthorizationInput), args["text"].(models.LargeText), args["destination"].(*models.StorageDestinationInput))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.HarvestJob)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._HarvestJob(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_backupStorage(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().BackupStorage(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageBackup)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageBackup(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_restoreStorage(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	var arg2 models.FilePathAndName
+	if tmp, ok := rawArgs["file"]; ok {
+		var err error
+		err = (&arg2).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["file"] = arg2
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Mutation"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.resolvers.Mutation().RestoreStorage(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName), args["file"].(models.FilePathAndName))
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageBackup)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageBackup(ctx, field.Selections, res)
+}
+
+var openGraphMetadataImplementors = []string{"OpenGraphMetadata"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _OpenGraphMetadata(ctx context.Context, sel ast.SelectionSet, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, openGraphMetadataImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OpenGraphMetadata")
+		case "type":
+			out.Values[i] = ec._OpenGraphMetadata_type(ctx, field, obj)
+		case "title":
+			out.Values[i] = ec._OpenGraphMetadata_title(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._OpenGraphMetadata_description(ctx, field, obj)
+		case "image":
+			out.Values[i] = ec._OpenGraphMetadata_image(ctx, field, obj)
+		case "url":
+			out.Values[i] = ec._OpenGraphMetadata_url(ctx, field, obj)
+		case "siteName":
+			out.Values[i] = ec._OpenGraphMetadata_siteName(ctx, field, obj)
+		case "locale":
+			out.Values[i] = ec._OpenGraphMetadata_locale(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _OpenGraphMetadata_type(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Type, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_title(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Title, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_description(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Description, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.MediumText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_image(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Image, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_url(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.URL, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_siteName(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.SiteName, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.NameText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _OpenGraphMetadata_locale(ctx context.Context, field graphql.CollectedField, obj *models.OpenGraphMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OpenGraphMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Locale, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.LanguageCode)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var organizationImplementors = []string{"Organization", "Party"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Organization(ctx context.Context, sel ast.SelectionSet, obj *models.Organization) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, organizationImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Organization")
+		case "id":
+			out.Values[i] = ec._Organization_id(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._Organization_name(ctx, field, obj)
+		case "units":
+			out.Values[i] = ec._Organization_units(ctx, field, obj)
+		case "services":
+			out.Values[i] = ec._Organization_services(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _Organization_id(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Organization"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	return graphql.MarshalID(res)
+}
+
+func (ec *executionContext) _Organization_name(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Organization"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.NameText)
+	return res
+}
+
+func (ec *executionContext) _Organization_units(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Organization"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Units, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.OrganizationalUnit)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._OrganizationalUnit(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _Organization_services(ctx context.Context, field graphql.CollectedField, obj *models.Organization) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Organization"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Services, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.ServiceIdentity)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+var organizationalUnitImplementors = []string{"OrganizationalUnit", "Party"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _OrganizationalUnit(ctx context.Context, sel ast.SelectionSet, obj *models.OrganizationalUnit) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, organizationalUnitImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OrganizationalUnit")
+		case "id":
+			out.Values[i] = ec._OrganizationalUnit_id(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._OrganizationalUnit_name(ctx, field, obj)
+		case "units":
+			out.Values[i] = ec._OrganizationalUnit_units(ctx, field, obj)
+		case "services":
+			out.Values[i] = ec._OrganizationalUnit_services(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _OrganizationalUnit_id(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OrganizationalUnit"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	return graphql.MarshalID(res)
+}
+
+func (ec *executionContext) _OrganizationalUnit_name(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OrganizationalUnit"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.NameText)
+	return res
+}
+
+func (ec *executionContext) _OrganizationalUnit_units(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OrganizationalUnit"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Units, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.OrganizationalUnit)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._OrganizationalUnit(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _OrganizationalUnit_services(ctx context.Context, field graphql.CollectedField, obj *models.OrganizationalUnit) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "OrganizationalUnit"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Services, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.ServiceIdentity)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+var pageMetadataImplementors = []string{"PageMetadata"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _PageMetadata(ctx context.Context, sel ast.SelectionSet, obj *models.PageMetadata) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, pageMetadataImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("PageMetadata")
+		case "title":
+			out.Values[i] = ec._PageMetadata_title(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._PageMetadata_description(ctx, field, obj)
+		case "author":
+			out.Values[i] = ec._PageMetadata_author(ctx, field, obj)
+		case "publishedAt":
+			out.Values[i] = ec._PageMetadata_publishedAt(ctx, field, obj)
+		case "siteName":
+			out.Values[i] = ec._PageMetadata_siteName(ctx, field, obj)
+		case "favicon":
+			out.Values[i] = ec._PageMetadata_favicon(ctx, field, obj)
+		case "language":
+			out.Values[i] = ec._PageMetadata_language(ctx, field, obj)
+		case "openGraph":
+			out.Values[i] = ec._PageMetadata_openGraph(ctx, field, obj)
+		case "twitterCard":
+			out.Values[i] = ec._PageMetadata_twitterCard(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _PageMetadata_title(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Title, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_description(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Description, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.MediumText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_author(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Author, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.NameText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_publishedAt(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.PublishedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.DateTime)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_siteName(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.SiteName, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.NameText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_favicon(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Favicon, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_language(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Language, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.LanguageCode)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _PageMetadata_openGraph(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.OpenGraph, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.OpenGraphMetadata)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._OpenGraphMetadata(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageMetadata_twitterCard(ctx context.Context, field graphql.CollectedField, obj *models.PageMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "PageMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.TwitterCard, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.TwitterCardMetadata)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._TwitterCardMetadata(ctx, field.Selections, res)
+}
+
+var personImplementors = []string{"Person", "Party"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Person(ctx context.Context, sel ast.SelectionSet, obj *models.Person) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, personImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Person")
+		case "id":
+			out.Values[i] = ec._Person_id(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._Person_name(ctx, field, obj)
+		case "firstName":
+			out.Values[i] = ec._Person_firstName(ctx, field, obj)
+		case "lastName":
+			out.Values[i] = ec._Person_lastName(ctx, field, obj)
+		case "users":
+			out.Values[i] = ec._Person_users(ctx, field, obj)
+		case "services":
+			out.Values[i] = ec._Person_services(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _Person_id(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	return graphql.MarshalID(res)
+}
+
+func (ec *executionContext) _Person_name(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.NameText)
+	return res
+}
+
+func (ec *executionContext) _Person_firstName(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.FirstName, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.NameText)
+	return res
+}
+
+func (ec *executionContext) _Person_lastName(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.LastName, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.NameText)
+	return res
+}
+
+func (ec *executionContext) _Person_users(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Users, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.UserIdentity)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._UserIdentity(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _Person_services(ctx context.Context, field graphql.CollectedField, obj *models.Person) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Person"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Services, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.ServiceIdentity)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._ServiceIdentity(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+var queryImplementors = []string{"Query"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, queryImplementors)
+
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+	})
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Query")
+		case "asymmetricCryptoPublicKey":
+			out.Values[i] = ec._Query_asymmetricCryptoPublicKey(ctx, field)
+		case "asymmetricCryptoPublicKeys":
+			out.Values[i] = ec._Query_asymmetricCryptoPublicKeys(ctx, field)
+		case "settingsBundles":
+			out.Values[i] = ec._Query_settingsBundles(ctx, field)
+		case "settingsBundle":
+			out.Values[i] = ec._Query_settingsBundle(ctx, field)
+		case "urlsInText":
+			out.Values[i] = ec._Query_urlsInText(ctx, field)
+		case "urlsInTexts":
+			out.Values[i] = ec._Query_urlsInTexts(ctx, field)
+		case "urlsInDocument":
+			out.Values[i] = ec._Query_urlsInDocument(ctx, field)
+		case "urlsInFile":
+			out.Values[i] = ec._Query_urlsInFile(ctx, field)
+		case "storageUsage":
+			out.Values[i] = ec._Query_storageUsage(ctx, field)
+		case "storageRetentionReport":
+			out.Values[i] = ec._Query_storageRetentionReport(ctx, field)
+		case "harvestJob":
+			out.Values[i] = ec._Query_harvestJob(ctx, field)
+		case "harvestRuleStats":
+			out.Values[i] = ec._Query_harvestRuleStats(ctx, field)
+		case "testHarvestRules":
+			out.Values[i] = ec._Query_testHarvestRules(ctx, field)
+		case "__type":
+			out.Values[i] = ec._Query___type(ctx, field)
+		case "__schema":
+			out.Values[i] = ec._Query___schema(ctx, field)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _Query_asymmetricCryptoPublicKey(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationClaimType
+	if tmp, ok := rawArgs["claimType"]; ok {
+		var err error
+		err = (&arg0).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["claimType"] = arg0
+	var arg1 models.AsymmetricCryptoPublicKeyName
+	if tmp, ok := rawArgs["keyId"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["keyId"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().AsymmetricCryptoPublicKey(ctx, args["claimType"].(models.AuthorizationClaimType), args["keyId"].(models.AsymmetricCryptoPublicKeyName))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(models.AuthorizationClaimCryptoKey)
+		return ec._AuthorizationClaimCryptoKey(ctx, field.Selections, &res)
+	})
+}
+
+func (ec *executionContext) _Query_asymmetricCryptoPublicKeys(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 *models.AuthorizationClaimType
+	if tmp, ok := rawArgs["claimType"]; ok {
+		var err error
+		var ptr1 models.AuthorizationClaimType
+		if tmp != nil {
+			err = (&ptr1).UnmarshalGQL(tmp)
+			arg0 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["claimType"] = arg0
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().AsymmetricCryptoPublicKeys(ctx, args["claimType"].(*models.AuthorizationClaimType))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.([]*models.AuthorizationClaimCryptoKey)
+		arr1 := graphql.Array{}
+		for idx1 := range res {
+			arr1 = append(arr1, func() graphql.Marshaler {
+				rctx := graphql.GetResolverContext(ctx)
+				rctx.PushIndex(idx1)
+				defer rctx.Pop()
+				if res[idx1] == nil {
+					return graphql.Null
+				}
+				return ec._AuthorizationClaimCryptoKey(ctx, field.Selections, res[idx1])
+			}())
+		}
+		return arr1
+	})
+}
+
+func (ec *executionContext) _Query_settingsBundles(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().SettingsBundles(ctx, args["authorization"].(models.PrivilegedAuthorizationInput))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.([]*models.SettingsBundle)
+		arr1 := graphql.Array{}
+		for idx1 := range res {
+			arr1 = append(arr1, func() graphql.Marshaler {
+				rctx := graphql.GetResolverContext(ctx)
+				rctx.PushIndex(idx1)
+				defer rctx.Pop()
+				if res[idx1] == nil {
+					return graphql.Null
+				}
+				return ec._SettingsBundle(ctx, field.Selections, res[idx1])
+			}())
+		}
+		return arr1
+	})
+}
+
+func (ec *executionContext) _Query_settingsBundle(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["name"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().SettingsBundle(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["name"].(models.SettingsBundleName))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.SettingsBundle)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._SettingsBundle(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_urlsInText(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.LargeText
+	if tmp, ok := rawArgs["text"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["text"] = arg1
+	var arg2 *models.HarvestDirectivesInput
+	if tmp, ok := rawArgs["directives"]; ok {
+		var err error
+		var ptr1 models.HarvestDirectivesInput
+		if tmp != nil {
+			ptr1, err = UnmarshalHarvestDirectivesInput(tmp)
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["directives"] = arg2
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().UrlsInText(ctx, args["authorization"].(models.AuthorizationInput), args["text"].(models.LargeText), args["directives"].(*models.HarvestDirectivesInput))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.HarvestedResources)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._HarvestedResources(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_urlsInTexts(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 []models.LargeText
+	if tmp, ok := rawArgs["texts"]; ok {
+		var err error
+		var rawIf1 []interface{}
+		if tmp != nil {
+			if tmp1, ok := tmp.([]interface{}); ok {
+				rawIf1 = tmp1
+			} else {
+				rawIf1 = []interface{}{tmp}
+			}
+		}
+		arg1 = make([]models.LargeText, len(rawIf1))
+		for idx1 := range rawIf1 {
+			err = (&arg1[idx1]).UnmarshalGQL(rawIf1[idx1])
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["texts"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().UrlsInTexts(ctx, args["authorization"].(models.AuthorizationInput), args["texts"].([]models.LargeText))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.([]*models.HarvestedResources)
+		arr1 := graphql.Array{}
+		for idx1 := range res {
+			arr1 = append(arr1, func() graphql.Marshaler {
+				rctx := graphql.GetResolverContext(ctx)
+				rctx.PushIndex(idx1)
+				defer rctx.Pop()
+				if res[idx1] == nil {
+					return graphql.Null
+				}
+				return ec._HarvestedResources(ctx, field.Selections, res[idx1])
+			}())
+		}
+		return arr1
+	})
+}
+
+func (ec *executionContext) _Query_urlsInDocument(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.Document
+	if tmp, ok := rawArgs["document"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["document"] = arg1
+	var arg2 models.DocumentFormat
+	if tmp, ok := rawArgs["format"]; ok {
+		var err error
+		err = (&arg2).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["format"] = arg2
+	var arg3 *models.HarvestDirectivesInput
+	if tmp, ok := rawArgs["directives"]; ok {
+		var err error
+		var ptr1 models.HarvestDirectivesInput
+		if tmp != nil {
+			ptr1, err = UnmarshalHarvestDirectivesInput(tmp)
+			arg3 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["directives"] = arg3
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().UrlsInDocument(ctx, args["authorization"].(models.AuthorizationInput), args["document"].(models.Document), args["format"].(models.DocumentFormat), args["directives"].(*models.HarvestDirectivesInput))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.HarvestedResources)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._HarvestedResources(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_urlsInFile(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.File
+	if tmp, ok := rawArgs["file"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["file"] = arg1
+	var arg2 *models.DocumentFormat
+	if tmp, ok := rawArgs["format"]; ok {
+		var err error
+		var ptr1 models.DocumentFormat
+		if tmp != nil {
 			err = (&ptr1).UnmarshalGQL(tmp)
-			arg0 = &ptr1
+			arg2 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["format"] = arg2
+	var arg3 *models.HarvestDirectivesInput
+	if tmp, ok := rawArgs["directives"]; ok {
+		var err error
+		var ptr1 models.HarvestDirectivesInput
+		if tmp != nil {
+			ptr1, err = UnmarshalHarvestDirectivesInput(tmp)
+			arg3 = &ptr1
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["directives"] = arg3
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().UrlsInFile(ctx, args["authorization"].(models.AuthorizationInput), args["file"].(models.File), args["format"].(*models.DocumentFormat), args["directives"].(*models.HarvestDirectivesInput))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.HarvestedResources)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._HarvestedResources(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_storageUsage(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().StorageUsage(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.StorageUsage)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._StorageUsage(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_storageRetentionReport(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().StorageRetentionReport(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.StorageRetentionReport)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._StorageRetentionReport(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_harvestJob(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.HarvestJobID
+	if tmp, ok := rawArgs["id"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["id"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().HarvestJob(ctx, args["authorization"].(models.AuthorizationInput), args["id"].(models.HarvestJobID))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.(*models.HarvestJob)
+		if res == nil {
+			return graphql.Null
+		}
+		return ec._HarvestJob(ctx, field.Selections, res)
+	})
+}
+
+func (ec *executionContext) _Query_harvestRuleStats(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().HarvestRuleStats(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.([]*models.HarvestRuleStats)
+		arr1 := graphql.Array{}
+		for idx1 := range res {
+			arr1 = append(arr1, func() graphql.Marshaler {
+				rctx := graphql.GetResolverContext(ctx)
+				rctx.PushIndex(idx1)
+				defer rctx.Pop()
+				if res[idx1] == nil {
+					return graphql.Null
+				}
+				return ec._HarvestRuleStats(ctx, field.Selections, res[idx1])
+			}())
+		}
+		return arr1
+	})
+}
+
+func (ec *executionContext) _Query_testHarvestRules(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.PrivilegedAuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.SettingsBundleName
+	if tmp, ok := rawArgs["bundle"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["bundle"] = arg1
+	var arg2 []models.URLText
+	if tmp, ok := rawArgs["urls"]; ok {
+		var err error
+		var rawIf1 []interface{}
+		if tmp != nil {
+			if tmp1, ok := tmp.([]interface{}); ok {
+				rawIf1 = tmp1
+			} else {
+				rawIf1 = []interface{}{tmp}
+			}
+		}
+		arg2 = make([]models.URLText, len(rawIf1))
+		for idx1 := range rawIf1 {
+			err = (&arg2[idx1]).UnmarshalGQL(rawIf1[idx1])
+		}
+
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["urls"] = arg2
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Query",
+		Args:   args,
+		Field:  field,
+	})
+	return graphql.Defer(func() (ret graphql.Marshaler) {
+		defer func() {
+			if r := recover(); r != nil {
+				userErr := ec.Recover(ctx, r)
+				ec.Error(ctx, userErr)
+				ret = graphql.Null
+			}
+		}()
+
+		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+			return ec.resolvers.Query().TestHarvestRules(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["bundle"].(models.SettingsBundleName), args["urls"].([]models.URLText))
+		})
+		if resTmp == nil {
+			return graphql.Null
+		}
+		res := resTmp.([]*models.HarvestRulesTest)
+		arr1 := graphql.Array{}
+		for idx1 := range res {
+			arr1 = append(arr1, func() graphql.Marshaler {
+				rctx := graphql.GetResolverContext(ctx)
+				rctx.PushIndex(idx1)
+				defer rctx.Pop()
+				if res[idx1] == nil {
+					return graphql.Null
+				}
+				return ec._HarvestRulesTest(ctx, field.Selections, res[idx1])
+			}())
+		}
+		return arr1
+	})
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		var err error
+		arg0, err = graphql.UnmarshalString(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return graphql.Null
+		}
+	}
+	args["name"] = arg0
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Query"
+	rctx.Args = args
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.introspectType(args["name"].(string)), nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec.___Type(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "Query"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return ec.introspectSchema(), nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec.___Schema(ctx, field.Selections, res)
+}
+
+var redirectHopImplementors = []string{"RedirectHop"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _RedirectHop(ctx context.Context, sel ast.SelectionSet, obj *models.RedirectHop) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, redirectHopImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RedirectHop")
+		case "url":
+			out.Values[i] = ec._RedirectHop_url(ctx, field, obj)
+		case "statusCode":
+			out.Values[i] = ec._RedirectHop_statusCode(ctx, field, obj)
+		case "location":
+			out.Values[i] = ec._RedirectHop_location(ctx, field, obj)
+		case "kind":
+			out.Values[i] = ec._RedirectHop_kind(ctx, field, obj)
+		case "latencyMilliseconds":
+			out.Values[i] = ec._RedirectHop_latencyMilliseconds(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _RedirectHop_url(ctx context.Context, field graphql.CollectedField, obj *models.RedirectHop) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RedirectHop"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.URL, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _RedirectHop_statusCode(ctx context.Context, field graphql.CollectedField, obj *models.RedirectHop) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RedirectHop"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.StatusCode, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HTTPStatusCode)
+	return res
+}
+
+func (ec *executionContext) _RedirectHop_location(ctx context.Context, field graphql.CollectedField, obj *models.RedirectHop) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RedirectHop"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Location, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.URLText)
+	return res
+}
+
+func (ec *executionContext) _RedirectHop_kind(ctx context.Context, field graphql.CollectedField, obj *models.RedirectHop) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RedirectHop"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Kind, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RedirectKind)
+	return res
+}
+
+func (ec *executionContext) _RedirectHop_latencyMilliseconds(ctx context.Context, field graphql.CollectedField, obj *models.RedirectHop) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RedirectHop"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.LatencyMilliseconds, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.LatencyMilliseconds)
+	return res
+}
+
+var removedQueryParamImplementors = []string{"RemovedQueryParam"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _RemovedQueryParam(ctx context.Context, sel ast.SelectionSet, obj *models.RemovedQueryParam) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, removedQueryParamImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RemovedQueryParam")
+		case "param":
+			out.Values[i] = ec._RemovedQueryParam_param(ctx, field, obj)
+		case "rule":
+			out.Values[i] = ec._RemovedQueryParam_rule(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _RemovedQueryParam_param(ctx context.Context, field graphql.CollectedField, obj *models.RemovedQueryParam) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RemovedQueryParam"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Param, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SmallText)
+	return res
+}
+
+func (ec *executionContext) _RemovedQueryParam_rule(ctx context.Context, field graphql.CollectedField, obj *models.RemovedQueryParam) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "RemovedQueryParam"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Rule, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.RuleIdentifier)
+	return res
+}
+
+var resourceDiscoveryImplementors = []string{"ResourceDiscovery"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _ResourceDiscovery(ctx context.Context, sel ast.SelectionSet, obj *models.ResourceDiscovery) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, resourceDiscoveryImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ResourceDiscovery")
+		case "offset":
+			out.Values[i] = ec._ResourceDiscovery_offset(ctx, field, obj)
+		case "length":
+			out.Values[i] = ec._ResourceDiscovery_length(ctx, field, obj)
+		case "anchorText":
+			out.Values[i] = ec._ResourceDiscovery_anchorText(ctx, field, obj)
+		case "contextSnippet":
+			out.Values[i] = ec._ResourceDiscovery_contextSnippet(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _ResourceDiscovery_offset(ctx context.Context, field graphql.CollectedField, obj *models.ResourceDiscovery) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ResourceDiscovery"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Offset, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.TextOffset)
+	return res
+}
+
+func (ec *executionContext) _ResourceDiscovery_length(ctx context.Context, field graphql.CollectedField, obj *models.ResourceDiscovery) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ResourceDiscovery"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Length, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.TextLength)
+	return res
+}
+
+func (ec *executionContext) _ResourceDiscovery_anchorText(ctx context.Context, field graphql.CollectedField, obj *models.ResourceDiscovery) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ResourceDiscovery"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.AnchorText, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _ResourceDiscovery_contextSnippet(ctx context.Context, field graphql.CollectedField, obj *models.ResourceDiscovery) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ResourceDiscovery"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ContextSnippet, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.MediumText)
+	return res
+}
+
+var serviceIdentityImplementors = []string{"ServiceIdentity", "AuthenticationIdentity"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _ServiceIdentity(ctx context.Context, sel ast.SelectionSet, obj *models.ServiceIdentity) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, serviceIdentityImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ServiceIdentity")
+		case "id":
+			out.Values[i] = ec._ServiceIdentity_id(ctx, field, obj)
+		case "type":
+			out.Values[i] = ec._ServiceIdentity_type(ctx, field, obj)
+		case "principal":
+			out.Values[i] = ec._ServiceIdentity_principal(ctx, field, obj)
+		case "key":
+			out.Values[i] = ec._ServiceIdentity_key(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _ServiceIdentity_id(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ServiceIdentity"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ID, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	return graphql.MarshalID(res)
+}
+
+func (ec *executionContext) _ServiceIdentity_type(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ServiceIdentity"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Type, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.AuthenticationType)
+	return res
+}
+
+func (ec *executionContext) _ServiceIdentity_principal(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ServiceIdentity"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Principal, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.IdentityPrincipal)
+	return res
+}
+
+func (ec *executionContext) _ServiceIdentity_key(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "ServiceIdentity"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Key, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.IdentityKey)
+	return res
+}
+
+var settingsBundleImplementors = []string{"SettingsBundle"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _SettingsBundle(ctx context.Context, sel ast.SelectionSet, obj *models.SettingsBundle) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, settingsBundleImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SettingsBundle")
+		case "name":
+			out.Values[i] = ec._SettingsBundle_name(ctx, field, obj)
+		case "storage":
+			out.Values[i] = ec._SettingsBundle_storage(ctx, field, obj)
+		case "harvest":
+			out.Values[i] = ec._SettingsBundle_harvest(ctx, field, obj)
+		case "errors":
+			out.Values[i] = ec._SettingsBundle_errors(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _SettingsBundle_name(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "SettingsBundle"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Name, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SettingsBundleName)
+	return res
+}
+
+func (ec *executionContext) _SettingsBundle_storage(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "SettingsBundle"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Storage, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageSettings)
+	return ec._StorageSettings(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _SettingsBundle_harvest(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "SettingsBundle"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Harvest, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.HarvestDirectivesSettings)
+	return ec._HarvestDirectivesSettings(ctx, field.Selections, &res)
+}
+
+func (ec *executionContext) _SettingsBundle_errors(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "SettingsBundle"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Errors, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.ErrorMessage)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return *res[idx1]
+		}())
+	}
+	return arr1
+}
+
+var storageBackupImplementors = []string{"StorageBackup"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageBackup(ctx context.Context, sel ast.SelectionSet, obj *models.StorageBackup) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageBackupImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageBackup")
+		case "bundle":
+			out.Values[i] = ec._StorageBackup_bundle(ctx, field, obj)
+		case "file":
+			out.Values[i] = ec._StorageBackup_file(ctx, field, obj)
+		case "storageType":
+			out.Values[i] = ec._StorageBackup_storageType(ctx, field, obj)
+		case "createdAt":
+			out.Values[i] = ec._StorageBackup_createdAt(ctx, field, obj)
+		case "records":
+			out.Values[i] = ec._StorageBackup_records(ctx, field, obj)
+		case "bytes":
+			out.Values[i] = ec._StorageBackup_bytes(ctx, field, obj)
+		case "checksum":
+			out.Values[i] = ec._StorageBackup_checksum(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageBackup_bundle(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bundle, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SettingsBundleName)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_file(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.File, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.FilePathAndName)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_storageType(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.StorageType, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageType)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_createdAt(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.CreatedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_records(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Records, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_bytes(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bytes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageBytesCount)
+	return res
+}
+
+func (ec *executionContext) _StorageBackup_checksum(ctx context.Context, field graphql.CollectedField, obj *models.StorageBackup) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageBackup"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Checksum, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.Checksum)
+	return res
+}
+
+var storageCollectionUsageImplementors = []string{"StorageCollectionUsage"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageCollectionUsage(ctx context.Context, sel ast.SelectionSet, obj *models.StorageCollectionUsage) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageCollectionUsageImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageCollectionUsage")
+		case "collection":
+			out.Values[i] = ec._StorageCollectionUsage_collection(ctx, field, obj)
+		case "records":
+			out.Values[i] = ec._StorageCollectionUsage_records(ctx, field, obj)
+		case "bytes":
+			out.Values[i] = ec._StorageCollectionUsage_bytes(ctx, field, obj)
+		case "quota":
+			out.Values[i] = ec._StorageCollectionUsage_quota(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageCollectionUsage_collection(ctx context.Context, field graphql.CollectedField, obj *models.StorageCollectionUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageCollectionUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Collection, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageKey)
+	return res
+}
+
+func (ec *executionContext) _StorageCollectionUsage_records(ctx context.Context, field graphql.CollectedField, obj *models.StorageCollectionUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageCollectionUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Records, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+func (ec *executionContext) _StorageCollectionUsage_bytes(ctx context.Context, field graphql.CollectedField, obj *models.StorageCollectionUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageCollectionUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bytes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageBytesCount)
+	return res
+}
+
+func (ec *executionContext) _StorageCollectionUsage_quota(ctx context.Context, field graphql.CollectedField, obj *models.StorageCollectionUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageCollectionUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Quota, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageQuotaSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageQuotaSettings(ctx, field.Selections, res)
+}
+
+var storageEncryptionSettingsImplementors = []string{"StorageEncryptionSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageEncryptionSettings(ctx context.Context, sel ast.SelectionSet, obj *models.StorageEncryptionSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageEncryptionSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageEncryptionSettings")
+		case "keyFile":
+			out.Values[i] = ec._StorageEncryptionSettings_keyFile(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageEncryptionSettings_keyFile(ctx context.Context, field graphql.CollectedField, obj *models.StorageEncryptionSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageEncryptionSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.KeyFile, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.FilePathAndName)
+	return res
+}
+
+var storageQuotaSettingsImplementors = []string{"StorageQuotaSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageQuotaSettings(ctx context.Context, sel ast.SelectionSet, obj *models.StorageQuotaSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageQuotaSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageQuotaSettings")
+		case "maxRecords":
+			out.Values[i] = ec._StorageQuotaSettings_maxRecords(ctx, field, obj)
+		case "maxBytes":
+			out.Values[i] = ec._StorageQuotaSettings_maxBytes(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageQuotaSettings_maxRecords(ctx context.Context, field graphql.CollectedField, obj *models.StorageQuotaSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageQuotaSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.MaxRecords, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageRecordsCount)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _StorageQuotaSettings_maxBytes(ctx context.Context, field graphql.CollectedField, obj *models.StorageQuotaSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageQuotaSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.MaxBytes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageBytesCount)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var storageRetentionReportImplementors = []string{"StorageRetentionReport"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageRetentionReport(ctx context.Context, sel ast.SelectionSet, obj *models.StorageRetentionReport) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageRetentionReportImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageRetentionReport")
+		case "bundle":
+			out.Values[i] = ec._StorageRetentionReport_bundle(ctx, field, obj)
+		case "evaluatedAt":
+			out.Values[i] = ec._StorageRetentionReport_evaluatedAt(ctx, field, obj)
+		case "dryRun":
+			out.Values[i] = ec._StorageRetentionReport_dryRun(ctx, field, obj)
+		case "examined":
+			out.Values[i] = ec._StorageRetentionReport_examined(ctx, field, obj)
+		case "expired":
+			out.Values[i] = ec._StorageRetentionReport_expired(ctx, field, obj)
+		case "records":
+			out.Values[i] = ec._StorageRetentionReport_records(ctx, field, obj)
+		case "expiredJobs":
+			out.Values[i] = ec._StorageRetentionReport_expiredJobs(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageRetentionReport_bundle(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bundle, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SettingsBundleName)
+	return res
+}
+
+func (ec *executionContext) _StorageRetentionReport_evaluatedAt(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.EvaluatedAt, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.DateTime)
+	return res
+}
+
+func (ec *executionContext) _StorageRetentionReport_dryRun(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.DryRun, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	return graphql.MarshalBoolean(res)
+}
+
+func (ec *executionContext) _StorageRetentionReport_examined(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Examined, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+func (ec *executionContext) _StorageRetentionReport_expired(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Expired, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+func (ec *executionContext) _StorageRetentionReport_records(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Records, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.ExpiredStorageRecord)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._ExpiredStorageRecord(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+func (ec *executionContext) _StorageRetentionReport_expiredJobs(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionReport) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionReport"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ExpiredJobs, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+var storageRetentionRuleImplementors = []string{"StorageRetentionRule"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageRetentionRule(ctx context.Context, sel ast.SelectionSet, obj *models.StorageRetentionRule) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageRetentionRuleImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageRetentionRule")
+		case "kind":
+			out.Values[i] = ec._StorageRetentionRule_kind(ctx, field, obj)
+		case "collection":
+			out.Values[i] = ec._StorageRetentionRule_collection(ctx, field, obj)
+		case "key":
+			out.Values[i] = ec._StorageRetentionRule_key(ctx, field, obj)
+		case "keepDays":
+			out.Values[i] = ec._StorageRetentionRule_keepDays(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
+	}
 
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
+	return out
+}
+
+func (ec *executionContext) _StorageRetentionRule_kind(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Kind, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.SavedResourceKind)
+	return res
+}
+
+func (ec *executionContext) _StorageRetentionRule_collection(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Collection, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageDestinationCollection)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _StorageRetentionRule_key(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Key, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageKey)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _StorageRetentionRule_keepDays(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionRule) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionRule"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.KeepDays, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.RetentionDays)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var storageRetentionSettingsImplementors = []string{"StorageRetentionSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageRetentionSettings(ctx context.Context, sel ast.SelectionSet, obj *models.StorageRetentionSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageRetentionSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageRetentionSettings")
+		case "rules":
+			out.Values[i] = ec._StorageRetentionSettings_rules(ctx, field, obj)
+		case "jobsKeepDays":
+			out.Values[i] = ec._StorageRetentionSettings_jobsKeepDays(ctx, field, obj)
+		case "sweepIntervalMinutes":
+			out.Values[i] = ec._StorageRetentionSettings_sweepIntervalMinutes(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
 	}
-	args["claimType"] = arg0
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
-		Field:  field,
+
+	return out
+}
+
+func (ec *executionContext) _StorageRetentionSettings_rules(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Rules, nil
 	})
-	return graphql.Defer(func() (ret graphql.Marshaler) {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := ec.Recover(ctx, r)
-				ec.Error(ctx, userErr)
-				ret = graphql.Null
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.StorageRetentionRule)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
 			}
-		}()
+			return ec._StorageRetentionRule(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
 
-		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.Query().AsymmetricCryptoPublicKeys(ctx, args["claimType"].(*models.AuthorizationClaimType))
-		})
-		if resTmp == nil {
-			return graphql.Null
-		}
-		res := resTmp.([]*models.AuthorizationClaimCryptoKey)
-		arr1 := graphql.Array{}
-		for idx1 := range res {
-			arr1 = append(arr1, func() graphql.Marshaler {
-				rctx := graphql.GetResolverContext(ctx)
-				rctx.PushIndex(idx1)
-				defer rctx.Pop()
-				if res[idx1] == nil {
-					return graphql.Null
-				}
-				return ec._AuthorizationClaimCryptoKey(ctx, field.Selections, res[idx1])
-			}())
+func (ec *executionContext) _StorageRetentionSettings_jobsKeepDays(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.JobsKeepDays, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.RetentionDays)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _StorageRetentionSettings_sweepIntervalMinutes(ctx context.Context, field graphql.CollectedField, obj *models.StorageRetentionSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageRetentionSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.SweepIntervalMinutes, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SweepIntervalMinutes)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+var storageSettingsImplementors = []string{"StorageSettings"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageSettings(ctx context.Context, sel ast.SelectionSet, obj *models.StorageSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageSettingsImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageSettings")
+		case "type":
+			out.Values[i] = ec._StorageSettings_type(ctx, field, obj)
+		case "filesys":
+			out.Values[i] = ec._StorageSettings_filesys(ctx, field, obj)
+		case "encryption":
+			out.Values[i] = ec._StorageSettings_encryption(ctx, field, obj)
+		case "backupsPath":
+			out.Values[i] = ec._StorageSettings_backupsPath(ctx, field, obj)
+		case "archivesPath":
+			out.Values[i] = ec._StorageSettings_archivesPath(ctx, field, obj)
+		case "quota":
+			out.Values[i] = ec._StorageSettings_quota(ctx, field, obj)
+		case "tenantQuota":
+			out.Values[i] = ec._StorageSettings_tenantQuota(ctx, field, obj)
+		case "retention":
+			out.Values[i] = ec._StorageSettings_retention(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
-		return arr1
+	}
+
+	return out
+}
+
+func (ec *executionContext) _StorageSettings_type(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Type, nil
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.StorageType)
+	return res
 }
 
-func (ec *executionContext) _Query_settingsBundles(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
-	}
-	args["authorization"] = arg0
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
-		Field:  field,
+func (ec *executionContext) _StorageSettings_filesys(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Filesys, nil
 	})
-	return graphql.Defer(func() (ret graphql.Marshaler) {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := ec.Recover(ctx, r)
-				ec.Error(ctx, userErr)
-				ret = graphql.Null
-			}
-		}()
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.FileStorageSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._FileStorageSettings(ctx, field.Selections, res)
+}
 
-		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.Query().SettingsBundles(ctx, args["authorization"].(models.PrivilegedAuthorizationInput))
-		})
-		if resTmp == nil {
-			return graphql.Null
-		}
-		res := resTmp.([]*models.SettingsBundle)
-		arr1 := graphql.Array{}
-		for idx1 := range res {
-			arr1 = append(arr1, func() graphql.Marshaler {
-				rctx := graphql.GetResolverContext(ctx)
-				rctx.PushIndex(idx1)
-				defer rctx.Pop()
-				if res[idx1] == nil {
-					return graphql.Null
-				}
-				return ec._SettingsBundle(ctx, field.Selections, res[idx1])
-			}())
-		}
-		return arr1
+func (ec *executionContext) _StorageSettings_encryption(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Encryption, nil
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageEncryptionSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageEncryptionSettings(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_settingsBundle(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.PrivilegedAuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalPrivilegedAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+func (ec *executionContext) _StorageSettings_backupsPath(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.BackupsPath, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
 	}
-	args["authorization"] = arg0
-	var arg1 models.SettingsBundleName
-	if tmp, ok := rawArgs["name"]; ok {
-		var err error
-		err = (&arg1).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+	res := resTmp.(*models.DirectoryPath)
+	if res == nil {
+		return graphql.Null
 	}
-	args["name"] = arg1
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
-		Field:  field,
+	return *res
+}
+
+func (ec *executionContext) _StorageSettings_archivesPath(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ArchivesPath, nil
 	})
-	return graphql.Defer(func() (ret graphql.Marshaler) {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := ec.Recover(ctx, r)
-				ec.Error(ctx, userErr)
-				ret = graphql.Null
-			}
-		}()
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.DirectoryPath)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
 
-		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.Query().SettingsBundle(ctx, args["authorization"].(models.PrivilegedAuthorizationInput), args["name"].(models.SettingsBundleName))
-		})
-		if resTmp == nil {
-			return graphql.Null
-		}
-		res := resTmp.(*models.SettingsBundle)
-		if res == nil {
-			return graphql.Null
-		}
-		return ec._SettingsBundle(ctx, field.Selections, res)
+func (ec *executionContext) _StorageSettings_quota(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Quota, nil
 	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageQuotaSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageQuotaSettings(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_urlsInText(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 models.AuthorizationInput
-	if tmp, ok := rawArgs["authorization"]; ok {
-		var err error
-		arg0, err = UnmarshalAuthorizationInput(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+func (ec *executionContext) _StorageSettings_tenantQuota(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.TenantQuota, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
 	}
-	args["authorization"] = arg0
-	var arg1 models.LargeText
-	if tmp, ok := rawArgs["text"]; ok {
-		var err error
-		err = (&arg1).UnmarshalGQL(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+	res := resTmp.(*models.StorageQuotaSettings)
+	if res == nil {
+		return graphql.Null
 	}
-	args["text"] = arg1
-	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
-		Object: "Query",
-		Args:   args,
-		Field:  field,
+	return ec._StorageQuotaSettings(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _StorageSettings_retention(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Retention, nil
 	})
-	return graphql.Defer(func() (ret graphql.Marshaler) {
-		defer func() {
-			if r := recover(); r != nil {
-				userErr := ec.Recover(ctx, r)
-				ec.Error(ctx, userErr)
-				ret = graphql.Null
-			}
-		}()
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.StorageRetentionSettings)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._StorageRetentionSettings(ctx, field.Selections, res)
+}
 
-		resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-			return ec.resolvers.Query().UrlsInText(ctx, args["authorization"].(models.AuthorizationInput), args["text"].(models.LargeText))
-		})
-		if resTmp == nil {
-			return graphql.Null
-		}
-		res := resTmp.(*models.HarvestedResources)
-		if res == nil {
-			return graphql.Null
+var storageUsageImplementors = []string{"StorageUsage"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _StorageUsage(ctx context.Context, sel ast.SelectionSet, obj *models.StorageUsage) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, storageUsageImplementors)
+
+	out := graphql.NewOrderedMap(len(fields))
+	for i, field := range fields {
+		out.Keys[i] = field.Alias
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("StorageUsage")
+		case "bundle":
+			out.Values[i] = ec._StorageUsage_bundle(ctx, field, obj)
+		case "records":
+			out.Values[i] = ec._StorageUsage_records(ctx, field, obj)
+		case "bytes":
+			out.Values[i] = ec._StorageUsage_bytes(ctx, field, obj)
+		case "quota":
+			out.Values[i] = ec._StorageUsage_quota(ctx, field, obj)
+		case "collections":
+			out.Values[i] = ec._StorageUsage_collections(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
 		}
-		return ec._HarvestedResources(ctx, field.Selections, res)
-	})
+	}
+
+	return out
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		var err error
-		arg0, err = graphql.UnmarshalString(tmp)
-		if err != nil {
-			ec.Error(ctx, err)
-			return graphql.Null
-		}
+func (ec *executionContext) _StorageUsage_bundle(ctx context.Context, field graphql.CollectedField, obj *models.StorageUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bundle, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
 	}
-	args["name"] = arg0
+	res := resTmp.(models.SettingsBundleName)
+	return res
+}
+
+func (ec *executionContext) _StorageUsage_records(ctx context.Context, field graphql.CollectedField, obj *models.StorageUsage) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Query"
-	rctx.Args = args
+	rctx.Object = "StorageUsage"
+	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.introspectType(args["name"].(string)), nil
+		return obj.Records, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
-	if res == nil {
+	res := resTmp.(models.StorageRecordsCount)
+	return res
+}
+
+func (ec *executionContext) _StorageUsage_bytes(ctx context.Context, field graphql.CollectedField, obj *models.StorageUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Bytes, nil
+	})
+	if resTmp == nil {
 		return graphql.Null
 	}
-	return ec.___Type(ctx, field.Selections, res)
+	res := resTmp.(models.StorageBytesCount)
+	return res
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) graphql.Marshaler {
+func (ec *executionContext) _StorageUsage_quota(ctx context.Context, field graphql.CollectedField, obj *models.StorageUsage) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Query"
+	rctx.Object = "StorageUsage"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return ec.introspectSchema(), nil
+		return obj.Quota, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*models.StorageQuotaSettings)
 	if res == nil {
 		return graphql.Null
 	}
-	return ec.___Schema(ctx, field.Selections, res)
+	return ec._StorageQuotaSettings(ctx, field.Selections, res)
 }
 
-var serviceIdentityImplementors = []string{"ServiceIdentity", "AuthenticationIdentity"}
+func (ec *executionContext) _StorageUsage_collections(ctx context.Context, field graphql.CollectedField, obj *models.StorageUsage) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "StorageUsage"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Collections, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*models.StorageCollectionUsage)
+	arr1 := graphql.Array{}
+	for idx1 := range res {
+		arr1 = append(arr1, func() graphql.Marshaler {
+			rctx := graphql.GetResolverContext(ctx)
+			rctx.PushIndex(idx1)
+			defer rctx.Pop()
+			if res[idx1] == nil {
+				return graphql.Null
+			}
+			return ec._StorageCollectionUsage(ctx, field.Selections, res[idx1])
+		}())
+	}
+	return arr1
+}
+
+var subscriptionImplementors = []string{"Subscription"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _ServiceIdentity(ctx context.Context, sel ast.SelectionSet, obj *models.ServiceIdentity) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, serviceIdentityImplementors)
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func() graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, subscriptionImplementors)
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		ec.Errorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "harvestProgress":
+		return ec._Subscription_harvestProgress(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
+}
+
+func (ec *executionContext) _Subscription_harvestProgress(ctx context.Context, field graphql.CollectedField) func() graphql.Marshaler {
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args := map[string]interface{}{}
+	var arg0 models.AuthorizationInput
+	if tmp, ok := rawArgs["authorization"]; ok {
+		var err error
+		arg0, err = UnmarshalAuthorizationInput(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return nil
+		}
+	}
+	args["authorization"] = arg0
+	var arg1 models.HarvestJobID
+	if tmp, ok := rawArgs["jobId"]; ok {
+		var err error
+		err = (&arg1).UnmarshalGQL(tmp)
+		if err != nil {
+			ec.Error(ctx, err)
+			return nil
+		}
+	}
+	args["jobId"] = arg1
+	ctx = graphql.WithResolverContext(ctx, &graphql.ResolverContext{
+		Field: field,
+	})
+	results, err := ec.resolvers.Subscription().HarvestProgress(ctx, args["authorization"].(models.AuthorizationInput), args["jobId"].(models.HarvestJobID))
+	if err != nil {
+		ec.Error(ctx, err)
+		return nil
+	}
+	return func() graphql.Marshaler {
+		res, ok := <-results
+		if !ok {
+			return nil
+		}
+		var out graphql.OrderedMap
+		out.Add(field.Alias, func() graphql.Marshaler {
+			return ec._HarvestProgressEvent(ctx, field.Selections, &res)
+		}())
+		return &out
+	}
+}
+
+var tenantImplementors = []string{"Tenant", "Party"}
+
+// nolint: gocyclo, errcheck, gas, goconst
+func (ec *executionContext) _Tenant(ctx context.Context, sel ast.SelectionSet, obj *models.Tenant) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, tenantImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -1623,15 +6756,13 @@ func (ec *executionContext) _ServiceIdentity(ctx context.Context, sel ast.Select
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("ServiceIdentity")
+			out.Values[i] = graphql.MarshalString("Tenant")
 		case "id":
-			out.Values[i] = ec._ServiceIdentity_id(ctx, field, obj)
-		case "type":
-			out.Values[i] = ec._ServiceIdentity_type(ctx, field, obj)
-		case "principal":
-			out.Values[i] = ec._ServiceIdentity_principal(ctx, field, obj)
-		case "key":
-			out.Values[i] = ec._ServiceIdentity_key(ctx, field, obj)
+			out.Values[i] = ec._Tenant_id(ctx, field, obj)
+		case "name":
+			out.Values[i] = ec._Tenant_name(ctx, field, obj)
+		case "org":
+			out.Values[i] = ec._Tenant_org(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -1640,9 +6771,9 @@ func (ec *executionContext) _ServiceIdentity(ctx context.Context, sel ast.Select
 	return out
 }
 
-func (ec *executionContext) _ServiceIdentity_id(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+func (ec *executionContext) _Tenant_id(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "ServiceIdentity"
+	rctx.Object = "Tenant"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
@@ -1657,62 +6788,45 @@ func (ec *executionContext) _ServiceIdentity_id(ctx context.Context, field graph
 	return graphql.MarshalID(res)
 }
 
-func (ec *executionContext) _ServiceIdentity_type(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
-	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "ServiceIdentity"
-	rctx.Args = nil
-	rctx.Field = field
-	rctx.PushField(field.Alias)
-	defer rctx.Pop()
-	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Type, nil
-	})
-	if resTmp == nil {
-		return graphql.Null
-	}
-	res := resTmp.(models.AuthenticationType)
-	return res
-}
-
-func (ec *executionContext) _ServiceIdentity_principal(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+func (ec *executionContext) _Tenant_name(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "ServiceIdentity"
+	rctx.Object = "Tenant"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Principal, nil
+		return obj.Name, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.IdentityPrincipal)
+	res := resTmp.(models.NameText)
 	return res
 }
 
-func (ec *executionContext) _ServiceIdentity_key(ctx context.Context, field graphql.CollectedField, obj *models.ServiceIdentity) graphql.Marshaler {
+func (ec *executionContext) _Tenant_org(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "ServiceIdentity"
+	rctx.Object = "Tenant"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Key, nil
+		return obj.Org, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.IdentityKey)
-	return res
+	res := resTmp.(models.Organization)
+	return ec._Organization(ctx, field.Selections, &res)
 }
 
-var settingsBundleImplementors = []string{"SettingsBundle"}
+var twitterCardMetadataImplementors = []string{"TwitterCardMetadata"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _SettingsBundle(ctx context.Context, sel ast.SelectionSet, obj *models.SettingsBundle) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, settingsBundleImplementors)
+func (ec *executionContext) _TwitterCardMetadata(ctx context.Context, sel ast.SelectionSet, obj *models.TwitterCardMetadata) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, twitterCardMetadataImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -1720,15 +6834,19 @@ func (ec *executionContext) _SettingsBundle(ctx context.Context, sel ast.Selecti
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("SettingsBundle")
-		case "name":
-			out.Values[i] = ec._SettingsBundle_name(ctx, field, obj)
-		case "storage":
-			out.Values[i] = ec._SettingsBundle_storage(ctx, field, obj)
-		case "harvest":
-			out.Values[i] = ec._SettingsBundle_harvest(ctx, field, obj)
-		case "errors":
-			out.Values[i] = ec._SettingsBundle_errors(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("TwitterCardMetadata")
+		case "card":
+			out.Values[i] = ec._TwitterCardMetadata_card(ctx, field, obj)
+		case "site":
+			out.Values[i] = ec._TwitterCardMetadata_site(ctx, field, obj)
+		case "creator":
+			out.Values[i] = ec._TwitterCardMetadata_creator(ctx, field, obj)
+		case "title":
+			out.Values[i] = ec._TwitterCardMetadata_title(ctx, field, obj)
+		case "description":
+			out.Values[i] = ec._TwitterCardMetadata_description(ctx, field, obj)
+		case "image":
+			out.Values[i] = ec._TwitterCardMetadata_image(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -1737,91 +6855,131 @@ func (ec *executionContext) _SettingsBundle(ctx context.Context, sel ast.Selecti
 	return out
 }
 
-func (ec *executionContext) _SettingsBundle_name(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+func (ec *executionContext) _TwitterCardMetadata_card(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "SettingsBundle"
+	rctx.Object = "TwitterCardMetadata"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Name, nil
+		return obj.Card, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.SettingsBundleName)
-	return res
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _SettingsBundle_storage(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+func (ec *executionContext) _TwitterCardMetadata_site(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "SettingsBundle"
+	rctx.Object = "TwitterCardMetadata"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Storage, nil
+		return obj.Site, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.StorageSettings)
-	return ec._StorageSettings(ctx, field.Selections, &res)
+	res := resTmp.(*models.NameText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _SettingsBundle_harvest(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+func (ec *executionContext) _TwitterCardMetadata_creator(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "SettingsBundle"
+	rctx.Object = "TwitterCardMetadata"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Harvest, nil
+		return obj.Creator, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.HarvestDirectivesSettings)
-	return ec._HarvestDirectivesSettings(ctx, field.Selections, &res)
+	res := resTmp.(*models.NameText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _SettingsBundle_errors(ctx context.Context, field graphql.CollectedField, obj *models.SettingsBundle) graphql.Marshaler {
+func (ec *executionContext) _TwitterCardMetadata_title(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "SettingsBundle"
+	rctx.Object = "TwitterCardMetadata"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Errors, nil
+		return obj.Title, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.SmallText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _TwitterCardMetadata_description(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "TwitterCardMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Description, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.MediumText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _TwitterCardMetadata_image(ctx context.Context, field graphql.CollectedField, obj *models.TwitterCardMetadata) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "TwitterCardMetadata"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Image, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*models.ErrorMessage)
-	arr1 := graphql.Array{}
-	for idx1 := range res {
-		arr1 = append(arr1, func() graphql.Marshaler {
-			rctx := graphql.GetResolverContext(ctx)
-			rctx.PushIndex(idx1)
-			defer rctx.Pop()
-			if res[idx1] == nil {
-				return graphql.Null
-			}
-			return *res[idx1]
-		}())
-	}
-	return arr1
+	res := resTmp.(*models.URLText)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-var storageSettingsImplementors = []string{"StorageSettings"}
+var uRLNormalizationSettingsImplementors = []string{"URLNormalizationSettings"}
 
 // nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _StorageSettings(ctx context.Context, sel ast.SelectionSet, obj *models.StorageSettings) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, storageSettingsImplementors)
+func (ec *executionContext) _URLNormalizationSettings(ctx context.Context, sel ast.SelectionSet, obj *models.URLNormalizationSettings) graphql.Marshaler {
+	fields := graphql.CollectFields(ctx, sel, uRLNormalizationSettingsImplementors)
 
 	out := graphql.NewOrderedMap(len(fields))
 	for i, field := range fields {
@@ -1829,11 +6987,21 @@ func (ec *executionContext) _StorageSettings(ctx context.Context, sel ast.Select
 
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("StorageSettings")
-		case "type":
-			out.Values[i] = ec._StorageSettings_type(ctx, field, obj)
-		case "filesys":
-			out.Values[i] = ec._StorageSettings_filesys(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("URLNormalizationSettings")
+		case "lowercaseSchemeAndHost":
+			out.Values[i] = ec._URLNormalizationSettings_lowercaseSchemeAndHost(ctx, field, obj)
+		case "removeDefaultPort":
+			out.Values[i] = ec._URLNormalizationSettings_removeDefaultPort(ctx, field, obj)
+		case "removeFragment":
+			out.Values[i] = ec._URLNormalizationSettings_removeFragment(ctx, field, obj)
+		case "trailingSlash":
+			out.Values[i] = ec._URLNormalizationSettings_trailingSlash(ctx, field, obj)
+		case "sortQueryParams":
+			out.Values[i] = ec._URLNormalizationSettings_sortQueryParams(ctx, field, obj)
+		case "punycodeHost":
+			out.Values[i] = ec._URLNormalizationSettings_punycodeHost(ctx, field, obj)
+		case "foldWWW":
+			out.Values[i] = ec._URLNormalizationSettings_foldWWW(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -1842,119 +7010,144 @@ func (ec *executionContext) _StorageSettings(ctx context.Context, sel ast.Select
 	return out
 }
 
-func (ec *executionContext) _StorageSettings_type(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+func (ec *executionContext) _URLNormalizationSettings_lowercaseSchemeAndHost(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "StorageSettings"
+	rctx.Object = "URLNormalizationSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Type, nil
+		return obj.LowercaseSchemeAndHost, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.StorageType)
-	return res
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalBoolean(*res)
 }
 
-func (ec *executionContext) _StorageSettings_filesys(ctx context.Context, field graphql.CollectedField, obj *models.StorageSettings) graphql.Marshaler {
+func (ec *executionContext) _URLNormalizationSettings_removeDefaultPort(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "StorageSettings"
+	rctx.Object = "URLNormalizationSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Filesys, nil
+		return obj.RemoveDefaultPort, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*models.FileStorageSettings)
+	res := resTmp.(*bool)
 	if res == nil {
 		return graphql.Null
 	}
-	return ec._FileStorageSettings(ctx, field.Selections, res)
+	return graphql.MarshalBoolean(*res)
 }
 
-var tenantImplementors = []string{"Tenant", "Party"}
-
-// nolint: gocyclo, errcheck, gas, goconst
-func (ec *executionContext) _Tenant(ctx context.Context, sel ast.SelectionSet, obj *models.Tenant) graphql.Marshaler {
-	fields := graphql.CollectFields(ctx, sel, tenantImplementors)
-
-	out := graphql.NewOrderedMap(len(fields))
-	for i, field := range fields {
-		out.Keys[i] = field.Alias
-
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Tenant")
-		case "id":
-			out.Values[i] = ec._Tenant_id(ctx, field, obj)
-		case "name":
-			out.Values[i] = ec._Tenant_name(ctx, field, obj)
-		case "org":
-			out.Values[i] = ec._Tenant_org(ctx, field, obj)
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
+func (ec *executionContext) _URLNormalizationSettings_removeFragment(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "URLNormalizationSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.RemoveFragment, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
 	}
+	return graphql.MarshalBoolean(*res)
+}
 
-	return out
+func (ec *executionContext) _URLNormalizationSettings_trailingSlash(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "URLNormalizationSettings"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.TrailingSlash, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.TrailingSlashPolicy)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
 }
 
-func (ec *executionContext) _Tenant_id(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
+func (ec *executionContext) _URLNormalizationSettings_sortQueryParams(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Tenant"
+	rctx.Object = "URLNormalizationSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.ID, nil
+		return obj.SortQueryParams, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
-	return graphql.MarshalID(res)
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalBoolean(*res)
 }
 
-func (ec *executionContext) _Tenant_name(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
+func (ec *executionContext) _URLNormalizationSettings_punycodeHost(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Tenant"
+	rctx.Object = "URLNormalizationSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Name, nil
+		return obj.PunycodeHost, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.NameText)
-	return res
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalBoolean(*res)
 }
 
-func (ec *executionContext) _Tenant_org(ctx context.Context, field graphql.CollectedField, obj *models.Tenant) graphql.Marshaler {
+func (ec *executionContext) _URLNormalizationSettings_foldWWW(ctx context.Context, field graphql.CollectedField, obj *models.URLNormalizationSettings) graphql.Marshaler {
 	rctx := graphql.GetResolverContext(ctx)
-	rctx.Object = "Tenant"
+	rctx.Object = "URLNormalizationSettings"
 	rctx.Args = nil
 	rctx.Field = field
 	rctx.PushField(field.Alias)
 	defer rctx.Pop()
 	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
-		return obj.Org, nil
+		return obj.FoldWWW, nil
 	})
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(models.Organization)
-	return ec._Organization(ctx, field.Selections, &res)
+	res := resTmp.(*bool)
+	if res == nil {
+		return graphql.Null
+	}
+	return graphql.MarshalBoolean(*res)
 }
 
 var unharvestedResourceImplementors = []string{"UnharvestedResource"}
@@ -1974,6 +7167,12 @@ func (ec *executionContext) _UnharvestedResource(ctx context.Context, sel ast.Se
 			out.Values[i] = ec._UnharvestedResource_url(ctx, field, obj)
 		case "reason":
 			out.Values[i] = ec._UnharvestedResource_reason(ctx, field, obj)
+		case "reasonCode":
+			out.Values[i] = ec._UnharvestedResource_reasonCode(ctx, field, obj)
+		case "httpStatus":
+			out.Values[i] = ec._UnharvestedResource_httpStatus(ctx, field, obj)
+		case "discovery":
+			out.Values[i] = ec._UnharvestedResource_discovery(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2016,6 +7215,63 @@ func (ec *executionContext) _UnharvestedResource_reason(ctx context.Context, fie
 	return res
 }
 
+func (ec *executionContext) _UnharvestedResource_reasonCode(ctx context.Context, field graphql.CollectedField, obj *models.UnharvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "UnharvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.ReasonCode, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(models.ReasonCode)
+	return res
+}
+
+func (ec *executionContext) _UnharvestedResource_httpStatus(ctx context.Context, field graphql.CollectedField, obj *models.UnharvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "UnharvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.HTTPStatus, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.HTTPStatusCode)
+	if res == nil {
+		return graphql.Null
+	}
+	return *res
+}
+
+func (ec *executionContext) _UnharvestedResource_discovery(ctx context.Context, field graphql.CollectedField, obj *models.UnharvestedResource) graphql.Marshaler {
+	rctx := graphql.GetResolverContext(ctx)
+	rctx.Object = "UnharvestedResource"
+	rctx.Args = nil
+	rctx.Field = field
+	rctx.PushField(field.Alias)
+	defer rctx.Pop()
+	resTmp := ec.FieldMiddleware(ctx, func(ctx context.Context) (interface{}, error) {
+		return obj.Discovery, nil
+	})
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*models.ResourceDiscovery)
+	if res == nil {
+		return graphql.Null
+	}
+	return ec._ResourceDiscovery(ctx, field.Selections, res)
+}
+
 var userIdentityImplementors = []string{"UserIdentity", "AuthenticationIdentity"}
 
 // nolint: gocyclo, errcheck, gas, goconst
@@ -3036,6 +8292,31 @@ func (ec *executionContext) _AuthorizationClaimCryptoKey(ctx context.Context, se
 	}
 }
 
+func (ec *executionContext) _HarvestProgressEvent(ctx context.Context, sel ast.SelectionSet, obj *models.HarvestProgressEvent) graphql.Marshaler {
+	switch obj := (*obj).(type) {
+	case nil:
+		return graphql.Null
+	case models.HarvestedResource:
+		return ec._HarvestedResource(ctx, sel, &obj)
+	case *models.HarvestedResource:
+		return ec._HarvestedResource(ctx, sel, obj)
+	case models.IgnoredResource:
+		return ec._IgnoredResource(ctx, sel, &obj)
+	case *models.IgnoredResource:
+		return ec._IgnoredResource(ctx, sel, obj)
+	case models.UnharvestedResource:
+		return ec._UnharvestedResource(ctx, sel, &obj)
+	case *models.UnharvestedResource:
+		return ec._UnharvestedResource(ctx, sel, obj)
+	case models.HarvestJob:
+		return ec._HarvestJob(ctx, sel, &obj)
+	case *models.HarvestJob:
+		return ec._HarvestJob(ctx, sel, obj)
+	default:
+		panic(fmt.Errorf("unexpected type %T", obj))
+	}
+}
+
 func (ec *executionContext) _Party(ctx context.Context, sel ast.SelectionSet, obj *models.Party) graphql.Marshaler {
 	switch obj := (*obj).(type) {
 	case nil:
@@ -3096,6 +8377,87 @@ func UnmarshalAuthorizationInput(v interface{}) (models.AuthorizationInput, erro
 	return it, nil
 }
 
+func UnmarshalHarvestDirectivesInput(v interface{}) (models.HarvestDirectivesInput, error) {
+	var it models.HarvestDirectivesInput
+	var asMap = v.(map[string]interface{})
+
+	for k, v := range asMap {
+		switch k {
+		case "ignoreURLsRegExprs":
+			var err error
+			var rawIf1 []interface{}
+			if v != nil {
+				if tmp1, ok := v.([]interface{}); ok {
+					rawIf1 = tmp1
+				} else {
+					rawIf1 = []interface{}{v}
+				}
+			}
+			it.IgnoreURLsRegExprs = make([]models.RegularExpression, len(rawIf1))
+			for idx1 := range rawIf1 {
+				err = (&it.IgnoreURLsRegExprs[idx1]).UnmarshalGQL(rawIf1[idx1])
+			}
+
+			if err != nil {
+				return it, err
+			}
+		case "removeParamsFromURLsRegEx":
+			var err error
+			var rawIf1 []interface{}
+			if v != nil {
+				if tmp1, ok := v.([]interface{}); ok {
+					rawIf1 = tmp1
+				} else {
+					rawIf1 = []interface{}{v}
+				}
+			}
+			it.RemoveParamsFromURLsRegEx = make([]models.RegularExpression, len(rawIf1))
+			for idx1 := range rawIf1 {
+				err = (&it.RemoveParamsFromURLsRegEx[idx1]).UnmarshalGQL(rawIf1[idx1])
+			}
+
+			if err != nil {
+				return it, err
+			}
+		case "followHTMLRedirects":
+			var err error
+			var ptr1 bool
+			if v != nil {
+				ptr1, err = graphql.UnmarshalBoolean(v)
+				it.FollowHTMLRedirects = &ptr1
+			}
+
+			if err != nil {
+				return it, err
+			}
+		case "respectRobotsTxt":
+			var err error
+			var ptr1 bool
+			if v != nil {
+				ptr1, err = graphql.UnmarshalBoolean(v)
+				it.RespectRobotsTxt = &ptr1
+			}
+
+			if err != nil {
+				return it, err
+			}
+		case "archive":
+			var err error
+			var ptr1 bool
+			if v != nil {
+				ptr1, err = graphql.UnmarshalBoolean(v)
+				it.Archive = &ptr1
+			}
+
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func UnmarshalPrivilegedAuthorizationInput(v interface{}) (models.PrivilegedAuthorizationInput, error) {
 	var it models.PrivilegedAuthorizationInput
 	var asMap = v.(map[string]interface{})
@@ -3203,6 +8565,32 @@ scalar IdentityPassword
 scalar IdentityKey
 
 scalar StorageKey
+scalar StorageRecordsCount
+scalar StorageBytesCount
+scalar Checksum
+scalar RetentionDays
+scalar SweepIntervalMinutes
+scalar HarvestJobID
+scalar HarvestJobWorkersCount
+scalar ResourcesCount
+scalar ConcurrencyLimit
+scalar TimeoutSeconds
+scalar RedirectsCount
+scalar UserAgent
+scalar ContentType
+scalar BodySizeBytes
+scalar DelayMilliseconds
+scalar CacheTTLMinutes
+scalar HTTPStatusCode
+scalar LatencyMilliseconds
+scalar RuleIdentifier
+scalar HitsCount
+scalar DomainName
+scalar LanguageCode
+scalar WordsCount
+scalar ReadingTimeMinutes
+scalar TextOffset
+scalar TextLength
 scalar SettingsBundleName
 
 scalar Document
@@ -3327,15 +8715,227 @@ type FileStorageSettings {
   basePath : DirectoryPath!
 }
 
+# StorageEncryptionSettings enables AES-GCM envelope encryption of stored records, keyFile
+# holds the master keys which wrap the per-tenant data keys
+type StorageEncryptionSettings {
+  keyFile : FilePathAndName!
+}
+
 type StorageSettings {
   type: StorageType!
   filesys : FileStorageSettings
+  encryption : StorageEncryptionSettings
+  backupsPath : DirectoryPath
+  archivesPath : DirectoryPath
+  quota : StorageQuotaSettings
+  tenantQuota : StorageQuotaSettings
+  retention : StorageRetentionSettings
+}
+
+# SavedResourceKind separates the harvested, ignored and invalid resources saved to a destination
+enum SavedResourceKind {
+  HARVESTED
+  IGNORED
+  INVALID
+}
+
+# StorageRetentionRule keeps saved resources of a kind for keepDays (forever if keepDays is not set). Rules
+# may be limited to a destination collection and key, the most specific matching rule wins.
+type StorageRetentionRule {
+  kind : SavedResourceKind!
+  collection : StorageDestinationCollection
+  key : StorageKey
+  keepDays : RetentionDays
+}
+
+# StorageRetentionSettings.jobsKeepDays deletes finished harvest jobs that many days after they finished
+# (they're kept forever if it's not set)
+type StorageRetentionSettings {
+  rules : [StorageRetentionRule]
+  jobsKeepDays : RetentionDays
+  sweepIntervalMinutes : SweepIntervalMinutes
+}
+
+type ExpiredStorageRecord {
+  collection : StorageDestinationCollection!
+  key : StorageKey!
+  kind : SavedResourceKind!
+  url : URLText!
+  savedAt : DateTime!
+  expiresAt : DateTime!
+}
+
+type StorageRetentionReport {
+  bundle : SettingsBundleName!
+  evaluatedAt : DateTime!
+  dryRun : Boolean!
+  examined : StorageRecordsCount!
+  expired : StorageRecordsCount!
+  records : [ExpiredStorageRecord]
+  expiredJobs : StorageRecordsCount!
+}
+
+# StorageQuotaSettings limits how much can be saved; StorageSettings.quota applies to the whole
+# settings bundle and StorageSettings.tenantQuota to each storage destination separately. Only the
+# resources saved to storage destinations count, not the records lectiod keeps for itself.
+type StorageQuotaSettings {
+  maxRecords : StorageRecordsCount
+  maxBytes : StorageBytesCount
+}
+
+type StorageCollectionUsage {
+  collection : StorageKey!
+  records : StorageRecordsCount!
+  bytes : StorageBytesCount!
+  quota : StorageQuotaSettings
+}
+
+type StorageUsage {
+  bundle : SettingsBundleName!
+  records : StorageRecordsCount!
+  bytes : StorageBytesCount!
+  quota : StorageQuotaSettings
+  collections : [StorageCollectionUsage]
+}
+
+# StorageBackup describes a compressed archive of every record in a settings bundle's storage
+type StorageBackup {
+  bundle : SettingsBundleName!
+  file : FilePathAndName!
+  storageType : StorageType!
+  createdAt : DateTime!
+  records : StorageRecordsCount!
+  bytes : StorageBytesCount!
+  checksum : Checksum!
+}
+
+# HTTPFetchSettings is the policy used for every HTTP request made while resolving harvested URLs,
+# acceptedContentTypes may contain wildcards like text/* and an empty list accepts everything. Requests
+# to the same host are limited to maxConcurrentRequestsPerHost and start hostDelayMilliseconds apart.
+type HTTPFetchSettings {
+  timeoutSeconds : TimeoutSeconds
+  maxRedirects : RedirectsCount
+  userAgent : UserAgent
+  acceptedContentTypes : [ContentType]
+  maxBodyBytes : BodySizeBytes
+  proxy : URLText
+  maxConcurrentRequests : ConcurrencyLimit
+  maxConcurrentRequestsPerHost : ConcurrencyLimit
+  hostDelayMilliseconds : DelayMilliseconds
+}
+
+# HarvestRule is a named ignore (URL) or cleaner (query parameter) rule, enabled defaults to true. Rules
+# given as plain regular expressions are named after their expression.
+type HarvestRule {
+  name : RuleIdentifier!
+  description : MediumText
+  enabled : Boolean
+  regEx : RegularExpression!
+}
+
+# DomainHarvestRules overrides the bundle's rules for URLs on domain and its subdomains: cleanURLs set to false
+# keeps their query parameters, removeParamsFromURLsRegEx adds to the bundle's cleaner rules
+type DomainHarvestRules {
+  domain : DomainName!
+  cleanURLs : Boolean
+  removeParamsFromURLsRegEx : [RegularExpression]
+  followHTMLRedirects : Boolean
+}
+
+enum TrailingSlashPolicy {
+  KEEP
+  ADD
+  REMOVE
+}
+
+# URLNormalizationSettings controls how the normalized URL of a resource, which identifies duplicates, is derived
+# from its final URL. The options default to false and trailingSlash to KEEP.
+type URLNormalizationSettings {
+  lowercaseSchemeAndHost : Boolean
+  removeDefaultPort : Boolean
+  removeFragment : Boolean
+  trailingSlash : TrailingSlashPolicy
+  sortQueryParams : Boolean
+  punycodeHost : Boolean
+  foldWWW : Boolean
+}
+
+enum CanonicalizerName {
+  GOOGLE_REDIRECT
+  AMP
+  YOUTUBE
+  AMAZON
+  MEDIUM
+}
+
+# CanonicalizerSettings turns a built-in canonicalizer on or off, canonicalizers which aren't listed are off
+type CanonicalizerSettings {
+  name : CanonicalizerName!
+  enabled : Boolean!
+}
+
+# CanonicalURLSettings controls the canonical URLs pages declare with <link rel="canonical"> or og:url: detect
+# reports them on harvested resources, dedupe also makes the normalized canonical URL their dedupe key
+type CanonicalURLSettings {
+  detect : Boolean!
+  dedupe : Boolean!
 }
 
+# allowDomains and denyDomains match a domain and its subdomains, the most specific entry wins; public suffixes
+# like com or co.uk aren't valid entries
 type HarvestDirectivesSettings {
   ignoreURLsRegExprs : [RegularExpression]
   removeParamsFromURLsRegEx : [RegularExpression]
   followHTMLRedirects : Boolean!
+  jobWorkers : HarvestJobWorkersCount
+  batchConcurrency : ConcurrencyLimit
+  allowRequestDirectives : Boolean!
+  fetch : HTTPFetchSettings
+  respectRobotsTxt : Boolean!
+  robotsTxtCacheMinutes : CacheTTLMinutes
+  resolutionCacheMinutes : CacheTTLMinutes
+  ignoreURLsRules : [HarvestRule]
+  removeParamsRules : [HarvestRule]
+  allowDomains : [DomainName]
+  denyDomains : [DomainName]
+  domainRules : [DomainHarvestRules]
+  normalization : URLNormalizationSettings
+  canonicalizers : [CanonicalizerSettings]
+  canonicalURLs : CanonicalURLSettings
+  extractMetadata : Boolean!
+  extractContent : Boolean!
+  archive : Boolean!
+}
+
+enum HarvestRuleKind {
+  IGNORE_URL
+  REMOVE_PARAM
+}
+
+# HarvestRuleStats counts how often a rule fired since the service started, domain is only set for the
+# rules of a domainRules override
+type HarvestRuleStats {
+  kind : HarvestRuleKind!
+  domain : DomainName
+  name : RuleIdentifier!
+  description : MediumText
+  enabled : Boolean!
+  regEx : RegularExpression!
+  hits : HitsCount!
+}
+
+type RemovedQueryParam {
+  param : SmallText!
+  rule : RuleIdentifier!
+}
+
+# HarvestRulesTest shows which rules fire for a URL as given, it's not resolved so redirects aren't followed
+type HarvestRulesTest {
+  url : URLText!
+  isValid : Boolean!
+  ignoreRule : RuleIdentifier
+  removedParams : [RemovedQueryParam]
+  cleaned : URLText
 }
 
 type SettingsBundle {
@@ -3345,11 +8945,38 @@ type SettingsBundle {
   errors: [ErrorMessage]
 }
 
+# normalized is final (or canonical, when harvest.canonicalURLs.dedupe is set) normalized according to
+# harvest.normalization, saved resources with the same normalized URL replace each other. canonical is the URL
+# the page declares as canonical, when harvest.canonicalURLs.detect is set.
 type HarvestedResourceUrls {
   original : URLText!
   final : URLText!
   cleaned : URLText!
   resolved : URLText!
+  normalized : URLText!
+  canonical : URLText
+}
+
+enum RedirectKind {
+  HTTP
+  HTML_META
+}
+
+# RedirectHop is a single redirect followed while resolving a URL, location is where url redirected to
+type RedirectHop {
+  url : URLText!
+  statusCode : HTTPStatusCode!
+  location : URLText!
+  kind : RedirectKind!
+  latencyMilliseconds : LatencyMilliseconds!
+}
+
+# ResolutionCacheStatus tells whether a harvested resource was resolved by this request (FRESH, and cached),
+# taken from the resolution cache (CACHED) or resolved without the cache because it's disabled (UNCACHED)
+enum ResolutionCacheStatus {
+  FRESH
+  CACHED
+  UNCACHED
 }
 
 type HarvestedResource {
@@ -3357,16 +8984,110 @@ type HarvestedResource {
   isHTMLRedirect : Boolean!
   isCleaned : Boolean!
   redirectURL : URLText
-}
-
+  redirectChain : [RedirectHop]
+  cacheStatus : ResolutionCacheStatus!
+  canonicalizedBy : [CanonicalizerName!]
+  metadata : PageMetadata
+  content : HarvestedContent
+  archiveRecord : ArchiveRecord
+  discovery : ResourceDiscovery
+}
+
+# ArchiveRecord locates the WARC response record of an archived page, warcFile is relative to the bundle's
+# storage.archivesPath and replayPath serves the archived response from the daemon
+type ArchiveRecord {
+  recordId : SmallText!
+  warcFile : SmallText!
+  offset : StorageBytesCount!
+  length : StorageBytesCount!
+  targetURL : URLText!
+  archivedAt : DateTime!
+  replayPath : URLText!
+}
+
+type OpenGraphMetadata {
+  type : SmallText
+  title : SmallText
+  description : MediumText
+  image : URLText
+  url : URLText
+  siteName : NameText
+  locale : LanguageCode
+}
+
+type TwitterCardMetadata {
+  card : SmallText
+  site : NameText
+  creator : NameText
+  title : SmallText
+  description : MediumText
+  image : URLText
+}
+
+# PageMetadata is what the final destination's HTML says about itself, fields the page doesn't declare are null.
+# title and description fall back to their OpenGraph values.
+type PageMetadata {
+  title : SmallText
+  description : MediumText
+  author : NameText
+  publishedAt : DateTime
+  siteName : NameText
+  favicon : URLText
+  language : LanguageCode
+  openGraph : OpenGraphMetadata
+  twitterCard : TwitterCardMetadata
+}
+
+# HarvestedContent is the main article of a harvested page with navigation, ads and other boilerplate removed, as
+# plain text (paragraphs separated by blank lines) and as sanitized HTML
+type HarvestedContent {
+  text : ExtraLargeText!
+  html : ExtraLargeText!
+  wordCount : WordsCount!
+  readingTimeMinutes : ReadingTimeMinutes!
+  extractedAt : DateTime!
+}
+
+# ReasonCode is the machine readable reason a resource was ignored or is invalid
+enum ReasonCode {
+  INVALID_URL
+  DESTINATION_UNREACHABLE
+  HTTP_ERROR
+  TOO_MANY_REDIRECTS
+  CONTENT_TYPE_NOT_ACCEPTED
+  MATCHED_IGNORE_RULE
+  DISALLOWED_BY_ROBOTS_TXT
+  DOMAIN_DENIED
+  DOMAIN_NOT_ALLOWED
+}
+
+# reason is the human readable message, matchedRule identifies the rule which caused a MATCHED_IGNORE_RULE,
+# DISALLOWED_BY_ROBOTS_TXT or DOMAIN_DENIED and httpStatus is the status of an HTTP_ERROR
 type IgnoredResource {
   urls : HarvestedResourceUrls!
   reason: SmallText!
+  reasonCode : ReasonCode!
+  matchedRule : RuleIdentifier
+  redirectChain : [RedirectHop]
+  discovery : ResourceDiscovery
 }
 
 type UnharvestedResource {
   url : URLText!
   reason: SmallText!
+  reasonCode : ReasonCode!
+  httpStatus : HTTPStatusCode
+  discovery : ResourceDiscovery
+}
+
+# ResourceDiscovery is where a URL was found in the text or document it was harvested from. offset and length
+# are in bytes of its UTF-8 encoding and cover the whole link, anchorText is the text it was linked from and
+# contextSnippet is the text around it.
+type ResourceDiscovery {
+  offset : TextOffset!
+  length : TextLength!
+  anchorText : SmallText
+  contextSnippet : MediumText!
 }
 
 type HarvestedResources {
@@ -3376,6 +9097,52 @@ type HarvestedResources {
   invalid : [UnharvestedResource]
 }
 
+enum HarvestJobStatus {
+  QUEUED
+  RUNNING
+  COMPLETED
+  FAILED
+}
+
+# HarvestJob is an asynchronous urlsInText (or saveURLsinText, when destination is given) run by a worker pool
+type HarvestJob {
+  id : HarvestJobID!
+  status : HarvestJobStatus!
+  destination : StorageKey
+  submittedAt : DateTime!
+  startedAt : DateTime
+  finishedAt : DateTime
+  discovered : ResourcesCount!
+  processed : ResourcesCount!
+  harvested : ResourcesCount!
+  ignored : ResourcesCount!
+  invalid : ResourcesCount!
+  error : ErrorMessage
+  resources : HarvestedResources
+}
+
+# HarvestProgressEvent is emitted by the harvestProgress subscription for every resource a job produces,
+# the final event is the finished HarvestJob itself
+union HarvestProgressEvent = HarvestedResource | IgnoredResource | UnharvestedResource | HarvestJob
+
+# HarvestDirectivesInput layers extra rules onto the settings bundle's HarvestDirectivesSettings for a single
+# request, only if the bundle's harvest.allowRequestDirectives is true
+input HarvestDirectivesInput {
+  ignoreURLsRegExprs : [RegularExpression!]
+  removeParamsFromURLsRegEx : [RegularExpression!]
+  followHTMLRedirects : Boolean
+  respectRobotsTxt : Boolean
+  archive : Boolean
+}
+
+# DocumentFormat selects how URLs are discovered in a document: HTML links and the URLs in its text, Markdown
+# links and bare URLs outside code, or the URLs in plain text
+enum DocumentFormat {
+  HTML
+  MARKDOWN
+  TEXT
+}
+
 input AuthorizationInput {
   claimType : AuthorizationClaimType!
   claimMedium : AuthorizationClaimMedium!
@@ -3403,7 +9170,15 @@ type Query {
   asymmetricCryptoPublicKeys(claimType : AuthorizationClaimType) : [AuthorizationClaimCryptoKey]
   settingsBundles(authorization : PrivilegedAuthorizationInput!) : [SettingsBundle]
   settingsBundle(authorization : PrivilegedAuthorizationInput!, name : SettingsBundleName!): SettingsBundle
-  urlsInText(authorization : AuthorizationInput!, text: LargeText!): HarvestedResources
+  urlsInText(authorization : AuthorizationInput!, text: LargeText!, directives : HarvestDirectivesInput): HarvestedResources
+  urlsInTexts(authorization : AuthorizationInput!, texts: [LargeText!]!): [HarvestedResources]
+  urlsInDocument(authorization : AuthorizationInput!, document : Document!, format : DocumentFormat!, directives : HarvestDirectivesInput): HarvestedResources
+  urlsInFile(authorization : AuthorizationInput!, file : File!, format : DocumentFormat, directives : HarvestDirectivesInput): HarvestedResources
+  storageUsage(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!) : StorageUsage
+  storageRetentionReport(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!) : StorageRetentionReport
+  harvestJob(authorization : AuthorizationInput!, id : HarvestJobID!) : HarvestJob
+  harvestRuleStats(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!) : [HarvestRuleStats]
+  testHarvestRules(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!, urls : [URLText!]!) : [HarvestRulesTest]
 }
 
 type Mutation {
@@ -3411,7 +9186,15 @@ type Mutation {
   refreshSession(privilegedAuthz : PrivilegedAuthorizationInput!, authorization : AuthorizationInput!) : AuthenticatedSession
   destroySession(privilegedAuthz : PrivilegedAuthorizationInput!, authorization : AuthorizationInput!) : Boolean!
   destroyAllSessions(authorization : PrivilegedAuthorizationInput!) : AuthenticatedSessionsCount!
-  saveURLsinText(authorization : AuthorizationInput!, destination: StorageDestinationInput!, text : LargeText!) : HarvestedResources
+  saveURLsinText(authorization : AuthorizationInput!, destination: StorageDestinationInput!, text : LargeText!, directives : HarvestDirectivesInput) : HarvestedResources
+  saveURLsinTexts(authorization : AuthorizationInput!, destination: StorageDestinationInput!, texts : [LargeText!]!) : [HarvestedResources]
+  submitHarvestJob(authorization : AuthorizationInput!, text : LargeText!, destination : StorageDestinationInput) : HarvestJob
+  backupStorage(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!) : StorageBackup
+  restoreStorage(authorization : PrivilegedAuthorizationInput!, bundle : SettingsBundleName!, file : FilePathAndName!) : StorageBackup
+}
+
+type Subscription {
+  harvestProgress(authorization : AuthorizationInput!, jobId : HarvestJobID!) : HarvestProgressEvent!
 }
 `},
 )
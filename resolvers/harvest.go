@@ -0,0 +1,545 @@
+package resolvers
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	observe "github.com/shah/observe-go"
+	"golang.org/x/net/html"
+)
+
+// resourceHarvester turns the URLs discovered in text into harvested, ignored or invalid resources. It
+// makes its own HTTP requests through a fetch.Client so the settings bundle's fetch policy applies.
+type resourceHarvester struct {
+	observatory               observe.Observatory
+	fetcher                   *fetch.Client
+	ignoreURLsRegEx           ignoreURLsRegExList
+	removeParamsFromURLsRegEx cleanURLsRegExList
+	domains                   *domainRules
+	normalizer                *urlNormalizer
+	canonicalizers            canonicalizerList
+	canonicalURLs             *models.CanonicalURLSettings
+	extractMetadata           bool
+	contents                  *contentStore
+	archives                  *resourceArchiver
+	followHTMLRedirects       bool
+	robots                    *robotsChecker
+	cache                     *resolutionCache
+	resolutionPolicy          string
+}
+
+// resolution is where a URL led to, it doesn't depend on the ignore or cleaner rules so it can be cached.
+// canonicalURL and metadata are what the page declares about itself, they're always extracted so cached
+// resolutions don't depend on harvest.canonicalURLs or harvest.extractMetadata either.
+type resolution struct {
+	resolved       *url.URL
+	isHTMLRedirect bool
+	redirectURL    string
+	redirectChain  []*models.RedirectHop
+	canonicalURL   string
+	metadata       *models.PageMetadata
+	cacheStatus    models.ResolutionCacheStatus
+}
+
+// unharvestedReason explains why a URL was ignored or is invalid, rule and status are only set when relevant
+type unharvestedReason struct {
+	code    models.ReasonCode
+	rule    string
+	status  int
+	message string
+}
+
+// fetchErrorReason classifies an error returned by fetch.Client.Fetch
+func fetchErrorReason(err error) *unharvestedReason {
+	result := &unharvestedReason{code: models.ReasonCodeDestinationUnreachable, message: fmt.Sprintf("Invalid URL Destination: %v", err)}
+	if policyErr, ok := err.(*fetch.PolicyError); ok {
+		switch policyErr.Limit {
+		case fetch.MaxRedirectsLimit:
+			result.code = models.ReasonCodeTooManyRedirects
+		case fetch.AcceptedContentTypesLimit:
+			result.code = models.ReasonCodeContentTypeNotAccepted
+		}
+	}
+	return result
+}
+
+// newResourceHarvester constructs a resourceHarvester, archives is nil unless harvested pages should be archived,
+// robots is nil unless robots.txt should be respected and cache is nil unless resolutions should be cached
+func newResourceHarvester(observatory observe.Observatory, fetcher *fetch.Client, ignoreURLsRegEx ignoreURLsRegExList, removeParamsFromURLsRegEx cleanURLsRegExList, domains *domainRules, normalizer *urlNormalizer, canonicalizers canonicalizerList, canonicalURLs *models.CanonicalURLSettings, extractMetadata bool, contents *contentStore, archives *resourceArchiver, followHTMLRedirects bool, robots *robotsChecker, cache *resolutionCache) *resourceHarvester {
+	result := new(resourceHarvester)
+	result.observatory = observatory
+	result.fetcher = fetcher
+	result.ignoreURLsRegEx = ignoreURLsRegEx
+	result.removeParamsFromURLsRegEx = removeParamsFromURLsRegEx
+	result.domains = domains
+	result.normalizer = normalizer
+	result.canonicalizers = canonicalizers
+	result.canonicalURLs = canonicalURLs
+	result.extractMetadata = extractMetadata
+	result.contents = contents
+	result.archives = archives
+	result.followHTMLRedirects = followHTMLRedirects
+	result.robots = robots
+	result.cache = cache
+	if cache != nil {
+		result.resolutionPolicy = resolutionPolicyID(fetcher.Policy(), domains, followHTMLRedirects)
+	}
+	return result
+}
+
+// discoverURLs finds the URLs in text
+func discoverURLs(text models.LargeText) []*discoveredURL {
+	return discoverDocumentURLs(string(text), models.DocumentFormatText)
+}
+
+// harvestText discovers the URLs in text and harvests each of them
+func (h *resourceHarvester) harvestText(text models.LargeText, parent opentracing.Span) *models.HarvestedResources {
+	span := h.observatory.StartChildTrace("resolvers.harvestText", parent)
+	defer span.Finish()
+
+	result := new(models.HarvestedResources)
+	result.Text = text
+	for _, discovered := range discoverURLs(text) {
+		h.harvestURL(result, discovered.url, discovered.discovery(), span)
+	}
+	return result
+}
+
+// harvestDocument discovers the URLs in document according to its format and harvests each of them
+func (h *resourceHarvester) harvestDocument(document string, format models.DocumentFormat, parent opentracing.Span) *models.HarvestedResources {
+	span := h.observatory.StartChildTrace("resolvers.harvestDocument", parent)
+	defer span.Finish()
+	span.LogFields(log.String("format", string(format)))
+
+	result := new(models.HarvestedResources)
+	result.Text = models.LargeText(document)
+	for _, discovered := range discoverDocumentURLs(document, format) {
+		span.LogFields(log.String("discovered", discovered.url), log.String("anchorText", discovered.anchorText))
+		h.harvestURL(result, discovered.url, discovered.discovery(), span)
+	}
+	return result
+}
+
+// harvestURL resolves urlText and adds it to the invalid, ignored or harvested resources in result, discovery
+// is where it was found (if it's known)
+func (h *resourceHarvester) harvestURL(result *models.HarvestedResources, urlText string, discovery *models.ResourceDiscovery, parent opentracing.Span) {
+	span := h.observatory.StartChildTrace("resolvers.harvestURL", parent)
+	defer span.Finish()
+	span.LogFields(log.String("url", urlText))
+
+	invalid := func(reason *unharvestedReason) {
+		span.LogFields(log.String("invalid", string(reason.code)), log.String("reason", reason.message))
+		resource := &models.UnharvestedResource{URL: models.URLText(urlText), Reason: models.SmallText(reason.message), ReasonCode: reason.code, Discovery: discovery}
+		if reason.status != 0 {
+			status := models.HTTPStatusCode(reason.status)
+			resource.HTTPStatus = &status
+		}
+		result.Invalid = append(result.Invalid, resource)
+	}
+
+	original, err := url.Parse(urlText)
+	if err != nil || !original.IsAbs() || (original.Scheme != "http" && original.Scheme != "https") {
+		invalid(&unharvestedReason{code: models.ReasonCodeInvalidUrl, message: "Invalid URL"})
+		return
+	}
+
+	var chain []*models.RedirectHop
+	// final is resolved, or its canonical URL once the canonicalizers were applied
+	ignored := func(resolved *url.URL, final *url.URL, reason *unharvestedReason) {
+		span.LogFields(log.String("ignored", string(reason.code)), log.String("reason", reason.message))
+		resource := &models.IgnoredResource{
+			Urls: models.HarvestedResourceUrls{
+				Original:   models.URLText(urlText),
+				Final:      urlToString(final),
+				Resolved:   urlToString(resolved),
+				Normalized: urlToString(h.normalizer.normalize(final)),
+			},
+			Reason:        models.SmallText(fmt.Sprintf("Ignored: %s", reason.message)),
+			ReasonCode:    reason.code,
+			RedirectChain: chain,
+			Discovery:     discovery,
+		}
+		if reason.rule != "" {
+			rule := models.RuleIdentifier(reason.rule)
+			resource.MatchedRule = &rule
+		}
+		result.Ignored = append(result.Ignored, resource)
+	}
+	robotsDisallowed := func(disallowed *robotsDisallowedError) {
+		ignored(disallowed.URL, disallowed.URL, &unharvestedReason{code: models.ReasonCodeDisallowedByRobotsTxt, rule: disallowed.Rule, message: disallowed.Error()})
+	}
+
+	// denied domains are checked before fetching so they aren't contacted, and again where the URL led to
+	if reason := h.domains.ignoreReason(original); reason != nil {
+		ignored(original, original, reason)
+		return
+	}
+
+	var filter fetch.RequestFilter
+	if h.robots != nil {
+		filter = h.robots.filter
+	}
+	fetchURL := func(target string) *fetch.Result {
+		fetched, err := h.fetcher.Fetch(target, filter, span)
+		if fetched != nil {
+			chain = append(chain, httpRedirectHops(fetched)...)
+		}
+		if disallowed, ok := err.(*robotsDisallowedError); ok {
+			robotsDisallowed(disallowed)
+			return nil
+		}
+		if err != nil {
+			invalid(fetchErrorReason(err))
+			return nil
+		}
+		return fetched
+	}
+
+	var resolved *resolution
+	if h.cache != nil {
+		resolved = h.cache.load(urlText, h.resolutionPolicy, span)
+	}
+	if resolved != nil {
+		chain = resolved.redirectChain
+	}
+	if resolved != nil && filter != nil {
+		// the cached resolution may predate the robots.txt rules
+		err = filter(resolved.resolved, span)
+		if disallowed, ok := err.(*robotsDisallowedError); ok {
+			robotsDisallowed(disallowed)
+			return
+		}
+	}
+
+	var page *fetch.Result
+	if resolved == nil {
+		fetched := fetchURL(urlText)
+		if fetched == nil {
+			return
+		}
+
+		resolved = &resolution{cacheStatus: models.ResolutionCacheStatusUncached}
+		for redirects := 0; isSuccess(fetched); redirects++ {
+			target := metaRefreshURL(fetched)
+			if target == nil {
+				break
+			}
+			resolved.isHTMLRedirect, resolved.redirectURL = true, target.String()
+			last := fetched.Hops[len(fetched.Hops)-1]
+			chain = append(chain, &models.RedirectHop{
+				URL:                 urlToString(last.URL),
+				StatusCode:          models.HTTPStatusCode(last.StatusCode),
+				Location:            models.URLText(resolved.redirectURL),
+				Kind:                models.RedirectKindHtmlMeta,
+				LatencyMilliseconds: models.LatencyMilliseconds(last.Latency / time.Millisecond),
+			})
+			if !h.domains.followHTMLRedirects(fetched.URL, h.followHTMLRedirects) || redirects >= h.fetcher.Policy().MaxRedirects {
+				break
+			}
+			fetched = fetchURL(resolved.redirectURL)
+			if fetched == nil {
+				return
+			}
+		}
+		if !isSuccess(fetched) {
+			invalid(&unharvestedReason{code: models.ReasonCodeHttpError, status: fetched.StatusCode, message: fmt.Sprintf("Invalid URL Destination: HTTP status %d", fetched.StatusCode)})
+			return
+		}
+		resolved.resolved = fetched.URL
+		resolved.redirectChain = chain
+		if canonical := canonicalLinkURL(fetched); canonical != nil {
+			resolved.canonicalURL = canonical.String()
+		}
+		resolved.metadata = pageMetadata(fetched)
+		page = fetched
+		if h.cache != nil {
+			h.cache.save(urlText, h.resolutionPolicy, resolved, span)
+		}
+	}
+
+	// canonicalizers unwrap URLs like google.com/url?q=..., so the rules are applied to what they lead to as
+	// well as to the resolved URL
+	var declared *url.URL
+	if resolved.canonicalURL != "" {
+		declared, _ = url.Parse(resolved.canonicalURL)
+	}
+	canonical, canonicalizedBy := h.canonicalizers.canonicalURL(resolved.resolved, declared)
+	for _, u := range ruleURLs(resolved.resolved, canonical) {
+		if reason := h.domains.ignoreReason(u); reason != nil {
+			ignored(resolved.resolved, u, reason)
+			return
+		}
+		if rule := h.ignoreURLsRegEx.ignoreRule(u); rule != nil {
+			ignored(resolved.resolved, u, &unharvestedReason{code: models.ReasonCodeMatchedIgnoreRule, rule: string(rule.name), message: fmt.Sprintf("Matched Ignore Rule `%s`", rule.name)})
+			return
+		}
+	}
+
+	cleaned, isCleaned := h.domains.cleaner(canonical, h.removeParamsFromURLsRegEx).cleanURL(canonical)
+	redirectURLText := models.URLText(resolved.redirectURL)
+	harvested := &models.HarvestedResource{
+		Urls: models.HarvestedResourceUrls{
+			Original:   models.URLText(urlText),
+			Final:      urlToString(cleaned),
+			Cleaned:    urlToString(cleaned),
+			Resolved:   urlToString(resolved.resolved),
+			Normalized: urlToString(h.normalizer.normalize(cleaned)),
+		},
+		IsCleaned:       isCleaned || len(canonicalizedBy) > 0,
+		IsHTMLRedirect:  resolved.isHTMLRedirect,
+		RedirectURL:     &redirectURLText,
+		RedirectChain:   resolved.redirectChain,
+		CacheStatus:     resolved.cacheStatus,
+		CanonicalizedBy: canonicalizedBy,
+		Discovery:       discovery,
+	}
+	if h.extractMetadata {
+		harvested.Metadata = resolved.metadata
+	}
+	if h.canonicalURLs != nil && h.canonicalURLs.Detect && resolved.canonicalURL != "" {
+		canonicalURLText := models.URLText(resolved.canonicalURL)
+		harvested.Urls.Canonical = &canonicalURLText
+		if canonicalURL, err := url.Parse(resolved.canonicalURL); err == nil && h.canonicalURLs.Dedupe {
+			harvested.Urls.Normalized = urlToString(h.normalizer.normalize(canonicalURL))
+		}
+	}
+	// content and archive records are keyed by the normalized URL so they're saved once it's final
+	if h.contents != nil {
+		h.contents.save(harvested, page, span)
+	}
+	if h.archives != nil {
+		h.archives.archive(harvested, page, span)
+	}
+	result.Harvested = append(result.Harvested, harvested)
+}
+
+// httpRedirectHops returns the HTTP redirects followed by a fetch
+func httpRedirectHops(fetched *fetch.Result) []*models.RedirectHop {
+	var result []*models.RedirectHop
+	for _, hop := range fetched.Hops {
+		if hop.Location == "" {
+			continue
+		}
+		result = append(result, &models.RedirectHop{
+			URL:                 urlToString(hop.URL),
+			StatusCode:          models.HTTPStatusCode(hop.StatusCode),
+			Location:            models.URLText(hop.Location),
+			Kind:                models.RedirectKindHttp,
+			LatencyMilliseconds: models.LatencyMilliseconds(hop.Latency / time.Millisecond),
+		})
+	}
+	return result
+}
+
+// cleanURL returns a copy of u without the query parameters matching the cleaner rules and true if any were removed
+func (l cleanURLsRegExList) cleanURL(u *url.URL) (*url.URL, bool) {
+	result := *u
+	query := u.Query()
+	removed := false
+	for param := range query {
+		remove, _ := l.RemoveQueryParamFromResource(param)
+		if remove {
+			query.Del(param)
+			removed = true
+		}
+	}
+	if removed {
+		result.RawQuery = query.Encode()
+	}
+	return &result, removed
+}
+
+// isSuccess returns true if fetched ended with a 2xx response
+func isSuccess(fetched *fetch.Result) bool {
+	return fetched.StatusCode >= 200 && fetched.StatusCode < 300
+}
+
+// isHTML returns true if fetched is an HTML page
+func isHTML(fetched *fetch.Result) bool {
+	mediaType, _, _ := mime.ParseMediaType(fetched.ContentType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+// metaRefreshURL returns the target of a <meta http-equiv="refresh"> tag in an HTML response, or nil
+func metaRefreshURL(fetched *fetch.Result) *url.URL {
+	if !isHTML(fetched) {
+		return nil
+	}
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(fetched.Body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return nil
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == "head" {
+				return nil
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) == "body" {
+				return nil
+			}
+			if string(name) != "meta" || !hasAttr {
+				continue
+			}
+			var httpEquiv, content string
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				switch strings.ToLower(string(key)) {
+				case "http-equiv":
+					httpEquiv = string(value)
+				case "content":
+					content = string(value)
+				}
+			}
+			if !strings.EqualFold(httpEquiv, "refresh") {
+				continue
+			}
+			// content is "<seconds>; url=<target>", the url part may be quoted
+			index := strings.Index(strings.ToLower(content), "url=")
+			if index < 0 {
+				return nil
+			}
+			target := strings.Trim(strings.TrimSpace(content[index+4:]), `'"`)
+			result, err := fetched.URL.Parse(target)
+			if err != nil || target == "" {
+				return nil
+			}
+			return result
+		}
+	}
+}
+
+// canonicalLinkURL returns the canonical URL an HTML response declares in its head, a <link rel="canonical">
+// is preferred over an og:url <meta> tag. It returns nil if there's neither.
+func canonicalLinkURL(fetched *fetch.Result) *url.URL {
+	if !isHTML(fetched) {
+		return nil
+	}
+
+	var ogURL string
+	found := func() *url.URL {
+		result, err := fetched.URL.Parse(ogURL)
+		if err != nil || ogURL == "" {
+			return nil
+		}
+		return result
+	}
+	tokenizer := html.NewTokenizer(bytes.NewReader(fetched.Body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return found()
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == "head" {
+				return found()
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			if string(name) == "body" {
+				return found()
+			}
+			if (string(name) != "link" && string(name) != "meta") || !hasAttr {
+				continue
+			}
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = strings.TrimSpace(string(value))
+			}
+			switch {
+			case string(name) == "link" && attrs["href"] != "":
+				for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+					if rel != "canonical" {
+						continue
+					}
+					result, err := fetched.URL.Parse(attrs["href"])
+					if err == nil {
+						return result
+					}
+				}
+			case string(name) == "meta" && ogURL == "":
+				if attrs["property"] == "og:url" || attrs["name"] == "og:url" {
+					ogURL = attrs["content"]
+				}
+			}
+		}
+	}
+}
+
+// harvesterWithDirectives returns the harvester to use for a request: the configured one if directives
+// is nil, otherwise one whose rules are the bundle's rules plus those in directives
+func (c *Configuration) harvesterWithDirectives(h *ServiceHandler, directives *models.HarvestDirectivesInput, parent opentracing.Span) (*resourceHarvester, error) {
+	if directives == nil {
+		return c.contentHarvester, nil
+	}
+
+	span := h.observatory.StartChildTrace("resolvers.harvesterWithDirectives", parent)
+	defer span.Finish()
+
+	if !c.settings.Harvest.AllowRequestDirectives {
+		error := fmt.Errorf("Settings bundle '%s' does not allow harvest directives in requests", c.settings.Name)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+
+	ignoreURLsRegEx := append(ignoreURLsRegExList{}, c.ignoreURLsRegEx...)
+	for _, value := range directives.IgnoreURLsRegExprs {
+		rule, err := newHarvestRule("", nil, nil, value)
+		if err != nil {
+			error := fmt.Errorf("Error adding regexp '%s' to ignore list: %s", value, err.Error())
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(error))
+			return nil, error
+		}
+		ignoreURLsRegEx = append(ignoreURLsRegEx, rule)
+	}
+
+	removeParamsFromURLsRegEx := append(cleanURLsRegExList{}, c.removeParamsFromURLsRegEx...)
+	for _, value := range directives.RemoveParamsFromURLsRegEx {
+		rule, err := newHarvestRule("", nil, nil, value)
+		if err != nil {
+			error := fmt.Errorf("Error adding regexp '%s' to param removal list: %s", value, err.Error())
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(error))
+			return nil, error
+		}
+		removeParamsFromURLsRegEx = append(removeParamsFromURLsRegEx, rule)
+	}
+
+	followHTMLRedirects := c.settings.Harvest.FollowHTMLRedirects
+	if directives.FollowHTMLRedirects != nil {
+		followHTMLRedirects = *directives.FollowHTMLRedirects
+	}
+	robots := c.contentHarvester.robots
+	if directives.RespectRobotsTxt != nil {
+		robots = nil
+		if *directives.RespectRobotsTxt {
+			robots = c.robots
+		}
+	}
+	archives := c.contentHarvester.archives
+	if directives.Archive != nil {
+		archives = nil
+		if *directives.Archive {
+			archives = c.archives
+		}
+	}
+	span.LogFields(log.Int("ignoreURLsRegEx", len(ignoreURLsRegEx)), log.Int("removeParamsFromURLsRegEx", len(removeParamsFromURLsRegEx)), log.Bool("followHTMLRedirects", followHTMLRedirects), log.Bool("respectRobotsTxt", robots != nil), log.Bool("archive", archives != nil))
+	return newResourceHarvester(h.observatory, c.contentHarvester.fetcher, ignoreURLsRegEx, removeParamsFromURLsRegEx, c.domains, c.contentHarvester.normalizer, c.contentHarvester.canonicalizers, c.contentHarvester.canonicalURLs, c.contentHarvester.extractMetadata, c.contentHarvester.contents, archives, followHTMLRedirects, robots, c.contentHarvester.cache), nil
+}
@@ -0,0 +1,111 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/lectio/lectiod/models"
+)
+
+// newHarvestServer serves pages for the harvest tests, /status/N responds with status N
+func newHarvestServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><head><title>Page</title></head><body>page</body></html>")
+	})
+	mux.HandleFunc("/status/", func(w http.ResponseWriter, r *http.Request) {
+		var status int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/status/"), "%d", &status)
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(status)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><meta http-equiv="refresh" content="0; url='/page'"></head><body></body></html>`)
+	})
+	mux.HandleFunc("/ignored", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html><body>ignored</body></html>")
+	})
+	return httptest.NewServer(mux)
+}
+
+func (suite *ResolversSuite) TestHarvestText() {
+	server := newHarvestServer()
+	defer server.Close()
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		ignored := models.RegularExpression(`/ignored$`)
+		settings.Harvest.IgnoreURLsRegExprs = append(settings.Harvest.IgnoreURLsRegExprs, &ignored)
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		harvested      bool
+		final          string
+		isCleaned      bool
+		isHTMLRedirect bool
+		redirects      int
+		ignoredRule    string
+		invalidStatus  int
+	}{
+		{name: "ok", path: "/page", harvested: true, final: "/page"},
+		{name: "non-authoritative is a success", path: "/status/203", harvested: true, final: "/status/203"},
+		{name: "no content is a success", path: "/status/204", harvested: true, final: "/status/204"},
+		{name: "not found", path: "/status/404", invalidStatus: 404},
+		{name: "server error", path: "/status/500", invalidStatus: 500},
+		{name: "HTTP redirect", path: "/moved", harvested: true, final: "/page", redirects: 1},
+		{name: "meta refresh", path: "/refresh", harvested: true, final: "/page", isHTMLRedirect: true, redirects: 1},
+		{name: "utm params are removed", path: "/page?utm_source=test&id=1", harvested: true, final: "/page?id=1", isCleaned: true},
+		{name: "matched ignore rule", path: "/ignored", ignoredRule: `/ignored$`},
+	}
+	for _, test := range tests {
+		result := config.contentHarvester.harvestText(models.LargeText("Link: "+server.URL+test.path), suite.span)
+		switch {
+		case test.harvested:
+			if !suite.Len(result.Harvested, 1, test.name) {
+				continue
+			}
+			harvested := result.Harvested[0]
+			suite.Equal(models.URLText(server.URL+test.final), harvested.Urls.Final, test.name)
+			suite.Equal(test.isCleaned, harvested.IsCleaned, test.name)
+			suite.Equal(test.isHTMLRedirect, harvested.IsHTMLRedirect, test.name)
+			suite.Len(harvested.RedirectChain, test.redirects, test.name)
+		case test.ignoredRule != "":
+			if !suite.Len(result.Ignored, 1, test.name) {
+				continue
+			}
+			ignored := result.Ignored[0]
+			suite.Equal(models.ReasonCodeMatchedIgnoreRule, ignored.ReasonCode, test.name)
+			if suite.NotNil(ignored.MatchedRule, test.name) {
+				suite.Equal(models.RuleIdentifier(test.ignoredRule), *ignored.MatchedRule, test.name)
+			}
+		default:
+			if !suite.Len(result.Invalid, 1, test.name) {
+				continue
+			}
+			invalid := result.Invalid[0]
+			suite.Equal(models.ReasonCodeHttpError, invalid.ReasonCode, test.name)
+			if suite.NotNil(invalid.HTTPStatus, test.name) {
+				suite.Equal(models.HTTPStatusCode(test.invalidStatus), *invalid.HTTPStatus, test.name)
+			}
+		}
+	}
+}
+
+func (suite *ResolversSuite) TestHarvestInvalidURL() {
+	config := suite.newConfiguration(nil)
+	for _, urlText := range []string{"ftp://example.com/file", "/relative/path", "http://%zz"} {
+		result := new(models.HarvestedResources)
+		config.contentHarvester.harvestURL(result, urlText, nil, suite.span)
+		if suite.Len(result.Invalid, 1, urlText) {
+			suite.Equal(models.ReasonCodeInvalidUrl, result.Invalid[0].ReasonCode, urlText)
+		}
+	}
+}
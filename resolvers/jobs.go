@@ -0,0 +1,472 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+const (
+	// DefaultHarvestJobWorkers is used when a settings bundle doesn't configure harvest.jobWorkers
+	DefaultHarvestJobWorkers = 4
+
+	// harvestJobQueueSize is the number of jobs which may wait for a worker before submissions are refused
+	harvestJobQueueSize = 1000
+
+	// harvestProgressBufferSize is the number of events a harvestProgress subscriber may fall behind
+	// before it's disconnected, so a slow client can't hold up the workers
+	harvestProgressBufferSize = 256
+)
+
+// harvestJobQueue runs the asynchronous harvest jobs of a settings bundle on a pool of workers. Jobs are
+// persisted in the bundle's datastore when they're queued, started and finished so queued or interrupted
+// jobs are restarted with the service. The progress of running jobs is only kept in memory.
+type harvestJobQueue struct {
+	config  *Configuration
+	handler *ServiceHandler
+	queue   chan *persistence.HarvestJobRecord
+	done    chan struct{}
+	workers sync.WaitGroup
+	jobs    map[models.HarvestJobID]*persistence.HarvestJobRecord
+	mutex   sync.Mutex
+	started bool
+
+	subscribers map[models.HarvestJobID][]chan models.HarvestProgressEvent
+}
+
+func newHarvestJobQueue(h *ServiceHandler, c *Configuration) *harvestJobQueue {
+	result := new(harvestJobQueue)
+	result.config = c
+	result.handler = h
+	result.queue = make(chan *persistence.HarvestJobRecord, harvestJobQueueSize)
+	result.done = make(chan struct{})
+	result.jobs = make(map[models.HarvestJobID]*persistence.HarvestJobRecord)
+	result.subscribers = make(map[models.HarvestJobID][]chan models.HarvestProgressEvent)
+	return result
+}
+
+// start requeues jobs which didn't finish before the last shutdown and starts the workers
+func (q *harvestJobQueue) start(parent opentracing.Span) {
+	span := q.handler.observatory.StartChildTrace("resolvers.harvestJobQueue.start", parent)
+	defer span.Finish()
+
+	q.mutex.Lock()
+	if q.started {
+		q.mutex.Unlock()
+		return
+	}
+	q.started = true
+	q.mutex.Unlock()
+
+	stored, err := q.config.store.Jobs().ListJobs()
+	if err != nil {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(fmt.Errorf("Unable to load harvest jobs: %v", err)))
+	}
+	requeued := 0
+	for _, record := range stored {
+		if record.Job.Status != models.HarvestJobStatusQueued && record.Job.Status != models.HarvestJobStatusRunning {
+			continue
+		}
+		record.Job.Status = models.HarvestJobStatusQueued
+		record.Job.StartedAt = nil
+		record.Job.Resources = nil
+		record.Job.Discovered, record.Job.Processed = 0, 0
+		record.Job.Harvested, record.Job.Ignored, record.Job.Invalid = 0, 0, 0
+		// jobs which don't fit stay queued in the datastore and are requeued by the next start
+		if !q.send(record) {
+			span.LogFields(log.String("event", "queue full"), log.Int("stored", len(stored)))
+			break
+		}
+		q.setCurrent(record)
+		requeued++
+	}
+
+	workers := DefaultHarvestJobWorkers
+	if q.config.settings.Harvest.JobWorkers != nil && *q.config.settings.Harvest.JobWorkers > 0 {
+		workers = int(*q.config.settings.Harvest.JobWorkers)
+	}
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	span.LogFields(log.Int("workers", workers), log.Int("requeued", requeued))
+}
+
+// stop ends the workers and waits for them, a running job is abandoned after the URL it's harvesting
+// and restarted by the next start
+func (q *harvestJobQueue) stop() {
+	q.mutex.Lock()
+	started := q.started
+	if started {
+		close(q.done)
+		q.started = false
+	}
+	q.mutex.Unlock()
+	if started {
+		q.workers.Wait()
+	}
+}
+
+// enqueue persists record and hands it to the workers. If the queue is full the record is deleted
+// again so it isn't run by the next start.
+func (q *harvestJobQueue) enqueue(record *persistence.HarvestJobRecord) error {
+	err := q.config.store.Jobs().SaveJob(record)
+	if err != nil {
+		return fmt.Errorf("Unable to save harvest job '%s': %v", record.Job.ID, err)
+	}
+	// the job is current before a worker can pick it up and replace it
+	q.setCurrent(record)
+	if q.send(record) {
+		return nil
+	}
+	q.mutex.Lock()
+	delete(q.jobs, record.Job.ID)
+	q.mutex.Unlock()
+	q.config.store.Jobs().DeleteJob(record.Job.ID)
+	return fmt.Errorf("Harvest job queue of bundle '%s' is full (%d jobs waiting), try again later", q.config.settings.Name, harvestJobQueueSize)
+}
+
+// send hands record to the workers without blocking, returning false if the queue is full
+func (q *harvestJobQueue) send(record *persistence.HarvestJobRecord) bool {
+	select {
+	case q.queue <- record:
+		return true
+	default:
+		return false
+	}
+}
+
+// setCurrent makes record the job's current state without persisting it
+func (q *harvestJobQueue) setCurrent(record *persistence.HarvestJobRecord) {
+	q.mutex.Lock()
+	q.jobs[record.Job.ID] = record
+	q.mutex.Unlock()
+}
+
+// update makes record the job's current state and sends events to the job's subscribers (closing their
+// subscriptions with a final summary once the job is finished). Records are only persisted if persist is
+// true, which callers limit to status changes so a job isn't rewritten for every URL it harvests.
+// Finished jobs are dropped from memory once they're persisted, job reads them from the datastore.
+func (q *harvestJobQueue) update(record *persistence.HarvestJobRecord, events []models.HarvestProgressEvent, persist bool) error {
+	q.mutex.Lock()
+	q.jobs[record.Job.ID] = record
+	subscribers := q.subscribers[record.Job.ID][:0]
+	for _, subscriber := range q.subscribers[record.Job.ID] {
+		if sendProgressEvents(subscriber, events) {
+			subscribers = append(subscribers, subscriber)
+		} else {
+			close(subscriber)
+		}
+	}
+	if isHarvestJobFinished(record) {
+		for _, subscriber := range subscribers {
+			job := record.Job
+			sendProgressEvents(subscriber, []models.HarvestProgressEvent{&job})
+			close(subscriber)
+		}
+		subscribers = nil
+	}
+	if len(subscribers) == 0 {
+		delete(q.subscribers, record.Job.ID)
+	} else {
+		q.subscribers[record.Job.ID] = subscribers
+	}
+	q.mutex.Unlock()
+
+	if !persist {
+		return nil
+	}
+	err := q.config.store.Jobs().SaveJob(record)
+	if err != nil {
+		return fmt.Errorf("Unable to save harvest job '%s': %v", record.Job.ID, err)
+	}
+	if isHarvestJobFinished(record) {
+		q.mutex.Lock()
+		if q.jobs[record.Job.ID] == record {
+			delete(q.jobs, record.Job.ID)
+		}
+		q.mutex.Unlock()
+	}
+	return nil
+}
+
+// sendProgressEvents sends events without blocking, returning false if subscriber has fallen too far behind
+func sendProgressEvents(subscriber chan models.HarvestProgressEvent, events []models.HarvestProgressEvent) bool {
+	for _, event := range events {
+		select {
+		case subscriber <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isHarvestJobFinished(record *persistence.HarvestJobRecord) bool {
+	return record.Job.Status == models.HarvestJobStatusCompleted || record.Job.Status == models.HarvestJobStatusFailed
+}
+
+// progressEvents returns the resources in result beyond the given counts as progress events
+func progressEvents(result *models.HarvestedResources, harvested, ignored, invalid int) []models.HarvestProgressEvent {
+	if result == nil {
+		return nil
+	}
+	events := make([]models.HarvestProgressEvent, 0)
+	for _, resource := range result.Harvested[harvested:] {
+		events = append(events, resource)
+	}
+	for _, resource := range result.Ignored[ignored:] {
+		events = append(events, resource)
+	}
+	for _, resource := range result.Invalid[invalid:] {
+		events = append(events, resource)
+	}
+	return events
+}
+
+// subscribe returns a channel receiving every resource the job of session has produced so far, then each
+// new one as it's produced, and finally the finished job. The channel is closed when ctx is done. The job
+// is read and the subscriber registered under the same lock so the job can't finish in between; finished
+// jobs get their resources and final state right away.
+func (q *harvestJobQueue) subscribe(ctx context.Context, id models.HarvestJobID, session models.AuthenticatedSessionID) (<-chan models.HarvestProgressEvent, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	record, err := q.currentJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if record.SessionID != session {
+		return nil, fmt.Errorf("Harvest job '%s' belongs to another session", id)
+	}
+	events := progressEvents(record.Job.Resources, 0, 0, 0)
+	if isHarvestJobFinished(record) {
+		job := record.Job
+		subscriber := make(chan models.HarvestProgressEvent, len(events)+1)
+		sendProgressEvents(subscriber, append(events, &job))
+		close(subscriber)
+		return subscriber, nil
+	}
+
+	subscriber := make(chan models.HarvestProgressEvent, len(events)+harvestProgressBufferSize)
+	sendProgressEvents(subscriber, events)
+	q.subscribers[record.Job.ID] = append(q.subscribers[record.Job.ID], subscriber)
+	go func() {
+		<-ctx.Done()
+		q.unsubscribe(record.Job.ID, subscriber)
+	}()
+	return subscriber, nil
+}
+
+// unsubscribe closes subscriber unless the job already did
+func (q *harvestJobQueue) unsubscribe(id models.HarvestJobID, subscriber chan models.HarvestProgressEvent) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	subscribers := q.subscribers[id]
+	for i, s := range subscribers {
+		if s == subscriber {
+			close(subscriber)
+			q.subscribers[id] = append(subscribers[:i], subscribers[i+1:]...)
+			if len(q.subscribers[id]) == 0 {
+				delete(q.subscribers, id)
+			}
+			return
+		}
+	}
+}
+
+// job returns the current state of a job, from memory if it was submitted or run since the service started
+func (q *harvestJobQueue) job(id models.HarvestJobID) (*persistence.HarvestJobRecord, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.currentJob(id)
+}
+
+// currentJob is job for callers holding the mutex, update only drops a finished job from memory under
+// the mutex after persisting it so a job missing from memory is read in its final state
+func (q *harvestJobQueue) currentJob(id models.HarvestJobID) (*persistence.HarvestJobRecord, error) {
+	if record, ok := q.jobs[id]; ok {
+		return record, nil
+	}
+	return q.config.store.Jobs().LoadJob(id)
+}
+
+func (q *harvestJobQueue) work() {
+	defer q.workers.Done()
+	for {
+		select {
+		case record := <-q.queue:
+			q.run(record)
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// run harvests the URLs of a job one at a time so progress can be reported while the job is running
+func (q *harvestJobQueue) run(queued *persistence.HarvestJobRecord) {
+	span := q.handler.observatory.StartTrace("resolvers.HarvestJob")
+	defer span.Finish()
+	span.LogFields(log.String("job.id", string(queued.Job.ID)))
+
+	record := *queued
+	result := new(models.HarvestedResources)
+	result.Text = record.Text
+	update := func(events []models.HarvestProgressEvent, persist bool) {
+		partial := *result
+		snapshot := record
+		snapshot.Job.Resources = &partial
+		err := q.update(&snapshot, events, persist)
+		if err != nil {
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(err))
+		}
+	}
+
+	started := models.NewDateTime(time.Now())
+	discovered := discoverURLs(record.Text)
+	record.Job.Status = models.HarvestJobStatusRunning
+	record.Job.StartedAt = &started
+	record.Job.Discovered = models.ResourcesCount(len(discovered))
+	update(nil, true)
+
+	for _, found := range discovered {
+		select {
+		case <-q.done:
+			span.LogFields(log.String("event", "abandoned, the queue is stopping"))
+			return
+		default:
+		}
+		harvested, ignored, invalid := len(result.Harvested), len(result.Ignored), len(result.Invalid)
+		q.config.contentHarvester.harvestURL(result, found.url, found.discovery(), span)
+		record.Job.Processed++
+		record.Job.Harvested = models.ResourcesCount(len(result.Harvested))
+		record.Job.Ignored = models.ResourcesCount(len(result.Ignored))
+		record.Job.Invalid = models.ResourcesCount(len(result.Invalid))
+		update(progressEvents(result, harvested, ignored, invalid), false)
+	}
+
+	record.Job.Status = models.HarvestJobStatusCompleted
+	if record.Destination != nil {
+		err := q.config.store.Resources().SaveHarvestedResources(*record.Destination, result)
+		if err != nil {
+			message := models.ErrorMessage(fmt.Sprintf("Unable to save resources to '%s' in %s: %v", record.Destination.Key, record.Destination.Collection, err))
+			record.Job.Status = models.HarvestJobStatusFailed
+			record.Job.Error = &message
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.String("error", string(message)))
+		}
+	}
+	finished := models.NewDateTime(time.Now())
+	record.Job.FinishedAt = &finished
+	update(nil, true)
+}
+
+// StartHarvestJobs starts the harvest job workers of every settings bundle
+func (h *ServiceHandler) StartHarvestJobs(parent opentracing.Span) {
+	for _, config := range h.configs {
+		config.jobs.start(parent)
+	}
+}
+
+// SubmitHarvestJob queues text for harvesting and returns immediately, poll harvestJob for the results
+func (m *mutation) SubmitHarvestJob(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText, destination *models.StorageDestinationInput) (*models.HarvestJob, error) {
+	span, ctx := m.handler.observatory.StartTraceFromContext(ctx, "Mutation_submitHarvestJob")
+	defer span.Finish()
+
+	authSess, sessErr := m.handler.ValidateAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+	conf, err := m.handler.bundleConfiguration(authSess.GetSettingsBundleName(), span)
+	if err != nil {
+		return nil, err
+	}
+
+	record := new(persistence.HarvestJobRecord)
+	record.Job.ID = models.HarvestJobID(uuid.New().String())
+	record.Job.Status = models.HarvestJobStatusQueued
+	record.Job.SubmittedAt = models.NewDateTime(time.Now())
+	record.Text = text
+	record.SessionID = authSess.GetAuthenticatedSessionID()
+	record.SettingsBundleName = authSess.GetSettingsBundleName()
+	if destination != nil {
+		if destination.Collection != models.StorageDestinationCollectionSessionPrincipal && destination.Collection != models.StorageDestinationCollectionSessionTenant {
+			error := fmt.Errorf("Unknown destination.Collection: '%s'", destination.Collection)
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(error))
+			return nil, error
+		}
+		record.Destination = destination
+		record.Job.Destination = &destination.Key
+	}
+	span.LogFields(log.String("job.id", string(record.Job.ID)))
+
+	err = conf.jobs.enqueue(record)
+	if err != nil {
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(err))
+		return nil, err
+	}
+	job := record.Job
+	return &job, nil
+}
+
+// HarvestJob returns the status, progress and (once completed) results of a job submitted by the same session
+func (q *query) HarvestJob(ctx context.Context, authorization models.AuthorizationInput, id models.HarvestJobID) (*models.HarvestJob, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_harvestJob")
+	defer span.Finish()
+
+	authSess, sessErr := q.handler.ValidateAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+	conf, err := q.handler.bundleConfiguration(authSess.GetSettingsBundleName(), span)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := conf.jobs.job(id)
+	if err != nil || record.SessionID != authSess.GetAuthenticatedSessionID() {
+		error := fmt.Errorf("Harvest job '%s' not found", id)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+	job := record.Job
+	return &job, nil
+}
+
+// HarvestProgress streams the resources of a job as they're harvested, followed by the finished job
+func (s *subscription) HarvestProgress(ctx context.Context, authorization models.AuthorizationInput, jobId models.HarvestJobID) (<-chan models.HarvestProgressEvent, error) {
+	span, ctx := s.handler.observatory.StartTraceFromContext(ctx, "Subscription_harvestProgress")
+	defer span.Finish()
+
+	authSess, sessErr := s.handler.ValidateAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+	conf, err := s.handler.bundleConfiguration(authSess.GetSettingsBundleName(), span)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := conf.jobs.subscribe(ctx, jobId, authSess.GetAuthenticatedSessionID())
+	if err != nil {
+		error := fmt.Errorf("Harvest job '%s' not found", jobId)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+	return events, nil
+}
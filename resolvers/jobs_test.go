@@ -0,0 +1,167 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
+)
+
+// newJob returns a queued job harvesting text
+func newJob(id string, text string) *persistence.HarvestJobRecord {
+	record := new(persistence.HarvestJobRecord)
+	record.Job.ID = models.HarvestJobID(id)
+	record.Job.Status = models.HarvestJobStatusQueued
+	record.Job.SubmittedAt = models.NewDateTime(time.Now())
+	record.Text = models.LargeText(text)
+	return record
+}
+
+// blockingServer serves pages, holding requests for the blocked path until release is closed.
+// arrived receives a value when the first of them comes in.
+type blockingServer struct {
+	*httptest.Server
+	arrived chan bool
+	release chan bool
+}
+
+func newBlockingServer(blocked string) *blockingServer {
+	result := &blockingServer{arrived: make(chan bool, 1), release: make(chan bool)}
+	result.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == blocked {
+			select {
+			case result.arrived <- true:
+			default:
+			}
+			<-result.release
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintf(w, "<html><head><title>%s</title></head><body>page</body></html>", r.URL.Path)
+	}))
+	return result
+}
+
+func (suite *ResolversSuite) TestFullJobQueueDeletesRefusedJobs() {
+	config := suite.newConfiguration(nil)
+	config.jobs.queue = make(chan *persistence.HarvestJobRecord, 1)
+
+	suite.Nil(config.jobs.enqueue(newJob("first", "no urls")))
+	suite.NotNil(config.jobs.enqueue(newJob("second", "no urls")), "A full queue should refuse jobs")
+
+	_, err := config.jobs.job("first")
+	suite.Nil(err, "Queued jobs should be kept")
+	_, err = config.jobs.job("second")
+	suite.NotNil(err, "Refused jobs shouldn't be kept")
+	_, err = config.store.Jobs().LoadJob("second")
+	suite.NotNil(err, "Refused jobs shouldn't be run by the next start")
+}
+
+func (suite *ResolversSuite) TestJobsArePersistedOnStatusChanges() {
+	server := newBlockingServer("/second")
+	defer server.Close()
+	config := suite.newConfiguration(nil)
+	record := newJob("progress", server.URL+"/first "+server.URL+"/second")
+	suite.Nil(config.jobs.enqueue(record))
+	config.jobs.start(suite.span)
+	<-server.arrived
+
+	current, err := config.jobs.job("progress")
+	suite.Nil(err)
+	suite.Equal(models.HarvestJobStatusRunning, current.Job.Status)
+	suite.Equal(models.ResourcesCount(1), current.Job.Processed, "Progress should be kept in memory")
+	stored, err := config.store.Jobs().LoadJob("progress")
+	suite.Nil(err)
+	suite.Equal(models.HarvestJobStatusRunning, stored.Job.Status, "Starting the job should be persisted")
+	suite.Equal(models.ResourcesCount(0), stored.Job.Processed, "Progress shouldn't be persisted for every URL")
+
+	close(server.release)
+	for i := 0; i < 100; i++ {
+		stored, err = config.store.Jobs().LoadJob("progress")
+		if err == nil && stored.Job.Status == models.HarvestJobStatusCompleted {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	suite.Equal(models.HarvestJobStatusCompleted, stored.Job.Status, "Finishing the job should be persisted")
+	suite.Equal(models.ResourcesCount(2), stored.Job.Processed)
+	suite.NotNil(stored.Job.Resources)
+
+	config.jobs.mutex.Lock()
+	_, inMemory := config.jobs.jobs["progress"]
+	config.jobs.mutex.Unlock()
+	suite.False(inMemory, "Finished jobs should be dropped from memory")
+	finished, err := config.jobs.job("progress")
+	suite.Nil(err, "Finished jobs should be read from the datastore")
+	suite.Equal(models.HarvestJobStatusCompleted, finished.Job.Status)
+}
+
+func (suite *ResolversSuite) TestStopWaitsForWorkers() {
+	server := newBlockingServer("/first")
+	defer server.Close()
+	config := suite.newConfiguration(nil)
+	suite.Nil(config.jobs.enqueue(newJob("interrupted", server.URL+"/first "+server.URL+"/second")))
+	config.jobs.start(suite.span)
+	<-server.arrived
+
+	stopped := make(chan bool)
+	go func() {
+		config.jobs.stop()
+		stopped <- true
+	}()
+	select {
+	case <-stopped:
+		suite.Fail("stop should wait for the worker harvesting a URL")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(server.release)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		suite.Fail("stop should return once the worker is done with its URL")
+	}
+
+	stored, err := config.store.Jobs().LoadJob("interrupted")
+	suite.Nil(err)
+	suite.Equal(models.HarvestJobStatusRunning, stored.Job.Status, "Interrupted jobs should be restarted by the next start")
+}
+
+func (suite *ResolversSuite) TestHarvestProgressOfFinishingJob() {
+	config := suite.newConfiguration(nil)
+	record := newJob("finishing", "no urls")
+	record.SessionID = "SIMULATED"
+	record.Job.Status = models.HarvestJobStatusRunning
+	config.jobs.setCurrent(record)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	running, err := config.jobs.subscribe(ctx, "finishing", "SIMULATED")
+	if !suite.Nil(err, "Unable to subscribe to a running job") {
+		return
+	}
+	finished := *record
+	finished.Job.Status = models.HarvestJobStatusCompleted
+	suite.Nil(config.jobs.update(&finished, nil, true))
+	event, open := <-running
+	if suite.True(open, "The finished job should be sent to subscribers") {
+		suite.Equal(models.HarvestJobStatusCompleted, event.(*models.HarvestJob).Status)
+	}
+	_, open = <-running
+	suite.False(open, "The subscription should end with the job")
+
+	// the finished job is only in the datastore now, new subscribers get its final state right away
+	late, err := config.jobs.subscribe(ctx, "finishing", "SIMULATED")
+	if suite.Nil(err, "Unable to subscribe to a finished job") {
+		event, open = <-late
+		if suite.True(open) {
+			suite.Equal(models.HarvestJobStatusCompleted, event.(*models.HarvestJob).Status)
+		}
+		_, open = <-late
+		suite.False(open, "The subscription of a finished job should end right away")
+	}
+	_, err = config.jobs.subscribe(ctx, "finishing", "OTHER")
+	suite.NotNil(err, "Jobs of other sessions can't be subscribed to")
+}
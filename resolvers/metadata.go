@@ -0,0 +1,201 @@
+package resolvers
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/models"
+	"golang.org/x/net/html"
+)
+
+// publishedAtLayouts are the date formats found in article:published_time and similar tags
+var publishedAtLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// pageMetadata extracts the metadata an HTML response declares in its head, it returns nil for other
+// responses and pages which declare nothing
+func pageMetadata(fetched *fetch.Result) *models.PageMetadata {
+	if !isHTML(fetched) {
+		return nil
+	}
+
+	// the first value of each meta tag, keyed by its lowercase name or property
+	meta := make(map[string]string)
+	var title, language, favicon string
+	inTitle := false
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(fetched.Body))
+	scanning := true
+	for scanning {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			scanning = false
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				scanning = false
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := tokenizer.TagName()
+			attrs := make(map[string]string)
+			for hasAttr {
+				var key, value []byte
+				key, value, hasAttr = tokenizer.TagAttr()
+				attrs[strings.ToLower(string(key))] = strings.TrimSpace(string(value))
+			}
+			switch string(name) {
+			case "body":
+				scanning = false
+			case "html":
+				language = attrs["lang"]
+			case "title":
+				inTitle = title == ""
+			case "meta":
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
+				if key == "" {
+					key = attrs["itemprop"]
+				}
+				if key == "" && attrs["http-equiv"] != "" {
+					key = "http-equiv:" + attrs["http-equiv"]
+				}
+				key = strings.ToLower(key)
+				if _, seen := meta[key]; key != "" && !seen && attrs["content"] != "" {
+					meta[key] = attrs["content"]
+				}
+			case "link":
+				for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+					if rel == "icon" && favicon == "" {
+						favicon = attrs["href"]
+					}
+				}
+			}
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := strings.TrimSpace(meta[key]); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+	smallText := func(value string) *models.SmallText {
+		if value == "" {
+			return nil
+		}
+		result := models.SmallText(value)
+		return &result
+	}
+	mediumText := func(value string) *models.MediumText {
+		if value == "" {
+			return nil
+		}
+		result := models.MediumText(value)
+		return &result
+	}
+	nameText := func(value string) *models.NameText {
+		if value == "" {
+			return nil
+		}
+		result := models.NameText(value)
+		return &result
+	}
+	languageCode := func(value string) *models.LanguageCode {
+		if value == "" {
+			return nil
+		}
+		result := models.LanguageCode(strings.Replace(value, "_", "-", -1))
+		return &result
+	}
+	urlText := func(value string) *models.URLText {
+		if value == "" {
+			return nil
+		}
+		u, err := fetched.URL.Parse(value)
+		if err != nil {
+			return nil
+		}
+		result := urlToString(u)
+		return &result
+	}
+
+	result := new(models.PageMetadata)
+	empty := true
+	if og := first("og:type", "og:title", "og:description", "og:image", "og:url", "og:site_name", "og:locale"); og != "" {
+		result.OpenGraph = &models.OpenGraphMetadata{
+			Type:        smallText(first("og:type")),
+			Title:       smallText(first("og:title")),
+			Description: mediumText(first("og:description")),
+			Image:       urlText(first("og:image", "og:image:url", "og:image:secure_url")),
+			URL:         urlText(first("og:url")),
+			SiteName:    nameText(first("og:site_name")),
+			Locale:      languageCode(first("og:locale")),
+		}
+		empty = false
+	}
+	if card := first("twitter:card", "twitter:site", "twitter:creator", "twitter:title", "twitter:description", "twitter:image"); card != "" {
+		result.TwitterCard = &models.TwitterCardMetadata{
+			Card:        smallText(first("twitter:card")),
+			Site:        nameText(first("twitter:site")),
+			Creator:     nameText(first("twitter:creator")),
+			Title:       smallText(first("twitter:title")),
+			Description: mediumText(first("twitter:description")),
+			Image:       urlText(first("twitter:image", "twitter:image:src")),
+		}
+		empty = false
+	}
+
+	title = strings.Join(strings.Fields(title), " ")
+	if title == "" {
+		title = first("og:title")
+	}
+	result.Title = smallText(title)
+	result.Description = mediumText(first("description", "og:description"))
+	for _, key := range []string{"author", "article:author", "dc.creator"} {
+		// article:author is often the URL of the author's profile page rather than a name
+		if author := first(key); author != "" && !strings.Contains(author, "://") {
+			result.Author = nameText(author)
+			break
+		}
+	}
+	for _, layout := range publishedAtLayouts {
+		published, err := time.Parse(layout, first("article:published_time", "datepublished", "date", "dc.date", "pubdate"))
+		if err == nil {
+			publishedAt := models.NewDateTime(published)
+			result.PublishedAt = &publishedAt
+			break
+		}
+	}
+	result.SiteName = nameText(first("og:site_name", "application-name"))
+	result.Favicon = urlText(favicon)
+	if language == "" {
+		language = first("http-equiv:content-language", "og:locale")
+	}
+	result.Language = languageCode(language)
+
+	if empty && result.Title == nil && result.Description == nil && result.Author == nil && result.PublishedAt == nil &&
+		result.SiteName == nil && result.Favicon == nil && result.Language == nil {
+		return nil
+	}
+	return result
+}
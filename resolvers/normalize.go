@@ -0,0 +1,104 @@
+package resolvers
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/lectio/lectiod/models"
+	"golang.org/x/net/idna"
+)
+
+// urlNormalizer derives the normalized URL of a resource, which identifies duplicates, from its final URL
+type urlNormalizer struct {
+	lowercaseSchemeAndHost bool
+	removeDefaultPort      bool
+	removeFragment         bool
+	trailingSlash          models.TrailingSlashPolicy
+	sortQueryParams        bool
+	punycodeHost           bool
+	foldWWW                bool
+}
+
+// newURLNormalizer converts settings into a urlNormalizer, using defaults for missing values
+func newURLNormalizer(settings *models.URLNormalizationSettings) *urlNormalizer {
+	result := new(urlNormalizer)
+	result.trailingSlash = models.TrailingSlashPolicyKeep
+	if settings == nil {
+		return result
+	}
+
+	// every option is off unless it's set, so the keys of saved resources don't change with the defaults
+	option := func(value *bool) bool {
+		return value != nil && *value
+	}
+	result.lowercaseSchemeAndHost = option(settings.LowercaseSchemeAndHost)
+	result.removeDefaultPort = option(settings.RemoveDefaultPort)
+	result.removeFragment = option(settings.RemoveFragment)
+	result.sortQueryParams = option(settings.SortQueryParams)
+	result.punycodeHost = option(settings.PunycodeHost)
+	result.foldWWW = option(settings.FoldWWW)
+	if settings.TrailingSlash != nil && settings.TrailingSlash.IsValid() {
+		result.trailingSlash = *settings.TrailingSlash
+	}
+	return result
+}
+
+// normalize returns a normalized copy of u
+func (n *urlNormalizer) normalize(u *url.URL) *url.URL {
+	if u == nil {
+		return nil
+	}
+	result := *u
+
+	host, port := result.Hostname(), result.Port()
+	if n.lowercaseSchemeAndHost {
+		result.Scheme = strings.ToLower(result.Scheme)
+		host = strings.ToLower(host)
+	}
+	if n.punycodeHost {
+		// the Punycode profile doesn't map the host, so its case is only changed by lowercaseSchemeAndHost.
+		// Hosts which can't be encoded are kept as they are.
+		if ascii, err := idna.Punycode.ToASCII(host); err == nil {
+			host = ascii
+		}
+	}
+	if n.foldWWW && strings.HasPrefix(strings.ToLower(host), "www.") {
+		host = host[len("www."):]
+	}
+	if n.removeDefaultPort && ((port == "80" && result.Scheme == "http") || (port == "443" && result.Scheme == "https")) {
+		port = ""
+	}
+	result.Host = host
+	if port != "" {
+		result.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		result.Host = "[" + host + "]"
+	}
+
+	if n.removeFragment {
+		result.Fragment = ""
+	}
+
+	switch n.trailingSlash {
+	case models.TrailingSlashPolicyAdd:
+		if !strings.HasSuffix(result.Path, "/") {
+			result.Path += "/"
+			result.RawPath = ""
+		}
+	case models.TrailingSlashPolicyRemove:
+		if len(result.Path) > 1 && strings.HasSuffix(result.Path, "/") {
+			result.Path = strings.TrimRight(result.Path, "/")
+			result.RawPath = ""
+		}
+	}
+	if result.Path == "" && n.trailingSlash != models.TrailingSlashPolicyKeep {
+		result.Path = "/"
+	}
+
+	if n.sortQueryParams && result.RawQuery != "" {
+		// Encode sorts by parameter name, the order of a parameter's values is kept
+		result.RawQuery = result.Query().Encode()
+	}
+	return &result
+}
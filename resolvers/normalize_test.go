@@ -0,0 +1,45 @@
+package resolvers
+
+import (
+	"net/url"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestNormalizeURL() {
+	on, off := true, false
+	trailingSlash := func(policy models.TrailingSlashPolicy) *models.TrailingSlashPolicy {
+		return &policy
+	}
+
+	tests := []struct {
+		name       string
+		settings   *models.URLNormalizationSettings
+		url        string
+		normalized string
+	}{
+		{name: "defaults", url: "HTTPS://Example.COM:443/Path?b=2&a=1#top", normalized: "https://Example.COM:443/Path?b=2&a=1#top"},
+		{name: "unset options", settings: &models.URLNormalizationSettings{}, url: "https://Bücher.DE:443/", normalized: "https://B%C3%BCcher.DE:443/"},
+		{name: "lowercase", settings: &models.URLNormalizationSettings{LowercaseSchemeAndHost: &on}, url: "HTTPS://Example.COM/Path", normalized: "https://example.com/Path"},
+		{name: "remove default port", settings: &models.URLNormalizationSettings{RemoveDefaultPort: &on}, url: "https://example.com:443/", normalized: "https://example.com/"},
+		{name: "default port kept for other scheme", settings: &models.URLNormalizationSettings{RemoveDefaultPort: &on}, url: "http://example.com:443/", normalized: "http://example.com:443/"},
+		{name: "non-default port", settings: &models.URLNormalizationSettings{RemoveDefaultPort: &on}, url: "http://example.com:8080/", normalized: "http://example.com:8080/"},
+		{name: "punycode", settings: &models.URLNormalizationSettings{PunycodeHost: &on, LowercaseSchemeAndHost: &on}, url: "https://Bücher.de/", normalized: "https://xn--bcher-kva.de/"},
+		{name: "punycode keeps case when not lowercasing", settings: &models.URLNormalizationSettings{PunycodeHost: &on, LowercaseSchemeAndHost: &off}, url: "https://Example.COM/", normalized: "https://Example.COM/"},
+		{name: "punycode keeps unicode case when not lowercasing", settings: &models.URLNormalizationSettings{PunycodeHost: &on}, url: "https://Bücher.DE/", normalized: "https://xn--Bcher-kva.DE/"},
+		{name: "no punycode", settings: &models.URLNormalizationSettings{PunycodeHost: &off}, url: "https://bücher.de/", normalized: "https://b%C3%BCcher.de/"},
+		{name: "remove fragment", settings: &models.URLNormalizationSettings{RemoveFragment: &on}, url: "https://example.com/page#top", normalized: "https://example.com/page"},
+		{name: "sort query params", settings: &models.URLNormalizationSettings{SortQueryParams: &on}, url: "https://example.com/?b=2&a=1&b=1", normalized: "https://example.com/?a=1&b=2&b=1"},
+		{name: "fold www", settings: &models.URLNormalizationSettings{FoldWWW: &on}, url: "https://www.example.com/", normalized: "https://example.com/"},
+		{name: "add trailing slash", settings: &models.URLNormalizationSettings{TrailingSlash: trailingSlash(models.TrailingSlashPolicyAdd)}, url: "https://example.com/page", normalized: "https://example.com/page/"},
+		{name: "add trailing slash to empty path", settings: &models.URLNormalizationSettings{TrailingSlash: trailingSlash(models.TrailingSlashPolicyAdd)}, url: "https://example.com", normalized: "https://example.com/"},
+		{name: "remove trailing slash", settings: &models.URLNormalizationSettings{TrailingSlash: trailingSlash(models.TrailingSlashPolicyRemove)}, url: "https://example.com/page//", normalized: "https://example.com/page"},
+		{name: "remove trailing slash keeps root", settings: &models.URLNormalizationSettings{TrailingSlash: trailingSlash(models.TrailingSlashPolicyRemove)}, url: "https://example.com/", normalized: "https://example.com/"},
+		{name: "IPv6 host", settings: &models.URLNormalizationSettings{RemoveDefaultPort: &on}, url: "http://[::1]:80/", normalized: "http://[::1]/"},
+	}
+	for _, test := range tests {
+		u, err := url.Parse(test.url)
+		suite.Nil(err, test.name)
+		suite.Equal(test.normalized, newURLNormalizer(test.settings).normalize(u).String(), test.name)
+	}
+}
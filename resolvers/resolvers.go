@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/persistence"
 	observe "github.com/shah/observe-go"
 
 	opentracing "github.com/opentracing/opentracing-go"
@@ -25,6 +26,8 @@ type ServiceHandler struct {
 	simulatedSession models.AuthenticatedSession
 	mutators         *mutation
 	queries          *query
+	subscriptions    *subscription
+	resources        *harvestedResource
 }
 type mutation struct {
 	handler *ServiceHandler
@@ -32,6 +35,9 @@ type mutation struct {
 type query struct {
 	handler *ServiceHandler
 }
+type subscription struct {
+	handler *ServiceHandler
+}
 
 func (h *ServiceHandler) Close() {
 	for _, config := range h.configs {
@@ -63,6 +69,12 @@ func NewSchemaResolvers(observatory observe.Observatory, configPath ConfigPathPr
 	result.queries = new(query)
 	result.queries.handler = result
 
+	result.subscriptions = new(subscription)
+	result.subscriptions.handler = result
+
+	result.resources = new(harvestedResource)
+	result.resources.handler = result
+
 	return result
 }
 
@@ -74,10 +86,23 @@ func (h *ServiceHandler) Query() QueryResolver {
 	return h.queries
 }
 
+func (h *ServiceHandler) Subscription() SubscriptionResolver {
+	return h.subscriptions
+}
+
+func (h *ServiceHandler) HarvestedResource() HarvestedResourceResolver {
+	return h.resources
+}
+
 func (h *ServiceHandler) DefaultConfiguration() *Configuration {
 	return h.defaultConfig
 }
 
+// Configurations returns the configuration of every loaded settings bundle
+func (h *ServiceHandler) Configurations() ConfigurationsMap {
+	return h.configs
+}
+
 func (h *ServiceHandler) ValidateAuthorization(ctx context.Context, authorization models.AuthorizationInput) (models.AuthenticatedSession, error) {
 	span, ctx := h.observatory.StartTraceFromContext(ctx, "ValidateSession")
 	defer span.Finish()
@@ -89,6 +114,7 @@ func (h *ServiceHandler) ValidateAuthorization(ctx context.Context, authorizatio
 		span.LogFields(log.Error(error))
 		return nil, error
 	}
+	requestScopeFromContext(ctx).setSettingsBundle(session.GetSettingsBundleName())
 	return session, nil
 }
 
@@ -149,7 +175,7 @@ func (q *query) SettingsBundle(ctx context.Context, authorization models.Privile
 	return nil, nil
 }
 
-func (q *query) UrlsInText(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText) (*models.HarvestedResources, error) {
+func (q *query) UrlsInText(ctx context.Context, authorization models.AuthorizationInput, text models.LargeText, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error) {
 	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_urlsInText")
 	defer span.Finish()
 
@@ -166,58 +192,49 @@ func (q *query) UrlsInText(ctx context.Context, authorization models.Authorizati
 		return nil, error
 	}
 
-	result := new(models.HarvestedResources)
-	result.Text = models.LargeText(text)
+	contentHarvester, err := conf.harvesterWithDirectives(q.handler, directives, span)
+	if err != nil {
+		return nil, err
+	}
+	return contentHarvester.harvestText(text, span), nil
+}
+
+func (q *query) UrlsInDocument(ctx context.Context, authorization models.AuthorizationInput, document models.Document, format models.DocumentFormat, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_urlsInDocument")
+	defer span.Finish()
 
-	r := conf.contentHarvester.HarvestResources(string(text), span)
-	for _, res := range r.Resources {
-		isURLValid, isDestValid := res.IsValid()
-		if !isURLValid {
-			result.Invalid = append(result.Invalid, &models.UnharvestedResource{URL: models.URLText(res.OriginalURLText()), Reason: "Invalid URL"})
-			continue
-		}
-		if !isDestValid {
-			isIgnored, ignoreReason := res.IsIgnored()
-			if isIgnored {
-				result.Invalid = append(result.Invalid, &models.UnharvestedResource{URL: models.URLText(res.OriginalURLText()), Reason: models.SmallText(fmt.Sprintf("Invalid URL Destination: %v", ignoreReason))})
-			} else {
-				result.Invalid = append(result.Invalid, &models.UnharvestedResource{URL: models.URLText(res.OriginalURLText()), Reason: models.SmallText("Invalid URL Destination: unkown reason")})
-			}
-			continue
-		}
+	authSess, sessErr := q.handler.ValidateAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
 
-		finalURL, resolvedURL, cleanedURL := res.GetURLs()
-		isHTMLRedirect, redirectURL := res.IsHTMLRedirect()
-		isCleaned, _ := res.IsCleaned()
-
-		isIgnored, ignoreReason := res.IsIgnored()
-		if isIgnored {
-			result.Ignored = append(result.Ignored, &models.IgnoredResource{
-				Urls: models.HarvestedResourceUrls{
-					Original: models.URLText(res.OriginalURLText()),
-					Final:    urlToString(finalURL),
-					Cleaned:  urlToString(cleanedURL),
-					Resolved: urlToString(resolvedURL),
-				},
-				Reason: models.SmallText(fmt.Sprintf("Ignored: %s", ignoreReason)),
-			})
-			continue
-		}
+	conf := q.handler.configs[authSess.GetSettingsBundleName()]
+	if conf == nil {
+		error := fmt.Errorf("Unable to run query: config '%s' not found", authSess.GetSettingsBundleName())
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
 
-		redirectURLText := models.URLText(redirectURL)
-		result.Harvested = append(result.Harvested, &models.HarvestedResource{
-			Urls: models.HarvestedResourceUrls{
-				Original: models.URLText(res.OriginalURLText()),
-				Final:    urlToString(finalURL),
-				Cleaned:  urlToString(cleanedURL),
-				Resolved: urlToString(resolvedURL),
-			},
-			IsCleaned:      isCleaned,
-			IsHTMLRedirect: isHTMLRedirect,
-			RedirectURL:    &redirectURLText,
-		})
+	contentHarvester, err := conf.harvesterWithDirectives(q.handler, directives, span)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	return contentHarvester.harvestDocument(string(document), format, span), nil
+}
+
+// UrlsInFile harvests the URLs in an uploaded file, its format is guessed from its content type or name if it's
+// not given
+func (q *query) UrlsInFile(ctx context.Context, authorization models.AuthorizationInput, file models.File, format *models.DocumentFormat, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error) {
+	uploaded, err := uploadedFile(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	documentFormat := documentFormat(uploaded)
+	if format != nil {
+		documentFormat = *format
+	}
+	return q.UrlsInDocument(ctx, authorization, models.Document(uploaded.Content), documentFormat, directives)
 }
 
 func (m *mutation) EstablishSimulatedSession(ctx context.Context, authorization models.PrivilegedAuthorizationInput, config models.SettingsBundleName) (models.AuthenticatedSession, error) {
@@ -236,21 +253,45 @@ func (m *mutation) RefreshSession(ctx context.Context, privilegedAuthz models.Pr
 	return nil, errors.New("Mutation refreshSession (for JWT refreshes) not implemented yet")
 }
 
-func (m *mutation) SaveURLsinText(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, text models.LargeText) (*models.HarvestedResources, error) {
+func (m *mutation) SaveURLsinText(ctx context.Context, authorization models.AuthorizationInput, destination models.StorageDestinationInput, text models.LargeText, directives *models.HarvestDirectivesInput) (*models.HarvestedResources, error) {
 	span, ctx := m.handler.observatory.StartTraceFromContext(ctx, "Mutation_saveURLsinText")
 	defer span.Finish()
 
-	resources, err := m.handler.queries.UrlsInText(ctx, authorization, text)
-	if err == nil {
-		switch destination.Collection {
-		case models.StorageDestinationCollectionSessionPrincipal:
-		case models.StorageDestinationCollectionSessionTenant:
-			fmt.Print("here")
-		default:
-			error := fmt.Errorf("Unknown destination.Collection: '%s'", destination.Collection)
+	resources, err := m.handler.queries.UrlsInText(ctx, authorization, text, directives)
+	if err != nil {
+		return resources, err
+	}
+
+	err = m.saveResources(ctx, span, authorization, destination, resources)
+	return resources, err
+}
+
+// saveResources saves resources harvested for the session in authorization to destination
+func (m *mutation) saveResources(ctx context.Context, span opentracing.Span, authorization models.AuthorizationInput, destination models.StorageDestinationInput, resources *models.HarvestedResources) error {
+	switch destination.Collection {
+	case models.StorageDestinationCollectionSessionPrincipal, models.StorageDestinationCollectionSessionTenant:
+		authSess, sessErr := m.handler.ValidateAuthorization(ctx, authorization)
+		if sessErr != nil {
+			return sessErr
+		}
+		conf := m.handler.configs[authSess.GetSettingsBundleName()]
+		err := conf.store.Resources().SaveHarvestedResources(destination, resources)
+		if quotaErr, ok := err.(*persistence.QuotaExceededError); ok {
+			opentrext.Error.Set(span, true)
+			span.LogFields(log.Error(quotaErr))
+			return quotaErr
+		}
+		if err != nil {
+			error := fmt.Errorf("Unable to save resources to '%s' in %s: %v", destination.Key, destination.Collection, err)
 			opentrext.Error.Set(span, true)
 			span.LogFields(log.Error(error))
+			return error
 		}
+	default:
+		error := fmt.Errorf("Unknown destination.Collection: '%s'", destination.Collection)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return error
 	}
-	return resources, err
+	return nil
 }
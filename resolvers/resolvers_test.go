@@ -0,0 +1,69 @@
+package resolvers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	observe "github.com/shah/observe-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResolversSuite struct {
+	suite.Suite
+	observatory observe.Observatory
+	span        opentracing.Span
+	handler     *ServiceHandler
+	configs     []*Configuration
+	paths       []string
+}
+
+func (suite *ResolversSuite) SetupSuite() {
+	observatory := observe.MakeObservatoryFromEnv()
+	suite.observatory = observatory
+	suite.span = observatory.StartTrace("ResolversSuite")
+	suite.handler = new(ServiceHandler)
+	suite.handler.observatory = observatory
+	suite.handler.configs = make(ConfigurationsMap)
+}
+
+func (suite *ResolversSuite) TearDownTest() {
+	for _, config := range suite.configs {
+		config.Close()
+	}
+	suite.configs = nil
+}
+
+func (suite *ResolversSuite) TearDownSuite() {
+	for _, path := range suite.paths {
+		os.RemoveAll(path)
+	}
+	suite.span.Finish()
+	suite.observatory.Close()
+}
+
+// newConfiguration sets up a bundle with the default settings changed by configure (which may be nil),
+// stored in a temp directory. It's closed after the test.
+func (suite *ResolversSuite) newConfiguration(configure func(*models.SettingsBundle)) *Configuration {
+	path, err := ioutil.TempDir("", "lectiod-resolvers")
+	suite.Nil(err, "Unable to create temp directory")
+	suite.paths = append(suite.paths, path)
+
+	result := new(Configuration)
+	result.settings = createDefaultSettings("TEST")
+	result.settings.Storage.Filesys.BasePath = models.DirectoryPath(filepath.Join(path, "flatfs"))
+	if configure != nil {
+		configure(result.settings)
+	}
+	result.ConfigureContentHarvester(suite.handler, suite.span)
+	suite.True(result.store.IsValid(), "Datastore should be valid: %v", result.store.GetError())
+	suite.configs = append(suite.configs, result)
+	return result
+}
+
+func TestResolversSuite(t *testing.T) {
+	suite.Run(t, new(ResolversSuite))
+}
@@ -0,0 +1,97 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/persistence"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DefaultRobotsTxtCacheTTL is used when a settings bundle doesn't configure harvest.robotsTxtCacheMinutes
+const DefaultRobotsTxtCacheTTL = 24 * time.Hour
+
+// RobotsTxtRetryTTL is how long a failed robots.txt request (a server error or no response at all) is
+// cached, when it's shorter than the bundle's TTL, so the host isn't asked again for every URL
+const RobotsTxtRetryTTL = 10 * time.Minute
+
+// robotsDisallowedError is returned by robotsChecker.filter for URLs robots.txt doesn't allow us to fetch
+type robotsDisallowedError struct {
+	URL  *url.URL
+	Rule string
+}
+
+func (e *robotsDisallowedError) Error() string {
+	return fmt.Sprintf("Disallowed by robots.txt rule `%s`", e.Rule)
+}
+
+// robotsChecker decides whether URLs may be fetched according to their host's robots.txt, which is
+// cached in the settings bundle's datastore
+type robotsChecker struct {
+	config  *Configuration
+	fetcher *fetch.Client
+	ttl     time.Duration
+}
+
+func newRobotsChecker(config *Configuration, fetcher *fetch.Client) *robotsChecker {
+	result := new(robotsChecker)
+	result.config = config
+	result.fetcher = fetcher
+	result.ttl = DefaultRobotsTxtCacheTTL
+	if config.settings.Harvest.RobotsTxtCacheMinutes != nil {
+		result.ttl = time.Duration(*config.settings.Harvest.RobotsTxtCacheMinutes) * time.Minute
+	}
+	return result
+}
+
+// robotsTxt returns the robots.txt rules of u's origin, fetching them if they're not cached or the
+// cached copy is older than the TTL
+func (r *robotsChecker) robotsTxt(u *url.URL, span opentracing.Span) *fetch.RobotsTxt {
+	origin := &url.URL{Scheme: u.Scheme, Host: u.Host}
+	robots := r.config.Store().Robots()
+
+	record, err := robots.LoadRobotsTxt(origin)
+	if err == nil && time.Since(record.FetchedAt) < r.recordTTL(record) {
+		span.LogFields(log.String("robots.cache", "hit"), log.String("origin", origin.String()))
+		return fetch.NewRobotsTxt(record.StatusCode, record.Body)
+	}
+	span.LogFields(log.String("robots.cache", "miss"), log.String("origin", origin.String()))
+
+	record = &persistence.RobotsTxtRecord{Origin: origin.String(), FetchedAt: time.Now()}
+	fetched, err := r.fetcher.FetchRobotsTxt(origin, span)
+	if err == nil {
+		record.StatusCode, record.Body = fetched.StatusCode, fetched.Body
+	} else {
+		// a status of 0 allows everything, an unreachable host will most likely fail the request being
+		// checked anyway
+		span.LogFields(log.String("robots.fetch", "failed"), log.Error(err))
+	}
+	err = robots.SaveRobotsTxt(origin, record)
+	if err != nil {
+		error := fmt.Errorf("Unable to cache robots.txt of %s: %v", origin, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+	}
+	return fetch.NewRobotsTxt(record.StatusCode, record.Body)
+}
+
+// recordTTL returns how long record may be used, failed requests are retried sooner
+func (r *robotsChecker) recordTTL(record *persistence.RobotsTxtRecord) time.Duration {
+	if (record.StatusCode == 0 || record.StatusCode >= 500) && RobotsTxtRetryTTL < r.ttl {
+		return RobotsTxtRetryTTL
+	}
+	return r.ttl
+}
+
+// filter is the fetch.RequestFilter which stops requests robots.txt disallows
+func (r *robotsChecker) filter(u *url.URL, span opentracing.Span) error {
+	allowed, rule := r.robotsTxt(u, span).Allowed(r.fetcher.Policy().UserAgent, u)
+	if allowed {
+		return nil
+	}
+	return &robotsDisallowedError{URL: u, Rule: rule}
+}
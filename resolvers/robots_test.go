@@ -0,0 +1,93 @@
+package resolvers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+)
+
+// robotsServer serves robots.txt with status and counts the requests for it
+type robotsServer struct {
+	*httptest.Server
+	status   int32
+	requests int32
+}
+
+func newRobotsServer(status int) *robotsServer {
+	result := &robotsServer{status: int32(status)}
+	result.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, "<html><body>page</body></html>")
+			return
+		}
+		atomic.AddInt32(&result.requests, 1)
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(int(atomic.LoadInt32(&result.status)))
+		fmt.Fprint(w, "User-agent: *\nDisallow: /private/\n")
+	}))
+	return result
+}
+
+func (suite *ResolversSuite) TestRobotsTxtCache() {
+	tests := []struct {
+		name     string
+		status   int
+		down     bool
+		private  bool
+		public   bool
+		retryTTL bool
+	}{
+		{name: "parsed", status: 200, public: true},
+		{name: "missing", status: 404, private: true, public: true},
+		{name: "server error", status: 503, retryTTL: true},
+		{name: "unreachable", down: true, private: true, public: true, retryTTL: true},
+	}
+	for _, test := range tests {
+		server := newRobotsServer(test.status)
+		if test.down {
+			server.Close()
+		}
+		config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+			settings.Harvest.RespectRobotsTxt = true
+		})
+		checker := config.robots
+
+		check := func(path string) bool {
+			u, err := url.Parse(server.URL + path)
+			suite.Nil(err, test.name)
+			return checker.filter(u, suite.span) == nil
+		}
+		suite.Equal(test.private, check("/private/page"), test.name)
+		suite.Equal(test.public, check("/page"), test.name)
+		suite.Equal(test.public, check("/other"), test.name)
+		if !test.down {
+			suite.Equal(int32(1), atomic.LoadInt32(&server.requests), "%s: robots.txt should be requested once", test.name)
+		}
+
+		// a cached copy just past the retry TTL is only used if the request succeeded
+		origin, _ := url.Parse(server.URL)
+		record, err := config.Store().Robots().LoadRobotsTxt(origin)
+		if !suite.Nil(err, "%s: robots.txt should be cached", test.name) {
+			continue
+		}
+		record.FetchedAt = time.Now().Add(-RobotsTxtRetryTTL - time.Minute)
+		suite.Nil(config.Store().Robots().SaveRobotsTxt(origin, record), test.name)
+		atomic.StoreInt32(&server.status, http.StatusOK)
+		check("/page")
+		if test.down {
+			suite.Equal(0, record.StatusCode, test.name)
+		} else if test.retryTTL {
+			suite.Equal(int32(2), atomic.LoadInt32(&server.requests), "%s: robots.txt should be requested again", test.name)
+			suite.False(check("/private/page"), "%s: the new robots.txt should be used", test.name)
+		} else {
+			suite.Equal(int32(1), atomic.LoadInt32(&server.requests), "%s: robots.txt shouldn't be requested again", test.name)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,137 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"sync/atomic"
+
+	"github.com/lectio/lectiod/models"
+)
+
+// harvestRuleStats describes a rule and how often it fired since the bundle was configured
+func (r *harvestRule) harvestRuleStats(kind models.HarvestRuleKind) *models.HarvestRuleStats {
+	return &models.HarvestRuleStats{
+		Kind:        kind,
+		Name:        r.name,
+		Description: r.description,
+		Enabled:     r.enabled,
+		RegEx:       models.RegularExpression(r.regEx.String()),
+		Hits:        models.HitsCount(atomic.LoadUint64(&r.hits)),
+	}
+}
+
+// harvestRuleStats lists the bundle's ignore and cleaner rules, followed by the cleaner rules of its
+// per-domain overrides
+func (c *Configuration) harvestRuleStats() []*models.HarvestRuleStats {
+	var result []*models.HarvestRuleStats
+	for _, rule := range c.ignoreURLsRegEx {
+		result = append(result, rule.harvestRuleStats(models.HarvestRuleKindIgnoreUrl))
+	}
+	for _, rule := range c.removeParamsFromURLsRegEx {
+		result = append(result, rule.harvestRuleStats(models.HarvestRuleKindRemoveParam))
+	}
+	if c.domains == nil {
+		return result
+	}
+	for _, override := range c.domains.overrides {
+		domain := models.DomainName(override.domain)
+		for _, rule := range override.removeParamsFromURLsRegEx {
+			stats := rule.harvestRuleStats(models.HarvestRuleKindRemoveParam)
+			stats.Domain = &domain
+			result = append(result, stats)
+		}
+	}
+	return result
+}
+
+// testHarvestRules reports which of the bundle's rules fire for urlText without fetching it or counting hits
+func (c *Configuration) testHarvestRules(urlText models.URLText) *models.HarvestRulesTest {
+	result := new(models.HarvestRulesTest)
+	result.URL = urlText
+
+	u, err := url.Parse(string(urlText))
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return result
+	}
+	result.IsValid = true
+
+	// as when harvesting, the rules are applied to the URL and to its canonical URL, the page isn't fetched so
+	// the canonical URL it declares isn't known
+	canonical, _ := c.contentHarvester.canonicalizers.canonicalURL(u, nil)
+	for _, candidate := range ruleURLs(u, canonical) {
+		if reason := c.domains.ignoreReason(candidate); reason != nil {
+			rule := models.RuleIdentifier(reason.rule)
+			if reason.code == models.ReasonCodeDomainNotAllowed {
+				rule = "allowDomains"
+			}
+			result.IgnoreRule = &rule
+			return result
+		}
+		if rule := c.ignoreURLsRegEx.match(candidate); rule != nil {
+			result.IgnoreRule = &rule.name
+			return result
+		}
+	}
+	u = canonical
+
+	query := u.Query()
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	cleaner := c.domains.cleaner(u, c.removeParamsFromURLsRegEx)
+	for _, param := range params {
+		if rule := cleaner.match(param); rule != nil {
+			result.RemovedParams = append(result.RemovedParams, &models.RemovedQueryParam{Param: models.SmallText(param), Rule: rule.name})
+			query.Del(param)
+		}
+	}
+	cleaned := *u
+	if len(result.RemovedParams) > 0 {
+		cleaned.RawQuery = query.Encode()
+	}
+	cleanedText := urlToString(&cleaned)
+	result.Cleaned = &cleanedText
+	return result
+}
+
+// HarvestRuleStats lists the bundle's ignore and cleaner rules with their hit counters
+func (q *query) HarvestRuleStats(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) ([]*models.HarvestRuleStats, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_harvestRuleStats")
+	defer span.Finish()
+
+	_, sessErr := q.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := q.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	return conf.harvestRuleStats(), nil
+}
+
+// TestHarvestRules shows which of the bundle's rules would fire for each of urls, nothing is fetched
+// so the URLs are tested as given rather than where they redirect to
+func (q *query) TestHarvestRules(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName, urls []models.URLText) ([]*models.HarvestRulesTest, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_testHarvestRules")
+	defer span.Finish()
+
+	_, sessErr := q.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := q.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.HarvestRulesTest, 0, len(urls))
+	for _, urlText := range urls {
+		result = append(result, conf.testHarvestRules(urlText))
+	}
+	return result, nil
+}
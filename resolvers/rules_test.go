@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"net/url"
+
+	"github.com/lectio/lectiod/models"
+)
+
+func (suite *ResolversSuite) TestHarvestRuleStats() {
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		ref := models.RegularExpression(`^ref$`)
+		settings.Harvest.DomainRules = []*models.DomainHarvestRules{
+			{Domain: "Amazon.com", RemoveParamsFromURLsRegEx: []*models.RegularExpression{&ref}},
+			{Domain: "docs.example.com"},
+		}
+	})
+	amazon, _ := url.Parse("https://www.amazon.com/dp/B0001?ref=abc&utm_source=test&id=1")
+	config.domains.cleaner(amazon, config.removeParamsFromURLsRegEx).cleanURL(amazon)
+
+	tests := []struct {
+		kind   models.HarvestRuleKind
+		name   models.RuleIdentifier
+		domain models.DomainName
+		hits   models.HitsCount
+	}{
+		{kind: models.HarvestRuleKindIgnoreUrl, name: `^https://twitter.com/(.*?)/status/(.*)$`},
+		{kind: models.HarvestRuleKindIgnoreUrl, name: `https://t.co`},
+		{kind: models.HarvestRuleKindRemoveParam, name: `^utm_`, hits: 1},
+		{kind: models.HarvestRuleKindRemoveParam, name: `^ref$`, domain: "amazon.com", hits: 1},
+	}
+	stats := config.harvestRuleStats()
+	if !suite.Len(stats, len(tests), "Every bundle and domain rule should be listed") {
+		return
+	}
+	for i, test := range tests {
+		stat := stats[i]
+		suite.Equal(test.kind, stat.Kind, string(test.name))
+		suite.Equal(test.name, stat.Name, string(test.name))
+		suite.Equal(test.hits, stat.Hits, string(test.name))
+		if test.domain == "" {
+			suite.Nil(stat.Domain, "%s: bundle rules have no domain", test.name)
+		} else if suite.NotNil(stat.Domain, string(test.name)) {
+			suite.Equal(test.domain, *stat.Domain, string(test.name))
+		}
+	}
+}
+
+func (suite *ResolversSuite) TestTestHarvestRules() {
+	config := suite.newConfiguration(func(settings *models.SettingsBundle) {
+		denied := models.DomainName("ads.example.com")
+		settings.Harvest.DenyDomains = []*models.DomainName{&denied}
+		ignored := models.RegularExpression(`^https://www\.google\.com/url\?q=https://example\.com/old`)
+		settings.Harvest.IgnoreURLsRegExprs = append(settings.Harvest.IgnoreURLsRegExprs, &ignored)
+		settings.Harvest.Canonicalizers = enabledCanonicalizers()
+	})
+
+	tests := []struct {
+		url           models.URLText
+		valid         bool
+		ignoreRule    models.RuleIdentifier
+		removedParams []string
+		cleaned       models.URLText
+	}{
+		{url: "not a url"},
+		{url: "https://ads.example.com/banner", valid: true, ignoreRule: "ads.example.com"},
+		{url: "https://twitter.com/lectio/status/1", valid: true, ignoreRule: `^https://twitter.com/(.*?)/status/(.*)$`},
+		{url: "https://www.google.com/url?q=https://ads.example.com/banner", valid: true, ignoreRule: "ads.example.com"},
+		{url: "https://www.google.com/url?q=https://twitter.com/lectio/status/1", valid: true, ignoreRule: `^https://twitter.com/(.*?)/status/(.*)$`},
+		{url: "https://www.google.com/url?q=https://example.com/old", valid: true, ignoreRule: `^https://www\.google\.com/url\?q=https://example\.com/old`},
+		{url: "https://example.com/page?utm_source=a&utm_medium=b&id=1", valid: true, removedParams: []string{"utm_medium", "utm_source"}, cleaned: "https://example.com/page?id=1"},
+		{url: "https://example.com/page?id=1", valid: true, cleaned: "https://example.com/page?id=1"},
+	}
+	for _, test := range tests {
+		result := config.testHarvestRules(test.url)
+		suite.Equal(test.valid, result.IsValid, string(test.url))
+		if test.ignoreRule != "" {
+			if suite.NotNil(result.IgnoreRule, string(test.url)) {
+				suite.Equal(test.ignoreRule, *result.IgnoreRule, string(test.url))
+			}
+			continue
+		}
+		suite.Nil(result.IgnoreRule, string(test.url))
+		if !test.valid {
+			continue
+		}
+		var removed []string
+		for _, param := range result.RemovedParams {
+			removed = append(removed, string(param.Param))
+		}
+		suite.Equal(test.removedParams, removed, string(test.url))
+		if suite.NotNil(result.Cleaned, string(test.url)) {
+			suite.Equal(test.cleaned, *result.Cleaned, string(test.url))
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package resolvers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lectio/lectiod/models"
+)
+
+// requestScope is shared by the resolvers of one HTTP request or websocket connection. Root resolvers record
+// what they learn about the request in it so the fields of their results, which are resolved separately,
+// can use it.
+type requestScope struct {
+	mutex  sync.Mutex
+	bundle models.SettingsBundleName
+}
+
+type requestScopeKey struct{}
+
+// WithRequestScope returns a copy of ctx with a new request scope, the server calls it for every request
+func WithRequestScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestScopeKey{}, new(requestScope))
+}
+
+// requestScopeFromContext returns the request scope of ctx, or nil if it has none
+func requestScopeFromContext(ctx context.Context) *requestScope {
+	scope, _ := ctx.Value(requestScopeKey{}).(*requestScope)
+	return scope
+}
+
+// setSettingsBundle records the settings bundle of the session the request was authorized with
+func (s *requestScope) setSettingsBundle(bundle models.SettingsBundleName) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bundle = bundle
+}
+
+// settingsBundle returns the settings bundle the request was authorized for, or "" if it isn't known
+func (s *requestScope) settingsBundle() models.SettingsBundleName {
+	if s == nil {
+		return ""
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.bundle
+}
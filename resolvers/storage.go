@@ -0,0 +1,99 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lectio/lectiod/models"
+	opentracing "github.com/opentracing/opentracing-go"
+	opentrext "github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// bundleConfiguration returns the configuration of the named settings bundle or an error if it's not loaded
+func (h *ServiceHandler) bundleConfiguration(name models.SettingsBundleName, span opentracing.Span) (*Configuration, error) {
+	conf := h.configs[name]
+	if conf == nil {
+		error := fmt.Errorf("Settings bundle '%s' not found", name)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+	return conf, nil
+}
+
+// BackupStorage writes every record of the bundle's datastore into a compressed archive in Storage.BackupsPath
+func (m *mutation) BackupStorage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageBackup, error) {
+	span, ctx := m.handler.observatory.StartTraceFromContext(ctx, "Mutation_backupStorage")
+	defer span.Finish()
+
+	_, sessErr := m.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := m.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	return conf.store.BackupToFile(bundle, span)
+}
+
+// RestoreStorage writes every record in a backup archive from Storage.BackupsPath into the bundle's datastore,
+// which may use a different StorageType than the one the backup was taken from
+func (m *mutation) RestoreStorage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName, file models.FilePathAndName) (*models.StorageBackup, error) {
+	span, ctx := m.handler.observatory.StartTraceFromContext(ctx, "Mutation_restoreStorage")
+	defer span.Finish()
+
+	_, sessErr := m.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := m.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	return conf.store.RestoreFromFile(file, span)
+}
+
+// StorageUsage reports how many records and bytes the bundle's datastore holds, overall and per collection
+func (q *query) StorageUsage(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageUsage, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_storageUsage")
+	defer span.Finish()
+
+	_, sessErr := q.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := q.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	result, err := conf.store.Usage(bundle)
+	if err != nil {
+		error := fmt.Errorf("Unable to compute storage usage of bundle '%s': %v", bundle, err)
+		opentrext.Error.Set(span, true)
+		span.LogFields(log.Error(error))
+		return nil, error
+	}
+	return result, nil
+}
+
+// StorageRetentionReport is a dry run of the retention sweeper, listing the saved resources it would delete now
+func (q *query) StorageRetentionReport(ctx context.Context, authorization models.PrivilegedAuthorizationInput, bundle models.SettingsBundleName) (*models.StorageRetentionReport, error) {
+	span, ctx := q.handler.observatory.StartTraceFromContext(ctx, "Query_storageRetentionReport")
+	defer span.Finish()
+
+	_, sessErr := q.handler.ValidatePrivilegedAuthorization(ctx, authorization)
+	if sessErr != nil {
+		return nil, sessErr
+	}
+
+	conf, err := q.handler.bundleConfiguration(bundle, span)
+	if err != nil {
+		return nil, err
+	}
+	return conf.store.SweepExpiredResources(bundle, true, span)
+}
@@ -0,0 +1,38 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+
+	"github.com/lectio/lectiod/models"
+)
+
+type uploadedFilesKey struct{}
+
+// WithUploadedFiles returns a copy of ctx holding the files of a multipart request, keyed by the names of their
+// parts, which the request's File variables are set to
+func WithUploadedFiles(ctx context.Context, files map[string]*multipart.FileHeader) context.Context {
+	return context.WithValue(ctx, uploadedFilesKey{}, files)
+}
+
+// uploadedFile returns file with the name, content type and content of the part it names
+func uploadedFile(ctx context.Context, file models.File) (*models.File, error) {
+	files, _ := ctx.Value(uploadedFilesKey{}).(map[string]*multipart.FileHeader)
+	header := files[file.Part]
+	if header == nil {
+		return nil, fmt.Errorf("No file was uploaded as '%s', files must be uploaded with a multipart request", file.Part)
+	}
+	opened, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open uploaded file '%s': %v", header.Filename, err)
+	}
+	defer opened.Close()
+	content, err := ioutil.ReadAll(opened)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read uploaded file '%s': %v", header.Filename, err)
+	}
+	result := &models.File{Part: file.Part, Name: header.Filename, ContentType: header.Header.Get("Content-Type"), Content: content}
+	return result, nil
+}
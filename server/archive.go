@@ -0,0 +1,97 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/resolvers"
+	"github.com/lectio/lectiod/warc"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	observe "github.com/shah/observe-go"
+)
+
+// replayedHeaders are the archived response headers which aren't sent back when an archived page is replayed
+var replayedHeaders = map[string]bool{
+	"Connection":                true,
+	"Keep-Alive":                true,
+	"Transfer-Encoding":         true,
+	"Content-Length":            true,
+	"Set-Cookie":                true,
+	"Strict-Transport-Security": true,
+	"Content-Security-Policy":   true,
+}
+
+// sessionIDHeader carries the session ID of archive replays which don't pass it as the sessionID parameter
+const sessionIDHeader = "X-Lectio-Session-ID"
+
+// replayAuthorization returns the session claim of an archive replay request, from the sessionID parameter
+// or from the sessionIDHeader
+func replayAuthorization(r *http.Request) models.AuthorizationInput {
+	result := models.AuthorizationInput{ClaimType: models.AuthorizationClaimTypeSessionId}
+	if id := r.URL.Query().Get("sessionID"); id != "" {
+		sessionID := models.AuthenticatedSessionID(id)
+		result.ClaimMedium, result.SessionID = models.AuthorizationClaimMediumParamValue, &sessionID
+	} else if id := r.Header.Get(sessionIDHeader); id != "" {
+		sessionID := models.AuthenticatedSessionID(id)
+		result.ClaimMedium, result.SessionID = models.AuthorizationClaimMediumHttpHeader, &sessionID
+	}
+	return result
+}
+
+// createArchiveReplayHandler serves the archived responses whose replayPath a harvested resource reports
+func createArchiveReplayHandler(o observe.Observatory, serviceHandler *resolvers.ServiceHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		span, _ := o.StartTraceFromContext(r.Context(), "HTTP Archive Replay")
+		defer span.Finish()
+		ext.SpanKind.Set(span, "server")
+		ext.HTTPMethod.Set(span, r.Method)
+		ext.HTTPUrl.Set(span, r.URL.String())
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		record, err := serviceHandler.ArchivedResponse(r.Context(), replayAuthorization(r), strings.TrimPrefix(r.URL.EscapedPath(), resolvers.ArchiveReplayPath), span)
+		switch {
+		case err == resolvers.ErrArchiveUnauthorized:
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case err == resolvers.ErrArchiveForbidden:
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		response, body, err := warc.ReadHTTPResponse(record.Block)
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+			http.Error(w, "Unreadable archived response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for name, values := range response.Header {
+			if replayedHeaders[http.CanonicalHeaderKey(name)] {
+				continue
+			}
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+		// archived pages are served from the daemon's origin, so their scripts must not run there
+		w.Header().Set("Content-Security-Policy", "sandbox")
+		w.Header().Set("Link", "<"+record.Get(warc.FieldTargetURI)+">; rel=\"original\"")
+		if archivedAt, err := time.Parse(time.RFC3339, record.Get(warc.FieldDate)); err == nil {
+			w.Header().Set("Memento-Datetime", archivedAt.UTC().Format(http.TimeFormat))
+		}
+		w.WriteHeader(response.StatusCode)
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+		ext.HTTPStatusCode.Set(span, uint16(response.StatusCode))
+	}
+}
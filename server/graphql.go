@@ -4,9 +4,13 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/handler"
+	"github.com/gorilla/websocket"
 	"github.com/lectio/lectiod/resolvers"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -63,35 +67,97 @@ func healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, `{"alive": true}`)
 }
 
-func createExecutableSchemaHandler(o observe.Observatory, provider resolvers.ConfigPathProvider, parent opentracing.Span) http.HandlerFunc {
-	span := o.StartChildTrace("graphql.createExecutableSchemaHandler", parent)
-	defer span.Finish()
+// allowedOriginsFromEnv returns the comma separated origins in LECTIOD_ALLOWED_ORIGINS
+func allowedOriginsFromEnv() []string {
+	var result []string
+	for _, origin := range strings.Split(os.Getenv("LECTIOD_ALLOWED_ORIGINS"), ",") {
+		origin = strings.TrimSpace(origin)
+		if origin != "" {
+			result = append(result, origin)
+		}
+	}
+	return result
+}
 
+// createOriginChecker only accepts websocket upgrades and multipart requests without an Origin (clients other
+// than browsers), from the daemon's own host or from one of allowedOrigins, so other sites can't open
+// subscriptions or run mutations as a visitor
+func createOriginChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		if strings.EqualFold(parsed.Host, r.Host) {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func createSchemaHandler(o observe.Observatory, serviceHandler *resolvers.ServiceHandler, allowedOrigins []string) http.HandlerFunc {
 	var cfg resolvers.Config
-	cfg.Resolvers = resolvers.NewSchemaResolvers(o, provider, span)
+	cfg.Resolvers = serviceHandler
 
 	// TODO Add error presenter and panic handlers: https://gqlgen.com/reference/errors/
 
-	return handler.GraphQL(resolvers.NewExecutableSchema(cfg),
+	// subscriptions (e.g. harvestProgress) are served over websockets on the same endpoint
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     createOriginChecker(allowedOrigins),
+	}
+
+	graphQLHandler := handler.GraphQL(resolvers.NewExecutableSchema(cfg),
 		handler.ResolverMiddleware(createGraphQLObservableResolverMiddleware(o)),
-		handler.RequestMiddleware(createGraphQLObservableRequestMiddleware(o)))
+		handler.RequestMiddleware(createGraphQLObservableRequestMiddleware(o)),
+		handler.WebsocketUpgrader(upgrader))
+	return func(w http.ResponseWriter, r *http.Request) {
+		graphQLHandler(w, r.WithContext(resolvers.WithRequestScope(r.Context())))
+	}
 }
 
-// CreateGraphQLOverHTTPServer prepares an HTTP server to run GraphQL queries
-func CreateGraphQLOverHTTPServer(o observe.Observatory, provider resolvers.ConfigPathProvider, parent opentracing.Span) *http.Server {
-	span := o.StartChildTrace("graphql.CreateGraphQLOverHTTPServer", parent)
+// createServiceHandler loads the settings bundles and starts their harvest jobs and retention sweepers
+func createServiceHandler(o observe.Observatory, provider resolvers.ConfigPathProvider, parent opentracing.Span) *resolvers.ServiceHandler {
+	span := o.StartChildTrace("graphql.createServiceHandler", parent)
 	defer span.Finish()
 
+	serviceHandler := resolvers.NewSchemaResolvers(o, provider, span)
+	serviceHandler.StartHarvestJobs(span)
+	serviceHandler.StartRetentionSweepers()
+	return serviceHandler
+}
+
+// createServeMux routes the daemon's endpoints to serviceHandler
+func createServeMux(o observe.Observatory, serviceHandler *resolvers.ServiceHandler) *http.ServeMux {
 	// TODO Add Voyager documentation handler: https://github.com/APIs-guru/graphql-voyager
 
+	allowedOrigins := allowedOriginsFromEnv()
 	serveMux := http.NewServeMux()
 	serveMux.Handle("/", handler.Playground("Lectio", "/graphql"))
-	serveMux.Handle("/graphql", createExecutableSchemaHandler(o, provider, span))
+	serveMux.Handle("/graphql", createMultipartRequestHandler(o, createSchemaHandler(o, serviceHandler, allowedOrigins), DefaultMaxUploadBytes, allowedOrigins))
+	serveMux.Handle(resolvers.ArchiveReplayPath, createArchiveReplayHandler(o, serviceHandler))
 	serveMux.HandleFunc("/health-check", healthCheckHandler)
+	return serveMux
+}
+
+// CreateGraphQLOverHTTPServer prepares an HTTP server to run GraphQL queries
+func CreateGraphQLOverHTTPServer(o observe.Observatory, provider resolvers.ConfigPathProvider, parent opentracing.Span) *http.Server {
+	span := o.StartChildTrace("graphql.CreateGraphQLOverHTTPServer", parent)
+	defer span.Finish()
 
 	server := http.Server{
 		Addr:    ":8080",
-		Handler: serveMux,
+		Handler: createServeMux(o, createServiceHandler(o, provider, span)),
 	}
 	return &server
 }
@@ -2,14 +2,19 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/lectio/lectiod/models"
+	"github.com/lectio/lectiod/resolvers"
 	opentracing "github.com/opentracing/opentracing-go"
 	observe "github.com/shah/observe-go"
 	"github.com/stretchr/testify/suite"
@@ -20,17 +25,24 @@ var quotesRegExp = regexp.MustCompile(`["]`)
 
 type GraphQLOverHTTPServerSuite struct {
 	suite.Suite
-	observatory observe.Observatory
-	span        opentracing.Span
+	observatory    observe.Observatory
+	span           opentracing.Span
+	serviceHandler *resolvers.ServiceHandler
+	serveMux       *http.ServeMux
 }
 
 func (suite *GraphQLOverHTTPServerSuite) SetupSuite() {
 	observatory := observe.MakeObservatoryFromEnv()
 	suite.observatory = observatory
 	suite.span = observatory.StartTrace("GraphQLOverHTTPServerSuite")
+
+	// requests are served the way CreateGraphQLOverHTTPServer serves them
+	suite.serviceHandler = createServiceHandler(observatory, func(string) []string { return []string{"../conf"} }, suite.span)
+	suite.serveMux = createServeMux(observatory, suite.serviceHandler)
 }
 
 func (suite *GraphQLOverHTTPServerSuite) TearDownSuite() {
+	suite.serviceHandler.Close()
 	suite.span.Finish()
 	suite.observatory.Close()
 }
@@ -73,13 +85,12 @@ func (suite *GraphQLOverHTTPServerSuite) testGraphQLQuery(queryName string) {
 
 	// We create a ResponseRecorder (which satisfies http.ResponseWriter) to record the response.
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(createExecutableSchemaHandler(suite.observatory, func(string) []string { return []string{"../conf"} }, suite.span))
 
 	// Our handlers satisfy http.Handler, so we can call their ServeHTTP method
 	// directly and pass in our Request and ResponseRecorder with context info.
 	ctx := opentracing.ContextWithSpan(req.Context(), suite.span)
 	req = req.WithContext(ctx)
-	handler.ServeHTTP(rr, req)
+	suite.serveMux.ServeHTTP(rr, req)
 
 	suite.Equalf(http.StatusOK, rr.Code, "Invalid HTTP Status")
 	suite.JSONEq(fmt.Sprintf("%s", responseToCompareTo), rr.Body.String(), "Unexpected response")
@@ -97,6 +108,116 @@ func (suite *GraphQLOverHTTPServerSuite) TestUrlsInTextGraphQLQuery() {
 	suite.testGraphQLQuery("urlsInText")
 }
 
+func (suite *GraphQLOverHTTPServerSuite) TestWebsocketOriginCheck() {
+	checkOrigin := createOriginChecker([]string{"https://reader.example.com/"})
+	tests := []struct {
+		host    string
+		origin  string
+		allowed bool
+	}{
+		{host: "localhost:8080", origin: "", allowed: true},
+		{host: "localhost:8080", origin: "http://localhost:8080", allowed: true},
+		{host: "localhost:8080", origin: "http://LOCALHOST:8080", allowed: true},
+		{host: "localhost:8080", origin: "https://reader.example.com", allowed: true},
+		{host: "localhost:8080", origin: "https://evil.example.com", allowed: false},
+		{host: "localhost:8080", origin: "http://localhost:9090", allowed: false},
+		{host: "localhost:8080", origin: "null", allowed: false},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "/graphql", nil)
+		suite.Nil(err, "Unable to create request")
+		req.Host = test.host
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		suite.Equal(test.allowed, checkOrigin(req), "Origin %q on %s", test.origin, test.host)
+	}
+}
+
+func (suite *GraphQLOverHTTPServerSuite) TestReplayAuthorization() {
+	tests := []struct {
+		url     string
+		header  string
+		medium  models.AuthorizationClaimMedium
+		session models.AuthenticatedSessionID
+	}{
+		{url: "/archive/TEST/file/0?sessionID=SIMULATED", medium: models.AuthorizationClaimMediumParamValue, session: "SIMULATED"},
+		{url: "/archive/TEST/file/0", header: "SIMULATED", medium: models.AuthorizationClaimMediumHttpHeader, session: "SIMULATED"},
+		{url: "/archive/TEST/file/0?sessionID=PARAM", header: "HEADER", medium: models.AuthorizationClaimMediumParamValue, session: "PARAM"},
+		{url: "/archive/TEST/file/0"},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", test.url, nil)
+		suite.Nil(err, "Unable to create request")
+		if test.header != "" {
+			req.Header.Set(sessionIDHeader, test.header)
+		}
+		authorization := replayAuthorization(req)
+		suite.Equal(models.AuthorizationClaimTypeSessionId, authorization.ClaimType, test.url)
+		if test.session == "" {
+			suite.Nil(authorization.SessionID, test.url)
+			continue
+		}
+		suite.Equal(test.medium, authorization.ClaimMedium, test.url)
+		if suite.NotNil(authorization.SessionID, test.url) {
+			suite.Equal(test.session, *authorization.SessionID, test.url)
+		}
+	}
+}
+
+func (suite *GraphQLOverHTTPServerSuite) TestMultipartRequest() {
+	tests := []struct {
+		name       string
+		operations string
+		filesMap   string
+		origin     string
+		status     int
+		variables  string
+	}{
+		{name: "file variable", operations: `{"query": "query ($file: File!) { f(file: $file) }", "variables": {"file": null}}`, filesMap: `{"0": ["variables.file"]}`, status: http.StatusOK, variables: `{"file": "0"}`},
+		{name: "file in a list", operations: `{"query": "", "variables": {"files": [null, null]}}`, filesMap: `{"0": ["variables.files.1"]}`, status: http.StatusOK, variables: `{"files": [null, "0"]}`},
+		{name: "missing file", operations: `{"query": "", "variables": {"file": null}}`, filesMap: `{"1": ["variables.file"]}`, status: http.StatusBadRequest},
+		{name: "invalid path", operations: `{"query": "", "variables": {"files": []}}`, filesMap: `{"0": ["variables.files.0"]}`, status: http.StatusBadRequest},
+		{name: "batched operations", operations: `[{"query": ""}]`, filesMap: `{"0": ["0.variables.file"]}`, status: http.StatusBadRequest},
+		{name: "invalid map", operations: `{"query": ""}`, filesMap: `[]`, status: http.StatusBadRequest},
+		{name: "same origin", operations: `{"query": "", "variables": {"file": null}}`, filesMap: `{"0": ["variables.file"]}`, origin: "http://lectio.example.com", status: http.StatusOK, variables: `{"file": "0"}`},
+		{name: "allowed origin", operations: `{"query": "", "variables": {"file": null}}`, filesMap: `{"0": ["variables.file"]}`, origin: "https://app.example.com", status: http.StatusOK, variables: `{"file": "0"}`},
+		{name: "other origin", operations: `{"query": "", "variables": {"file": null}}`, filesMap: `{"0": ["variables.file"]}`, origin: "https://evil.example.org", status: http.StatusForbidden},
+	}
+	for _, test := range tests {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		writer.WriteField("operations", test.operations)
+		writer.WriteField("map", test.filesMap)
+		part, err := writer.CreateFormFile("0", "notes.md")
+		suite.Nil(err, "Unable to create the file's part")
+		part.Write([]byte("[Docs](https://example.com/docs)"))
+		writer.Close()
+
+		var forwarded map[string]json.RawMessage
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			suite.Equal("application/json", r.Header.Get("Content-Type"), test.name)
+			suite.Nil(json.NewDecoder(r.Body).Decode(&forwarded), test.name)
+		})
+		req, err := http.NewRequest("POST", "http://lectio.example.com/graphql", &body)
+		suite.Nil(err, "Unable to create request")
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		rr := httptest.NewRecorder()
+		createMultipartRequestHandler(suite.observatory, next, DefaultMaxUploadBytes, []string{"https://app.example.com"}).ServeHTTP(rr, req)
+
+		suite.Equal(test.status, rr.Code, "%s: %s", test.name, rr.Body.String())
+		if test.status == http.StatusForbidden {
+			suite.Nil(forwarded, "%s: the request shouldn't be forwarded", test.name)
+		}
+		if test.status == http.StatusOK {
+			suite.JSONEq(test.variables, string(forwarded["variables"]), test.name)
+		}
+	}
+}
+
 func TestSuite(t *testing.T) {
 	suite.Run(t, new(GraphQLOverHTTPServerSuite))
 }
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/lectio/lectiod/fetch"
+	"github.com/lectio/lectiod/resolvers"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	observe "github.com/shah/observe-go"
+)
+
+// DefaultMaxUploadBytes limits the size of a multipart GraphQL request, including all of its files, to the size
+// of the largest document the daemon fetches since uploaded files are read into memory
+const DefaultMaxUploadBytes = fetch.DefaultMaxBodyBytes
+
+// multipartMemoryBytes of a multipart request's files are kept in memory, the rest are stored in temp files
+// until the request is done
+const multipartMemoryBytes = 1024 * 1024
+
+// createMultipartRequestHandler accepts GraphQL multipart requests (https://github.com/jaydenseric/graphql-multipart-request-spec):
+// the operations part is the usual JSON request and the map part says which variables each uploaded file goes
+// into. The files are kept with the request, each File variable is set to the name of its file's part and the
+// request is passed to next as a JSON request. Browsers send multipart forms to other sites without a preflight,
+// so requests from origins which aren't allowed are refused before they're parsed.
+func createMultipartRequestHandler(o observe.Observatory, next http.Handler, maxBytes int64, allowedOrigins []string) http.HandlerFunc {
+	checkOrigin := createOriginChecker(allowedOrigins)
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if r.Method != http.MethodPost || mediaType != "multipart/form-data" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		span, _ := o.StartTraceFromContext(r.Context(), "HTTP Multipart Request")
+		defer span.Finish()
+		ext.SpanKind.Set(span, "server")
+		fail := func(err error) {
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+
+		if !checkOrigin(r) {
+			err := fmt.Errorf("Multipart requests from origin '%s' aren't allowed", r.Header.Get("Origin"))
+			ext.Error.Set(span, true)
+			span.LogFields(otlog.Error(err))
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		err := r.ParseMultipartForm(multipartMemoryBytes)
+		if err != nil {
+			fail(fmt.Errorf("Invalid multipart request: %v", err))
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		var operations map[string]interface{}
+		err = json.Unmarshal([]byte(r.FormValue("operations")), &operations)
+		if err != nil {
+			fail(fmt.Errorf("Invalid operations in multipart request, batched operations aren't supported: %v", err))
+			return
+		}
+		var filesMap map[string][]string
+		err = json.Unmarshal([]byte(r.FormValue("map")), &filesMap)
+		if err != nil {
+			fail(fmt.Errorf("Invalid map in multipart request: %v", err))
+			return
+		}
+
+		files := make(map[string]*multipart.FileHeader)
+		for name, paths := range filesMap {
+			headers := r.MultipartForm.File[name]
+			if len(headers) == 0 {
+				fail(fmt.Errorf("File '%s' listed in map is missing", name))
+				return
+			}
+			files[name] = headers[0]
+			for _, path := range paths {
+				err = setOperationsPath(operations, strings.Split(path, "."), name)
+				if err != nil {
+					fail(fmt.Errorf("Invalid path '%s' for file '%s': %v", path, name, err))
+					return
+				}
+			}
+			span.LogFields(otlog.String("file", headers[0].Filename), otlog.Int64("bytes", headers[0].Size))
+		}
+
+		body, err := json.Marshal(operations)
+		if err != nil {
+			fail(err)
+			return
+		}
+		request := r.WithContext(resolvers.WithUploadedFiles(r.Context(), files))
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		request.ContentLength = int64(len(body))
+		request.Header = make(http.Header)
+		for name, values := range r.Header {
+			request.Header[name] = values
+		}
+		request.Header.Set("Content-Type", "application/json")
+		next.ServeHTTP(w, request)
+	}
+}
+
+// setOperationsPath sets the value found by following path, object keys and array indexes, from value to the
+// name of the file's part
+func setOperationsPath(value interface{}, path []string, file string) error {
+	if len(path) == 0 {
+		return fmt.Errorf("empty path")
+	}
+	last := len(path) == 1
+	switch container := value.(type) {
+	case map[string]interface{}:
+		if last {
+			container[path[0]] = file
+			return nil
+		}
+		return setOperationsPath(container[path[0]], path[1:], file)
+	case []interface{}:
+		index, err := strconv.Atoi(path[0])
+		if err != nil || index < 0 || index >= len(container) {
+			return fmt.Errorf("no element '%s'", path[0])
+		}
+		if last {
+			container[index] = file
+			return nil
+		}
+		return setOperationsPath(container[index], path[1:], file)
+	}
+	return fmt.Errorf("'%s' isn't inside an object or array", path[0])
+}
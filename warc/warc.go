@@ -0,0 +1,211 @@
+package warc
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Version is the WARC format version written by WriteRecord
+const Version = "WARC/1.0"
+
+// Record types written and read by lectiod
+const (
+	TypeWarcinfo = "warcinfo"
+	TypeRequest  = "request"
+	TypeResponse = "response"
+)
+
+// Header field names used by lectiod
+const (
+	FieldType          = "WARC-Type"
+	FieldRecordID      = "WARC-Record-ID"
+	FieldDate          = "WARC-Date"
+	FieldTargetURI     = "WARC-Target-URI"
+	FieldConcurrentTo  = "WARC-Concurrent-To"
+	FieldFilename      = "WARC-Filename"
+	FieldTruncated     = "WARC-Truncated"
+	FieldContentType   = "Content-Type"
+	FieldContentLength = "Content-Length"
+)
+
+// Field is a single named header field of a record, fields are kept in the order they're written
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Record is a single WARC record, WriteRecord adds its WARC-Record-ID, WARC-Date and Content-Length if missing
+type Record struct {
+	Fields []Field
+	Block  []byte
+}
+
+// NewRecord constructs a record of recordType with a new record ID
+func NewRecord(recordType string, date time.Time, block []byte) *Record {
+	result := new(Record)
+	result.Set(FieldType, recordType)
+	result.Set(FieldRecordID, NewRecordID())
+	result.Set(FieldDate, date.UTC().Format(time.RFC3339))
+	result.Block = block
+	return result
+}
+
+// NewRecordID returns a unique WARC-Record-ID
+func NewRecordID() string {
+	return "<urn:uuid:" + uuid.New().String() + ">"
+}
+
+// Get returns the value of the first field named name, or "" if there's none
+func (r *Record) Get(name string) string {
+	for _, field := range r.Fields {
+		if strings.EqualFold(field.Name, name) {
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// Set replaces the value of the field named name, or adds the field if there's none
+func (r *Record) Set(name, value string) {
+	for index, field := range r.Fields {
+		if strings.EqualFold(field.Name, name) {
+			r.Fields[index].Value = value
+			return
+		}
+	}
+	r.Fields = append(r.Fields, Field{Name: name, Value: value})
+}
+
+// WriteRecord writes record to w as a gzip member of its own so it can be read from its offset in a .warc.gz
+// file, it returns the number of (compressed) bytes written
+func WriteRecord(w io.Writer, record *Record) (int64, error) {
+	if record.Get(FieldRecordID) == "" {
+		record.Set(FieldRecordID, NewRecordID())
+	}
+	if record.Get(FieldDate) == "" {
+		record.Set(FieldDate, time.Now().UTC().Format(time.RFC3339))
+	}
+	record.Set(FieldContentLength, strconv.Itoa(len(record.Block)))
+
+	var uncompressed bytes.Buffer
+	uncompressed.WriteString(Version + "\r\n")
+	for _, field := range record.Fields {
+		uncompressed.WriteString(field.Name + ": " + field.Value + "\r\n")
+	}
+	uncompressed.WriteString("\r\n")
+	uncompressed.Write(record.Block)
+	uncompressed.WriteString("\r\n\r\n")
+
+	var compressed bytes.Buffer
+	member := gzip.NewWriter(&compressed)
+	member.Write(uncompressed.Bytes())
+	err := member.Close()
+	if err != nil {
+		return 0, err
+	}
+	written, err := w.Write(compressed.Bytes())
+	return int64(written), err
+}
+
+// ReadRecord reads the record stored in the gzip member at the start of r
+func ReadRecord(r io.Reader) (*Record, error) {
+	member, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid WARC record: %v", err)
+	}
+	member.Multistream(false)
+	defer member.Close()
+
+	reader := textproto.NewReader(bufio.NewReader(member))
+	version, err := reader.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid WARC record: %v", err)
+	}
+	if !strings.HasPrefix(version, "WARC/") {
+		return nil, fmt.Errorf("Invalid WARC record: unexpected version line '%s'", version)
+	}
+
+	result := new(Record)
+	for {
+		line, err := reader.ReadLine()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid WARC record: %v", err)
+		}
+		if line == "" {
+			break
+		}
+		colon := strings.Index(line, ":")
+		if colon <= 0 {
+			return nil, fmt.Errorf("Invalid WARC record: malformed header line '%s'", line)
+		}
+		result.Fields = append(result.Fields, Field{Name: line[:colon], Value: strings.TrimSpace(line[colon+1:])})
+	}
+
+	length, err := strconv.ParseInt(result.Get(FieldContentLength), 10, 64)
+	if err != nil || length < 0 {
+		return nil, fmt.Errorf("Invalid WARC record: bad Content-Length '%s'", result.Get(FieldContentLength))
+	}
+	result.Block, err = ioutil.ReadAll(io.LimitReader(reader.R, length))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid WARC record: %v", err)
+	}
+	if int64(len(result.Block)) != length {
+		return nil, fmt.Errorf("Invalid WARC record: block has %d bytes, Content-Length is %d", len(result.Block), length)
+	}
+	return result, nil
+}
+
+// HTTPRequestBlock returns request as it's sent on the wire, the block of a request record
+func HTTPRequestBlock(request *http.Request) ([]byte, error) {
+	var result bytes.Buffer
+	err := request.Write(&result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Bytes(), nil
+}
+
+// HTTPResponseBlock returns the block of a response record. body is the body as read by the HTTP client, so the
+// original Content-Length and Transfer-Encoding are replaced by the length of body.
+func HTTPResponseBlock(proto, status string, header http.Header, body []byte) []byte {
+	var result bytes.Buffer
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	result.WriteString(proto + " " + status + "\r\n")
+	written := make(http.Header, len(header))
+	for name, values := range header {
+		written[name] = values
+	}
+	written.Del("Transfer-Encoding")
+	written.Set("Content-Length", strconv.Itoa(len(body)))
+	written.Write(&result)
+	result.WriteString("\r\n")
+	result.Write(body)
+	return result.Bytes()
+}
+
+// ReadHTTPResponse parses the block of a response record
+func ReadHTTPResponse(block []byte) (*http.Response, []byte, error) {
+	response, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(block)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, body, nil
+}
@@ -0,0 +1,100 @@
+package warc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type WARCSuite struct {
+	suite.Suite
+}
+
+func (suite *WARCSuite) TestRecordRoundTrip() {
+	date := time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC)
+	records := []*Record{
+		NewRecord(TypeResponse, date, []byte("HTTP/1.1 200 OK\r\n\r\nbody")),
+		NewRecord(TypeRequest, date, nil),
+	}
+	records[0].Set(FieldTargetURI, "https://example.com/page")
+	records[1].Set(FieldConcurrentTo, records[0].Get(FieldRecordID))
+
+	var file bytes.Buffer
+	var offsets []int64
+	for _, record := range records {
+		offsets = append(offsets, int64(file.Len()))
+		written, err := WriteRecord(&file, record)
+		suite.Nil(err, "Unable to write a record")
+		suite.Equal(int64(file.Len())-offsets[len(offsets)-1], written, "The compressed length should be returned")
+	}
+
+	for i, record := range records {
+		read, err := ReadRecord(bytes.NewReader(file.Bytes()[offsets[i]:]))
+		if !suite.Nil(err, "Unable to read record %d", i) {
+			continue
+		}
+		suite.Equal(record.Fields, read.Fields, "Record %d's fields should be kept in order", i)
+		suite.Equal(len(record.Block), len(read.Block), "Record %d's block", i)
+		suite.Equal("2018-07-01T12:00:00Z", read.Get(FieldDate))
+	}
+	suite.Equal("https://example.com/page", records[0].Get(FieldTargetURI))
+	suite.Equal("", records[0].Get("Missing-Field"))
+}
+
+func (suite *WARCSuite) TestReadInvalidRecord() {
+	compress := func(text string) []byte {
+		var result bytes.Buffer
+		member := gzip.NewWriter(&result)
+		member.Write([]byte(text))
+		member.Close()
+		return result.Bytes()
+	}
+
+	tests := map[string][]byte{
+		"not gzip":           []byte("WARC/1.0\r\n\r\n"),
+		"version":            compress("HTTP/1.1 200 OK\r\n\r\n"),
+		"malformed field":    compress("WARC/1.0\r\nno colon\r\n\r\n"),
+		"no Content-Length":  compress("WARC/1.0\r\nWARC-Type: response\r\n\r\n"),
+		"short block":        compress("WARC/1.0\r\nContent-Length: 10\r\n\r\nshort"),
+		"unterminated field": compress("WARC/1.0\r\nWARC-Type: response"),
+	}
+	for name, data := range tests {
+		_, err := ReadRecord(bytes.NewReader(data))
+		suite.NotNil(err, name)
+	}
+}
+
+func (suite *WARCSuite) TestHTTPResponseBlock() {
+	header := http.Header{}
+	header.Set("Content-Type", "text/html")
+	header.Set("Transfer-Encoding", "chunked")
+	header.Set("Content-Length", "1000")
+	body := []byte("<html>page</html>")
+
+	block := HTTPResponseBlock("", "200 OK", header, body)
+	suite.True(bytes.HasPrefix(block, []byte("HTTP/1.1 200 OK\r\n")), "The protocol should default to HTTP/1.1")
+	suite.Equal("chunked", header.Get("Transfer-Encoding"), "The fetched header shouldn't be changed")
+
+	response, read, err := ReadHTTPResponse(block)
+	if !suite.Nil(err, "The block should be a valid response") {
+		return
+	}
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("text/html", response.Header.Get("Content-Type"))
+	suite.Equal("17", response.Header.Get("Content-Length"), "The length of the body read should be written")
+	suite.Equal(body, read)
+
+	request, err := http.NewRequest("GET", "https://example.com/page", nil)
+	suite.Nil(err, "Unable to create request")
+	requestBlock, err := HTTPRequestBlock(request)
+	suite.Nil(err, "Unable to write the request")
+	suite.True(bytes.HasPrefix(requestBlock, []byte("GET /page HTTP/1.1\r\nHost: example.com\r\n")), string(requestBlock))
+}
+
+func TestWARCSuite(t *testing.T) {
+	suite.Run(t, new(WARCSuite))
+}